@@ -0,0 +1,186 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/confp/generic"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/rlp"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	forkidCommand = cli.Command{
+		Name:  "forkid",
+		Usage: "EIP-2124 fork ID tools",
+		Subcommands: []cli.Command{
+			forkidListCommand,
+			forkidCheckCommand,
+		},
+	}
+	forkidListCommand = cli.Command{
+		Name:      "list",
+		Usage:     "Lists the fork IDs of a chain, from genesis through every fork",
+		Action:    forkidList,
+		ArgsUsage: "<network|chainspec.json>",
+	}
+	forkidCheckCommand = cli.Command{
+		Name:      "check",
+		Usage:     "Classifies the nodes of a node set by the compatibility of their fork IDs",
+		Action:    forkidCheck,
+		ArgsUsage: "<network|chainspec.json> <nodes.json>",
+		Flags:     []cli.Flag{forkidHeadFlag, forkidWriteFlag},
+	}
+	forkidHeadFlag = cli.Uint64Flag{
+		Name:  "head",
+		Usage: "Local head block number to check at (defaults to genesis)",
+	}
+	forkidWriteFlag = cli.StringFlag{
+		Name:  "write",
+		Usage: "Write the nodes of the given class (compatible, stale, incompatible, unknown) as a node set",
+	}
+)
+
+// Fork ID compatibility classes of nodes.
+const (
+	forkidCompatible   = "compatible"   // accepted by the filter
+	forkidStale        = "stale"        // rejected, the remote node needs an update
+	forkidIncompatible = "incompatible" // rejected, the chains diverged, or the local node needs an update
+	forkidUnknown      = "unknown"      // the node record has no eth entry
+)
+
+var forkidClasses = []string{forkidCompatible, forkidStale, forkidIncompatible, forkidUnknown}
+
+func forkidList(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("need network name or chainspec file as argument")
+	}
+	genesis, err := loadChainspec(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	hash := genesisHash(genesis)
+	fmt.Printf("Genesis: %s\n", hash.Hex())
+	for _, head := range append([]uint64{0}, confp.Forks(genesis.Config)...) {
+		id := forkid.NewIDFromConfig(genesis.Config, hash, head)
+		fmt.Printf("block %-10d hash %#x next %d\n", head, id.Hash, id.Next)
+	}
+	return nil
+}
+
+func forkidCheck(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("need network name or chainspec file, and nodes file as arguments")
+	}
+	genesis, err := loadChainspec(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	hash := genesisHash(genesis)
+	filter := forkid.NewStaticFilter(genesis.Config, hash)
+	if ctx.IsSet(forkidHeadFlag.Name) {
+		filter = forkid.NewFilterFromConfig(genesis.Config, hash, ctx.Uint64(forkidHeadFlag.Name))
+	}
+	ns := loadNodesJSON(ctx.Args().Get(1))
+	classes := classifyNodes(ns, filter)
+
+	if class := ctx.String(forkidWriteFlag.Name); class != "" {
+		if _, ok := classes[class]; !ok {
+			return fmt.Errorf("invalid class %q", class)
+		}
+		writeNodesJSON("-", classes[class])
+		return nil
+	}
+	fmt.Printf("Set contains %d nodes.\n", len(ns))
+	for _, class := range forkidClasses {
+		fmt.Printf("%-14s %d\n", class+":", len(classes[class]))
+	}
+	return nil
+}
+
+// classifyNodes sorts the nodes of a set by the compatibility of their fork IDs.
+func classifyNodes(ns nodeSet, filter forkid.Filter) map[string]nodeSet {
+	classes := make(map[string]nodeSet)
+	for _, class := range forkidClasses {
+		classes[class] = make(nodeSet)
+	}
+	for id, n := range ns {
+		var eth struct {
+			ForkID forkid.ID
+			_      []rlp.RawValue `rlp:"tail"`
+		}
+		if n.N.Load(enr.WithEntry("eth", &eth)) != nil {
+			classes[forkidUnknown][id] = n
+			continue
+		}
+		switch filter(eth.ForkID) {
+		case nil:
+			classes[forkidCompatible][id] = n
+		case forkid.ErrRemoteStale:
+			classes[forkidStale][id] = n
+		default:
+			classes[forkidIncompatible][id] = n
+		}
+	}
+	return classes
+}
+
+// loadChainspec loads the genesis of a built-in network, or from a chainspec file
+// in any of the supported formats.
+func loadChainspec(arg string) (*genesisT.Genesis, error) {
+	if n := params.NetworkByName(arg); n != nil {
+		return n.Genesis(), nil
+	}
+	data, err := ioutil.ReadFile(arg)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := generic.UnmarshalChainConfigurator(data)
+	if err != nil {
+		return nil, err
+	}
+	// Chainspecs like Parity's include the genesis; convert them.
+	if spec, ok := conf.(ctypes.GenesisBlocker); ok {
+		genesis := &genesisT.Genesis{Config: &coregeth.CoreGethChainConfig{}}
+		if err := confp.Convert(spec, genesis); err != nil {
+			return nil, err
+		}
+		return genesis, nil
+	}
+	genesis := new(genesisT.Genesis)
+	if err := json.Unmarshal(data, genesis); err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// genesisHash returns the hash of the genesis block of a chainspec.
+func genesisHash(genesis *genesisT.Genesis) common.Hash {
+	return core.GenesisToBlock(genesis, nil).Hash()
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+type testEthEntry struct {
+	ForkID forkid.ID
+	Rest   []rlp.RawValue `rlp:"tail"`
+}
+
+func (e testEthEntry) ENRKey() string { return "eth" }
+
+func testForkIDNode(id byte, entry *testEthEntry) nodeJSON {
+	var r enr.Record
+	if entry != nil {
+		r.Set(entry)
+	}
+	return nodeJSON{N: enode.SignNull(&r, enode.ID{id})}
+}
+
+func TestClassifyNodes(t *testing.T) {
+	var (
+		config  = params.ClassicChainConfig
+		hash    = params.MainnetGenesisHash
+		phoenix = uint64(10500839)
+	)
+	ns := nodeSet{
+		// Passed Phoenix, as the local node.
+		enode.ID{1}: testForkIDNode(1, &testEthEntry{ForkID: forkid.NewIDFromConfig(config, hash, phoenix)}),
+		// Syncing, aware of Phoenix.
+		enode.ID{2}: testForkIDNode(2, &testEthEntry{ForkID: forkid.NewIDFromConfig(config, hash, phoenix-1)}),
+		// Unaware of Phoenix.
+		enode.ID{3}: testForkIDNode(3, &testEthEntry{ForkID: forkid.ID{Hash: forkid.NewIDFromConfig(config, hash, phoenix-1).Hash}}),
+		// On another chain.
+		enode.ID{4}: testForkIDNode(4, &testEthEntry{ForkID: forkid.ID{Hash: [4]byte{0xde, 0xad, 0xbe, 0xef}}}),
+		// Without eth entry.
+		enode.ID{5}: testForkIDNode(5, nil),
+	}
+	classes := classifyNodes(ns, forkid.NewFilterFromConfig(config, hash, phoenix+1))
+	want := map[string][]enode.ID{
+		forkidCompatible:   {{1}, {2}},
+		forkidStale:        {{3}},
+		forkidIncompatible: {{4}},
+		forkidUnknown:      {{5}},
+	}
+	for class, ids := range want {
+		if len(classes[class]) != len(ids) {
+			t.Errorf("%s: got %d nodes, want %d", class, len(classes[class]), len(ids))
+		}
+		for _, id := range ids {
+			if _, ok := classes[class][id]; !ok {
+				t.Errorf("%s: missing node %v", class, id)
+			}
+		}
+	}
+}
+
+// Tests that chainspec files yield the fork IDs of the chains they define.
+func TestLoadChainspecFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devp2p-forkid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	mordor := new(parity.ParityChainSpec)
+	if err := confp.Convert(params.DefaultMordorGenesisBlock(), mordor); err != nil {
+		t.Fatalf("failed to convert chainspec: %v", err)
+	}
+	tests := []struct {
+		name      string
+		chainspec interface{}
+		head      uint64
+		want      forkid.ID
+	}{
+		// Ethereum Classic just before Phoenix, as a core-geth genesis
+		{"classic.json", params.DefaultClassicGenesisBlock(), 10500838, forkid.ID{Hash: [4]byte{0x7b, 0xa2, 0x28, 0x82}, Next: 10500839}},
+		// Mordor at Agharta, as a Parity chainspec
+		{"mordor.json", mordor, 301243, forkid.ID{Hash: [4]byte{0x60, 0x4f, 0x6e, 0xe1}, Next: 999983}},
+	}
+	for _, tt := range tests {
+		blob, err := json.Marshal(tt.chainspec)
+		if err != nil {
+			t.Fatalf("%s: failed to encode chainspec: %v", tt.name, err)
+		}
+		path := filepath.Join(dir, tt.name)
+		if err := ioutil.WriteFile(path, blob, 0644); err != nil {
+			t.Fatal(err)
+		}
+		genesis, err := loadChainspec(path)
+		if err != nil {
+			t.Fatalf("%s: failed to load chainspec: %v", tt.name, err)
+		}
+		if have := forkid.NewIDFromConfig(genesis.Config, genesisHash(genesis), tt.head); have != tt.want {
+			t.Errorf("%s: fork ID mismatch: have %x, want %x", tt.name, have, tt.want)
+		}
+	}
+}
@@ -62,6 +62,7 @@ func init() {
 		discv5Command,
 		dnsCommand,
 		nodesetCommand,
+		forkidCommand,
 	}
 }
 
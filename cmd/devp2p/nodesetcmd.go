@@ -172,7 +172,11 @@ func ethFilter(args []string) (nodeFilter, error) {
 	case "mordor":
 		filter = forkid.NewStaticFilter(params.MordorChainConfig, params.MordorGenesisHash)
 	default:
-		return nil, fmt.Errorf("unknown network %q", args[0])
+		genesis, err := loadChainspec(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("unknown network %q: %v", args[0], err)
+		}
+		filter = forkid.NewStaticFilter(genesis.Config, genesisHash(genesis))
 	}
 
 	f := func(n nodeJSON) bool {
@@ -0,0 +1,74 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var diffCommand = cli.Command{
+	Name:  "diff",
+	Usage: "Show all differences to another configuration",
+	Description: `Compares the configuration with another one, given with the command's own --default, or --file and --inputf flags.
+Every divergent transition, reward schedule entry, difficulty bomb delay, consensus parameter and genesis field is reported.
+Exits 0 if the configurations are identical, 1 if not.`,
+	Flags: []cli.Flag{
+		formatInFlag,
+		fileInFlag,
+		defaultValueFlag,
+	},
+	Action: diff,
+}
+
+var errNoDiffChainspecValue = errors.New("no configuration to compare with, use --default or --file")
+
+func diff(ctx *cli.Context) error {
+	var (
+		other ctypes.Configurator
+		err   error
+	)
+	switch {
+	case ctx.IsSet(defaultValueFlag.Name):
+		other, err = getDefaultChainspecValue(ctx.String(defaultValueFlag.Name))
+	case ctx.IsSet(fileInFlag.Name):
+		var data []byte
+		data, err = ioutil.ReadFile(ctx.String(fileInFlag.Name))
+		if err != nil {
+			return err
+		}
+		other, err = unmarshalChainSpec(ctx.String(formatInFlag.Name), data)
+	default:
+		return errNoDiffChainspecValue
+	}
+	if err != nil {
+		return err
+	}
+	diffs := confp.Diff(globalChainspecValue, other)
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	if len(diffs) > 0 {
+		return cli.NewExitError(fmt.Sprintf("%d differences found", len(diffs)), 1)
+	}
+	return nil
+}
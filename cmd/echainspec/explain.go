@@ -0,0 +1,158 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	blockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Block number [|0x042|0x42|42]",
+	}
+
+	explainCommand = cli.Command{
+		Name:   "explain",
+		Usage:  "Show the features active at a block",
+		Flags:  []cli.Flag{blockFlag},
+		Action: explain,
+	}
+)
+
+var (
+	errNoBlock     = errors.New("missing --block")
+	errNoEraRounds = errors.New("ECIP1017 transition set without era rounds")
+)
+
+// precompileNames are the names of the precompiled contracts defined by the protocol.
+var precompileNames = map[common.Address]string{
+	common.BytesToAddress([]byte{1}):  "ecrecover",
+	common.BytesToAddress([]byte{2}):  "sha256",
+	common.BytesToAddress([]byte{3}):  "ripemd160",
+	common.BytesToAddress([]byte{4}):  "identity",
+	common.BytesToAddress([]byte{5}):  "modexp",
+	common.BytesToAddress([]byte{6}):  "bn256Add",
+	common.BytesToAddress([]byte{7}):  "bn256ScalarMul",
+	common.BytesToAddress([]byte{8}):  "bn256Pairing",
+	common.BytesToAddress([]byte{9}):  "blake2F",
+	common.BytesToAddress([]byte{10}): "bls12381G1Add",
+	common.BytesToAddress([]byte{11}): "bls12381G1Mul",
+	common.BytesToAddress([]byte{12}): "bls12381G1MultiExp",
+	common.BytesToAddress([]byte{13}): "bls12381G2Add",
+	common.BytesToAddress([]byte{14}): "bls12381G2Mul",
+	common.BytesToAddress([]byte{15}): "bls12381G2MultiExp",
+	common.BytesToAddress([]byte{16}): "bls12381Pairing",
+	common.BytesToAddress([]byte{17}): "bls12381MapG1",
+	common.BytesToAddress([]byte{18}): "bls12381MapG2",
+}
+
+func explain(ctx *cli.Context) error {
+	if !ctx.IsSet(blockFlag.Name) {
+		return errNoBlock
+	}
+	var n math.HexOrDecimal64
+	if err := n.UnmarshalText([]byte(ctx.String(blockFlag.Name))); err != nil {
+		return err
+	}
+	return explainBlock(os.Stdout, globalChainspecValue, new(big.Int).SetUint64(uint64(n)))
+}
+
+// explainBlock writes the features of the configuration active at the block.
+func explainBlock(w io.Writer, conf ctypes.ChainConfigurator, bn *big.Int) error {
+	fmt.Fprintln(w, "Block:", bn)
+	fmt.Fprintln(w, "Consensus engine:", conf.GetConsensusEngineType())
+
+	// Transitions (EIPs, ECIPs, ...)
+	fmt.Fprintln(w, "Features:")
+	fns, names := confp.Transitions(conf)
+	for i, fn := range fns {
+		if !conf.IsEnabled(fn, bn) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(names[i], "Get"), "Transition")
+		fmt.Fprintf(w, "  %s (block %d)\n", name, *fn())
+	}
+
+	// Precompiled contracts
+	fmt.Fprintln(w, "Precompiles:")
+	addrs := vm.ActivePrecompiles(conf, bn)
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	custom := conf.GetCustomPrecompiles()
+	for _, addr := range addrs {
+		name, ok := precompileNames[addr]
+		if p, isCustom := custom[addr]; isCustom {
+			name, ok = "custom: "+p.Builtin, true
+		}
+		if !ok {
+			name = "unknown"
+		}
+		fmt.Fprintf(w, "  %s %s\n", addr.Hex(), name)
+	}
+
+	// Opcodes, relative to Frontier
+	var (
+		frontier = make(map[vm.OpCode]bool)
+		added    []string
+	)
+	for _, op := range vm.ActiveOpCodes(&coregeth.CoreGethChainConfig{}, bn) {
+		frontier[op] = true
+	}
+	for _, op := range vm.ActiveOpCodes(conf, bn) {
+		if !frontier[op] {
+			added = append(added, op.String())
+		}
+	}
+	fmt.Fprintln(w, "Opcodes added since Frontier:")
+	fmt.Fprintf(w, "  %s\n", strings.Join(added, " "))
+
+	// Block reward
+	if conf.GetConsensusEngineType().IsEthash() {
+		if conf.IsEnabled(conf.GetEthashECIP1017Transition, bn) {
+			rounds := conf.GetEthashECIP1017EraRounds()
+			if rounds == nil || *rounds == 0 {
+				return errNoEraRounds
+			}
+			era := ethash.GetBlockEra(bn, new(big.Int).SetUint64(*rounds))
+			fmt.Fprintln(w, "Block reward:", ethash.BlockWinnerReward(conf, bn), "wei", fmt.Sprintf("(ECIP1017 era %d)", era.Uint64()+1))
+		} else {
+			fmt.Fprintln(w, "Block reward:", ethash.BlockWinnerReward(conf, bn), "wei")
+		}
+		if split, ok := ctypes.EthashBlockRewardSplit(conf, bn); ok {
+			fmt.Fprintf(w, "Block reward split: %d%% to treasury %s\n", split.Percentage, split.Address.Hex())
+		}
+	}
+	return nil
+}
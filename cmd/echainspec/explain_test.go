@@ -0,0 +1,76 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+func TestExplainClassic(t *testing.T) {
+	classic, err := getDefaultChainspecValue("classic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		number  int64
+		want    []string
+		notWant []string
+	}{
+		// Atlantis
+		{
+			number:  8772000,
+			want:    []string{"EIP198 (block 8772000)", "0x0000000000000000000000000000000000000005 modexp", "REVERT", "Block reward: 4000000000000000000 wei (ECIP1017 era 2)"},
+			notWant: []string{"EIP1344", "blake2F", "CHAINID"},
+		},
+		// Phoenix
+		{
+			number: 10500839,
+			want:   []string{"EIP1344 (block 10500839)", "0x0000000000000000000000000000000000000009 blake2F", "CHAINID", "Block reward: 3200000000000000000 wei (ECIP1017 era 3)"},
+		},
+	}
+	for _, tt := range tests {
+		out := new(bytes.Buffer)
+		if err := explainBlock(out, classic, big.NewInt(tt.number)); err != nil {
+			t.Fatalf("block %d: failed to explain: %v", tt.number, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("block %d: missing %q in output:\n%s", tt.number, want, out)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(out.String(), notWant) {
+				t.Errorf("block %d: unexpected %q in output:\n%s", tt.number, notWant, out)
+			}
+		}
+	}
+}
+
+func TestExplainMissingEraRounds(t *testing.T) {
+	n := uint64(0)
+	conf := &coregeth.CoreGethChainConfig{Ethash: new(ctypes.EthashConfig)}
+	conf.SetEthashECIP1017Transition(&n)
+	if err := explainBlock(new(bytes.Buffer), conf, big.NewInt(1)); err != errNoEraRounds {
+		t.Errorf("error mismatch: have %v, want %v", err, errNoEraRounds)
+	}
+}
@@ -9,11 +9,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
 	"github.com/ethereum/go-ethereum/params/types/multigethv0"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
 	"github.com/ethereum/go-ethereum/params/types/parity"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -32,7 +34,9 @@ var (
 		"geth": &genesisT.Genesis{
 			Config: &goethereum.ChainConfig{},
 		},
-		"parity": &parity.ParityChainSpec{},
+		"parity":     &parity.ParityChainSpec{},
+		"besu":       &besu.BesuGenesis{},
+		"nethermind": &nethermind.NethermindChainSpec{},
 		// TODO
 		// "aleth"
 		// "retesteth"
@@ -109,12 +113,9 @@ func mustGetChainspecValue(ctx *cli.Context) error {
 		}
 	}
 	if ctx.GlobalIsSet(defaultValueFlag.Name) {
-		if ctx.GlobalString(defaultValueFlag.Name) == "" {
-			return errNoChainspecValue
-		}
-		v, ok := defaultChainspecValues[ctx.GlobalString(defaultValueFlag.Name)]
-		if !ok {
-			return fmt.Errorf("error: %v, name: %s", errInvalidDefaultValue, ctx.GlobalString(defaultValueFlag.Name))
+		v, err := getDefaultChainspecValue(ctx.GlobalString(defaultValueFlag.Name))
+		if err != nil {
+			return err
 		}
 		globalChainspecValue = v
 		return nil
@@ -131,6 +132,17 @@ func mustGetChainspecValue(ctx *cli.Context) error {
 	return nil
 }
 
+func getDefaultChainspecValue(name string) (ctypes.Configurator, error) {
+	if name == "" {
+		return nil, errNoChainspecValue
+	}
+	v, ok := defaultChainspecValues[name]
+	if !ok {
+		return nil, fmt.Errorf("error: %v, name: %s", errInvalidDefaultValue, name)
+	}
+	return v, nil
+}
+
 func convertf(ctx *cli.Context) error {
 	c, ok := chainspecFormatTypes[ctx.String(outputFormatFlag.Name)]
 	if !ok && ctx.String(outputFormatFlag.Name) == "" {
@@ -196,11 +208,11 @@ EXAMPLES:
 
 	Convert an external chain configuration between client formats (from STDIN)
 .
-		> cat my-parity-spec.json | {{.Name}} --inputf parity --outputf [geth|coregeth]
+		> cat my-parity-spec.json | {{.Name}} --inputf parity --outputf [geth|coregeth|besu|nethermind]
 
 	Convert an external chain configuration between client formats (from file).
 
-		> {{.Name}} --inputf parity --file my-parity-spec.json --outputf [geth|coregeth]
+		> {{.Name}} --inputf parity --file my-parity-spec.json --outputf [geth|coregeth|besu|nethermind]
 
 	Print a default Ethereum Classic network chain configuration in coregeth format:
 	
@@ -210,6 +222,18 @@ EXAMPLES:
 	
 		> {{.Name}} --default kotti validate 3000000
 
+	Lint a chain configuration, reporting all findings with their severities:
+
+		> {{.Name}} --inputf coregeth --file my-genesis.json validate --strict
+
+	Show the differences between the default Ethereum Classic and Mordor network chain configurations:
+
+		> {{.Name}} --default classic diff --default mordor
+
+	Explain the features of the default Ethereum Classic network chain configuration at block #10500839:
+
+		> {{.Name}} --default classic explain --block 10500839
+
 VERSION:
    {{.Version}}
 
@@ -233,6 +257,8 @@ GLOBAL OPTIONS:
 		validateCommand,
 		forksCommand,
 		ipsCommand,
+		diffCommand,
+		explainCommand,
 	}
 	app.Before = mustGetChainspecValue
 	app.Action = convertf
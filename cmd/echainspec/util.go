@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/params/types/coregeth"
@@ -20,8 +21,21 @@ func readInputData(ctx *cli.Context) ([]byte, error) {
 	return ioutil.ReadFile(ctx.GlobalString(fileInFlag.Name))
 }
 
+// newChainspecValue returns a new, empty value of the data type for the given format.
+func newChainspecValue(format string) (ctypes.Configurator, bool) {
+	proto, ok := chainspecFormatTypes[format]
+	if !ok {
+		return nil, false
+	}
+	if g, ok := proto.(*genesisT.Genesis); ok {
+		config := reflect.New(reflect.TypeOf(g.Config).Elem()).Interface().(ctypes.ChainConfigurator)
+		return &genesisT.Genesis{Config: config}, true
+	}
+	return reflect.New(reflect.TypeOf(proto).Elem()).Interface().(ctypes.Configurator), true
+}
+
 func unmarshalChainSpec(format string, data []byte) (conf ctypes.Configurator, err error) {
-	conf, ok := chainspecFormatTypes[format]
+	conf, ok := newChainspecValue(format)
 	if !ok {
 		return nil, errInvalidChainspecValue
 	}
@@ -43,12 +57,12 @@ func unmarshalChainSpec(format string, data []byte) (conf ctypes.Configurator, e
 	var d dec
 	if format == "geth" {
 		d.Config = &goethereum.ChainConfig{}
-	} else if format == "multigeth" {
+	} else if format == "multigeth" || format == "coregeth" {
 		d.Config = &coregeth.CoreGethChainConfig{}
 	} else {
 		panic("impossible")
 	}
-	t := chainspecFormatTypes[format].(*genesisT.Genesis)
+	t := conf.(*genesisT.Genesis)
 	err = json.Unmarshal(data, &d)
 	if err != nil {
 		return conf, err
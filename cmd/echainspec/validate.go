@@ -9,12 +9,18 @@ import (
 	"gopkg.in/urfave/cli.v1"
 )
 
+var validateStrictFlag = cli.BoolFlag{
+	Name:  "strict",
+	Usage: "Run the configuration linter, reporting all findings, and failing on errors",
+}
+
 var validateCommand = cli.Command{
 	Name:        "validate",
 	Aliases:     []string{"valid"},
 	Description: "Exits 0 if valid, 1 if not.",
 	Usage:       "Tests whether a configuration is valid",
 	ArgsUsage:   "[|0x042|0x42|42]",
+	Flags:       []cli.Flag{validateStrictFlag},
 	Action:      validate,
 }
 
@@ -34,6 +40,15 @@ func validate(ctx *cli.Context) error {
 		log.Println(err)
 		os.Exit(1)
 	}
+	if ctx.Bool(validateStrictFlag.Name) {
+		findings := confp.Lint(globalChainspecValue)
+		for _, f := range findings {
+			log.Println(f)
+		}
+		if len(confp.LintErrors(findings)) > 0 {
+			os.Exit(1)
+		}
+	}
 	log.Println("Valid")
 	os.Exit(0)
 	return nil
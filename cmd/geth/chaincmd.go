@@ -17,14 +17,20 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
@@ -34,12 +40,17 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -121,6 +132,63 @@ Optional second and third arguments control the first and
 last block to write. In this mode, the file will be appended
 if already existing. If the file ends with .gz, the output will
 be gzipped.`,
+	}
+	exportHistoryCommand = cli.Command{
+		Action:    utils.MigrateFlags(exportHistory),
+		Name:      "export-history",
+		Usage:     "Export the ancient store into history archives",
+		ArgsUsage: "<dir> [<blockNumFirst> <blockNumLast>]",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AncientFlag,
+			utils.CacheFlag,
+			utils.RopstenFlag,
+			utils.RinkebyFlag,
+			utils.GoerliFlag,
+			utils.LegacyTestnetFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The export-history command writes the headers, bodies, receipts and total
+difficulties of the ancient store into history archives (.erh files) in the
+given directory, one per epoch of 8192 blocks, named after the network, the
+epoch and the root of the accumulator of the block hashes and total difficulties
+of the file. The archives are not era1 files, and can't be read as such.
+The SHA-256 checksums of the files are recorded in checksums.txt.
+
+Optional second and third arguments select the epochs covering the given block
+range, all the ancient store is exported by default. Epochs are always written
+whole, only the epoch at the end of the ancient store may be partial, and it is
+replaced by later exports.`,
+	}
+	importHistoryCommand = cli.Command{
+		Action:    utils.MigrateFlags(importHistory),
+		Name:      "import-history",
+		Usage:     "Import history archives into the ancient store",
+		ArgsUsage: "<dir>",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AncientFlag,
+			utils.CacheFlag,
+			utils.RopstenFlag,
+			utils.RinkebyFlag,
+			utils.GoerliFlag,
+			utils.LegacyTestnetFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The import-history command appends the blocks of the history archives of the
+network in the given directory to the ancient store, continuing from its current
+end. The files are checked against checksums.txt and their accumulators, and each
+block against its header and parent, but the blocks are not executed.
+
+The database must not hold blocks beyond the ancient store. A database holding
+only the genesis is initialized from the imported blocks on the next start of
+the node, which then syncs the state and the recent blocks from the network.`,
 	}
 	importPreimagesCommand = cli.Command{
 		Action:    utils.MigrateFlags(importPreimages),
@@ -171,6 +239,8 @@ The export-preimages command export hash preimages to an RLP encoded stream`,
 			utils.GoerliFlag,
 			utils.LegacyTestnetFlag,
 			utils.KottiFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
@@ -220,10 +290,109 @@ Use "ethereum dump 0" to dump the genesis block.`,
 			utils.RinkebyFlag,
 			utils.GoerliFlag,
 			utils.LegacyTestnetFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
 			utils.SyncModeFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 	}
+	backfillSupplyCommand = cli.Command{
+		Action:    utils.MigrateFlags(backfillSupply),
+		Name:      "backfill-supply",
+		Usage:     "Index the ether supply and issuance of the blocks in the database",
+		ArgsUsage: " ",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AncientFlag,
+			utils.CacheFlag,
+			utils.RopstenFlag,
+			utils.RinkebyFlag,
+			utils.GoerliFlag,
+			utils.LegacyTestnetFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
+			utils.SyncModeFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The backfill-supply command re-executes the blocks of an existing database to
+index the ether supply and issuance of each of them, as a node running with
+--supplyindex would. It continues from the last indexed block, and stops once
+all the complete index sections of the chain are indexed.`,
+	}
+	reexecCommand = cli.Command{
+		Action:    utils.MigrateFlagsExcept(reexecBlocks, utils.ReexecConfigFlag.Name),
+		Name:      "reexec",
+		Usage:     "Re-execute a range of blocks and verify their results against the chain",
+		ArgsUsage: " ",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AncientFlag,
+			utils.CacheFlag,
+			utils.RopstenFlag,
+			utils.RinkebyFlag,
+			utils.GoerliFlag,
+			utils.LegacyTestnetFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
+			utils.ReexecFromFlag,
+			utils.ReexecToFlag,
+			utils.ReexecConfigFlag,
+			utils.ReexecDepthFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+geth reexec --from <number> [--to <number>] [--config <spec.json>]
+
+The reexec command loads the state of the block given by --from, and replays the
+blocks after it up to --to, comparing the state root, receipts root, bloom and
+gas used resulting from each of them to its canonical header. If the state of
+the first block is missing, it's regenerated from the nearest available state
+at most --reexec blocks before it.
+
+The blocks are processed under the configuration of the chain spec given by
+--config, whose genesis must be the one of the database, or the stored chain
+configuration by default. The database is not modified.
+
+The first diverging block is reported along with the accounts it changes, their
+state before the block, after its re-execution, and after its canonical
+execution if still available. The accounts are taken from the state diffs, so
+those changed outside of the EVM, like reward recipients, are included.`,
+	}
+	dumpBadBlockCommand = cli.Command{
+		Action:    utils.MigrateFlags(dumpBadBlock),
+		Name:      "dump-badblock",
+		Usage:     "Dump a bad block along with its traces into a bundle",
+		ArgsUsage: "[<blockHash> <dir>]",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AncientFlag,
+			utils.CacheFlag,
+			utils.RopstenFlag,
+			utils.RinkebyFlag,
+			utils.GoerliFlag,
+			utils.LegacyTestnetFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
+			utils.ReexecDepthFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The dump-badblock command writes a block rejected by the chain into the given
+directory, to reproduce its rejection elsewhere:
+
+  block.rlp     the RLP encoded block
+  parent.rlp    the RLP encoded header of its parent
+  config.json   the chain configuration, as hashed into the rejection record
+  badblock.json the rejection record, as returned by debug_getBadBlocks
+  trace-*.jsonl the standard traces of its transactions
+
+The traces need the state of the parent of the block, which is regenerated from
+the nearest available state at most --reexec blocks before it if missing. The
+bundle is written without them if the block can't be traced.
+
+Without arguments, the bad blocks kept in the database are listed.`,
+	}
 )
 
 // initGenesis will initialise the given JSON format genesis file and writes it as
@@ -245,6 +414,19 @@ func initGenesis(ctx *cli.Context) error {
 	if err != nil {
 		utils.Fatalf("invalid genesis file: %v", err)
 	}
+	if genesis.Config != nil {
+		findings := confp.Lint(genesis.Config)
+		for _, f := range findings {
+			if f.Severity == confp.LintError {
+				log.Error("Invalid chain configuration", "rule", f.Rule, "err", f.Message)
+			} else {
+				log.Warn("Suspicious chain configuration", "rule", f.Rule, "severity", f.Severity, "msg", f.Message)
+			}
+		}
+		if errs := confp.LintErrors(findings); len(errs) > 0 {
+			utils.Fatalf("Invalid chain configuration: %d lint errors", len(errs))
+		}
+	}
 
 	log.Info("Initialising genesis", "config", genesis.Config)
 	// Open an initialise both full and light databases
@@ -405,6 +587,53 @@ func exportChain(ctx *cli.Context) error {
 	return nil
 }
 
+// exportHistory exports the ancient store into history archives.
+func exportHistory(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 3 {
+		utils.Fatalf("This command requires one or three arguments.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack)
+	defer db.Close()
+
+	first, last := uint64(0), uint64(math.MaxUint64)
+	if len(ctx.Args()) == 3 {
+		var ferr, lerr error
+		first, ferr = strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		last, lerr = strconv.ParseUint(ctx.Args().Get(2), 10, 64)
+		if ferr != nil || lerr != nil {
+			utils.Fatalf("Export error in parsing parameters: block number not an integer\n")
+		}
+	}
+	start := time.Now()
+	if err := utils.ExportHistory(db, ctx.Args().First(), utils.MakeNetworkName(ctx), first, last); err != nil {
+		utils.Fatalf("Export error: %v\n", err)
+	}
+	fmt.Printf("Export done in %v\n", time.Since(start))
+	return nil
+}
+
+// importHistory imports history archives into the ancient store.
+func importHistory(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack)
+	defer db.Close()
+
+	start := time.Now()
+	if err := utils.ImportHistory(db, ctx.Args().First(), utils.MakeNetworkName(ctx)); err != nil {
+		utils.Fatalf("Import error: %v\n", err)
+	}
+	fmt.Printf("Import done in %v\n", time.Since(start))
+	return nil
+}
+
 // importPreimages imports preimage data from the specified file.
 func importPreimages(ctx *cli.Context) error {
 	if len(ctx.Args()) < 1 {
@@ -606,6 +835,214 @@ func inspect(ctx *cli.Context) error {
 	return rawdb.InspectDatabase(chainDb)
 }
 
+func backfillSupply(ctx *cli.Context) error {
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack, false)
+	defer chainDb.Close()
+	defer chain.Stop()
+
+	head := chain.CurrentBlock().NumberU64()
+	target := (head + 1) / eth.SupplySectionSize
+	if target == 0 {
+		log.Info("Chain too short to index its supply", "head", head, "section", eth.SupplySectionSize)
+		return nil
+	}
+	indexer := eth.NewSupplyIndexer(chainDb, chain, eth.SupplySectionSize, 0)
+	defer indexer.Close()
+	indexer.Start(chain)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	var (
+		start  = time.Now()
+		report = time.NewTicker(8 * time.Second)
+	)
+	defer report.Stop()
+	for {
+		sections, _, _ := indexer.Sections()
+		if sections >= target {
+			log.Info("Indexed supply", "blocks", sections*eth.SupplySectionSize, "elapsed", common.PrettyDuration(time.Since(start)))
+			return nil
+		}
+		select {
+		case <-report.C:
+			log.Info("Indexing supply", "blocks", sections*eth.SupplySectionSize, "head", head, "elapsed", common.PrettyDuration(time.Since(start)))
+		case <-interrupt:
+			log.Info("Interrupted, supply indexing can be resumed later", "blocks", sections*eth.SupplySectionSize)
+			return nil
+		}
+	}
+}
+
+// reexecBlocks re-executes a range of blocks, reporting the first one diverging
+// from its canonical header.
+func reexecBlocks(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	var spec *genesisT.Genesis
+	if path := ctx.String(utils.ReexecConfigFlag.Name); path != "" {
+		blob, err := ioutil.ReadFile(path)
+		if err != nil {
+			utils.Fatalf("Failed to read chain spec: %v", err)
+		}
+		spec = new(genesisT.Genesis)
+		if err := spec.UnmarshalJSON(blob); err != nil {
+			utils.Fatalf("Invalid chain spec: %v", err)
+		}
+		if spec.Config == nil {
+			utils.Fatalf("Chain spec without configuration")
+		}
+	}
+	var config ctypes.ChainConfigurator
+	if spec != nil {
+		config = spec.Config
+	}
+	chain, chainDb := utils.MakeChainWithConfig(ctx, stack, true, config)
+	defer chainDb.Close()
+
+	if spec != nil {
+		if hash := core.GenesisToBlock(spec, nil).Hash(); hash != chain.Genesis().Hash() {
+			utils.Fatalf("Chain spec genesis %x doesn't match the database genesis %x", hash, chain.Genesis().Hash())
+		}
+	}
+	to := chain.CurrentBlock().NumberU64()
+	if ctx.IsSet(utils.ReexecToFlag.Name) {
+		to = ctx.Uint64(utils.ReexecToFlag.Name)
+	}
+	d, err := utils.ReexecBlocks(chainDb, chain, ctx.Uint64(utils.ReexecFromFlag.Name), to, ctx.Uint64(utils.ReexecDepthFlag.Name))
+	if err != nil {
+		utils.Fatalf("Re-execution failed: %v", err)
+	}
+	if d == nil {
+		log.Info("All blocks match the chain")
+		return nil
+	}
+	printDivergence(d)
+	return fmt.Errorf("block #%d diverges from the chain", d.Number)
+}
+
+// printDivergence prints the header fields and the accounts of a diverging block.
+func printDivergence(d *utils.Divergence) {
+	fmt.Printf("Block #%d [%x] diverges from the chain\n", d.Number, d.Hash)
+	if d.Err != nil {
+		fmt.Printf("  processing failed: %v\n", d.Err)
+	}
+	for _, m := range d.Mismatches {
+		fmt.Printf("  %-12s have %s, want %s\n", m.Field, m.Have, m.Want)
+	}
+	if len(d.Accounts) == 0 {
+		return
+	}
+	fmt.Println("Changed accounts (before -> re-executed, canonical):")
+	for _, diff := range d.Accounts {
+		fmt.Printf("  %s\n", diff.Address.Hex())
+
+		field := func(name string, value func(*utils.AccountState) string) {
+			pre, post := value(&diff.Pre), value(&diff.Post)
+			canonical := "unavailable"
+			if diff.Canonical != nil {
+				canonical = value(diff.Canonical)
+			}
+			if pre != post || (diff.Canonical != nil && post != canonical) {
+				fmt.Printf("    %-10s %s -> %s, %s\n", name, pre, post, canonical)
+			}
+		}
+		field("balance", func(s *utils.AccountState) string { return s.Balance.String() })
+		field("nonce", func(s *utils.AccountState) string { return strconv.FormatUint(s.Nonce, 10) })
+		field("codeHash", func(s *utils.AccountState) string { return s.CodeHash.Hex() })
+
+		slots := make([]common.Hash, 0, len(diff.Post.Storage))
+		for slot := range diff.Post.Storage {
+			slots = append(slots, slot)
+		}
+		sort.Slice(slots, func(i, j int) bool { return bytes.Compare(slots[i][:], slots[j][:]) < 0 })
+		for _, slot := range slots {
+			field(slot.Hex(), func(s *utils.AccountState) string { return s.Storage[slot].Hex() })
+		}
+	}
+}
+
+// dumpBadBlock writes a bad block, its rejection record and its traces into a
+// bundle, or lists the bad blocks without arguments.
+func dumpBadBlock(ctx *cli.Context) error {
+	if ctx.NArg() != 0 && ctx.NArg() != 2 {
+		utils.Fatalf("This command requires no arguments or two.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack, true)
+	defer chainDb.Close()
+
+	if ctx.NArg() == 0 {
+		for _, bad := range rawdb.ReadAllBadBlocks(chainDb) {
+			fmt.Printf("%x #%d %s peer=%q reason=%q\n", bad.Header.Hash(), bad.Header.Number, time.Unix(int64(bad.Time), 0).UTC().Format(time.RFC3339), bad.Peer, bad.Reason)
+		}
+		return nil
+	}
+	hash := common.HexToHash(ctx.Args().First())
+	bad := rawdb.ReadBadBlock(chainDb, hash)
+	if bad == nil {
+		utils.Fatalf("Bad block %x not found", hash)
+	}
+	dir := ctx.Args().Get(1)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		utils.Fatalf("Failed to create bundle directory: %v", err)
+	}
+	write := func(name string, data []byte) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			utils.Fatalf("Failed to write %s: %v", name, err)
+		}
+		log.Info("Wrote bad block bundle file", "file", name, "size", common.StorageSize(len(data)))
+	}
+	block, _ := rlp.EncodeToBytes(bad.Block())
+	write("block.rlp", block)
+
+	if parent := chain.GetHeader(bad.Header.ParentHash, bad.Header.Number.Uint64()-1); parent != nil {
+		header, _ := rlp.EncodeToBytes(parent)
+		write("parent.rlp", header)
+	} else {
+		log.Warn("Parent of bad block not found", "hash", bad.Header.ParentHash)
+	}
+	config, err := json.Marshal(chain.Config())
+	if err != nil {
+		utils.Fatalf("Failed to encode chain configuration: %v", err)
+	}
+	if crypto.Keccak256Hash(config) != bad.ConfigHash {
+		log.Warn("Chain configuration changed since the rejection", "rejected", bad.ConfigHash, "current", crypto.Keccak256Hash(config))
+	}
+	write("config.json", config)
+
+	api := eth.NewOfflineDebugAPI(chain, chainDb)
+	records, _ := api.GetBadBlocks(context.Background())
+	for _, record := range records {
+		if record.Hash == hash {
+			blob, _ := json.MarshalIndent(record, "", "  ")
+			write("badblock.json", blob)
+		}
+	}
+	reexec := ctx.Uint64(utils.ReexecDepthFlag.Name)
+	dumps, err := api.StandardTraceBadBlockToFile(context.Background(), hash, &eth.StdTraceConfig{Reexec: &reexec})
+	for i, dump := range dumps {
+		trace, err := ioutil.ReadFile(dump)
+		if err != nil {
+			utils.Fatalf("Failed to read trace: %v", err)
+		}
+		write(fmt.Sprintf("trace-%d-%x.jsonl", i, bad.Body.Transactions[i].Hash()), trace)
+		os.Remove(dump)
+	}
+	if err != nil {
+		log.Warn("Failed to trace bad block", "err", err)
+	}
+	log.Info("Dumped bad block", "number", bad.Header.Number, "hash", hash, "dir", dir, "traces", len(dumps))
+	return nil
+}
+
 // hashish returns true for strings that look like hashes.
 func hashish(x string) bool {
 	_, err := strconv.Atoi(x)
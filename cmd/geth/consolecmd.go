@@ -132,22 +132,8 @@ func remoteConsole(ctx *cli.Context) error {
 				} else {
 					path = filepath.Join(path, "ropsten")
 				}
-			} else if ctx.GlobalBool(utils.RinkebyFlag.Name) {
-				path = filepath.Join(path, "rinkeby")
-			} else if ctx.GlobalBool(utils.KottiFlag.Name) {
-				path = filepath.Join(path, "kotti")
-			} else if ctx.GlobalBool(utils.ClassicFlag.Name) {
-				path = filepath.Join(path, "classic")
-			} else if ctx.GlobalBool(utils.MordorFlag.Name) {
-				path = filepath.Join(path, "mordor")
-			} else if ctx.GlobalBool(utils.SocialFlag.Name) {
-				path = filepath.Join(path, "social")
-			} else if ctx.GlobalBool(utils.MixFlag.Name) {
-				path = filepath.Join(path, "mix")
-			} else if ctx.GlobalBool(utils.EthersocialFlag.Name) {
-				path = filepath.Join(path, "ethersocial")
-			} else if ctx.GlobalBool(utils.GoerliFlag.Name) {
-				path = filepath.Join(path, "goerli")
+			} else {
+				path = utils.DataDirPathForCtxChainConfig(ctx, path)
 			}
 		}
 		endpoint = fmt.Sprintf("%s/geth.ipc", path)
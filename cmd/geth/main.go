@@ -96,6 +96,7 @@ var (
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
 		utils.TxLookupLimitFlag,
+		utils.SupplyIndexFlag,
 		utils.LightServeFlag,
 		utils.LegacyLightServFlag,
 		utils.LightIngressFlag,
@@ -131,6 +132,8 @@ var (
 		utils.LegacyMinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerNoVerfiyFlag,
+		utils.MinerStratumFlag,
+		utils.MinerStratumDifficultyFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV5Flag,
@@ -140,6 +143,7 @@ var (
 		utils.DNSDiscoveryFlag,
 		utils.DeveloperFlag,
 		utils.DeveloperPeriodFlag,
+		utils.DeveloperInstantSealFlag,
 		utils.ClassicFlag,
 		utils.MordorFlag,
 		utils.SocialFlag,
@@ -150,6 +154,8 @@ var (
 		utils.RinkebyFlag,
 		utils.KottiFlag,
 		utils.GoerliFlag,
+		utils.NetworkFlag,
+		utils.NetworkDirFlag,
 		utils.VMEnableDebugFlag,
 		utils.NetworkIdFlag,
 		utils.EthStatsURLFlag,
@@ -226,6 +232,8 @@ func init() {
 		initCommand,
 		importCommand,
 		exportCommand,
+		exportHistoryCommand,
+		importHistoryCommand,
 		importPreimagesCommand,
 		exportPreimagesCommand,
 		copydbCommand,
@@ -233,6 +241,10 @@ func init() {
 		dumpCommand,
 		dumpGenesisCommand,
 		inspectCommand,
+		backfillSupplyCommand,
+		reexecCommand,
+		dumpBadBlockCommand,
+		snapshotCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -261,6 +273,7 @@ func init() {
 	app.Flags = append(app.Flags, debug.DeprecatedFlags...)
 	app.Flags = append(app.Flags, whisperFlags...)
 	app.Flags = append(app.Flags, metricsFlags...)
+	app.Flags = append(app.Flags, utils.OverrideTransitionFlags...)
 
 	app.Before = func(ctx *cli.Context) error {
 		return debug.Setup(ctx)
@@ -305,6 +318,9 @@ func prepare(ctx *cli.Context) {
 	case ctx.GlobalIsSet(utils.DeveloperFlag.Name):
 		log.Info("Starting Geth in ephemeral dev mode...")
 
+	case ctx.GlobalIsSet(utils.NetworkFlag.Name):
+		log.Info("Starting Geth on network...", "name", ctx.GlobalString(utils.NetworkFlag.Name))
+
 	case !ctx.GlobalIsSet(utils.NetworkIdFlag.Name):
 		log.Info("Starting Geth on Ethereum mainnet...")
 	}
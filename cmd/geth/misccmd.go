@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -39,6 +40,8 @@ var (
 		Category:  "MISCELLANEOUS COMMANDS",
 		Description: `
 The makecache command generates an ethash cache in <outputDir>.
+The epoch length (ECIP-1099) is determined by the chain configuration
+selected with the network flags (eg. --classic).
 
 This command exists to support the system testing project.
 Regular users do not need to execute it.
@@ -52,6 +55,8 @@ Regular users do not need to execute it.
 		Category:  "MISCELLANEOUS COMMANDS",
 		Description: `
 The makedag command generates an ethash DAG in <outputDir>.
+The epoch length (ECIP-1099) is determined by the chain configuration
+selected with the network flags (eg. --classic).
 
 This command exists to support the system testing project.
 Regular users do not need to execute it.
@@ -86,7 +91,7 @@ func makecache(ctx *cli.Context) error {
 	if err != nil {
 		utils.Fatalf("Invalid block number: %v", err)
 	}
-	ethash.MakeCache(block, args[1])
+	ethash.MakeCache(block, ethashEpochLength(ctx, block), args[1])
 
 	return nil
 }
@@ -101,11 +106,21 @@ func makedag(ctx *cli.Context) error {
 	if err != nil {
 		utils.Fatalf("Invalid block number: %v", err)
 	}
-	ethash.MakeDataset(block, args[1])
+	ethash.MakeDataset(block, ethashEpochLength(ctx, block), args[1])
 
 	return nil
 }
 
+// ethashEpochLength returns the ethash epoch length for the given block number,
+// as configured (ECIP-1099) by the chain selected with the command line flags.
+func ethashEpochLength(ctx *cli.Context, block uint64) uint64 {
+	var config ctypes.ChainConfigurator = params.MainnetChainConfig
+	if genesis := utils.MakeGenesis(ctx); genesis != nil {
+		config = genesis.Config
+	}
+	return ethash.CalcEpochLength(block, config.GetEthashECIP1099Transition())
+}
+
 func version(ctx *cli.Context) error {
 	versionClientIdentifier := clientIdentifier
 	if params.VersionName != "" {
@@ -398,6 +398,8 @@ func (api *RetestethAPI) SetChainParams(ctx context.Context, chainParams ChainPa
 			DatasetsInMem:    1,
 			DatasetsOnDisk:   2,
 			DatasetsLockMmap: false,
+			ECIP1099Block:    chainConfig.GetEthashECIP1099Transition(),
+			ECIP1049Block:    chainConfig.GetEthashECIP1049Transition(),
 		}, nil, false)
 	default:
 		return false, fmt.Errorf("unrecognised seal engine: %s", chainParams.SealEngine)
@@ -425,7 +427,7 @@ func (api *RetestethAPI) SetChainParams(ctx context.Context, chainParams ChainPa
 
 func (api *RetestethAPI) SendRawTransaction(ctx context.Context, rawTx hexutil.Bytes) (common.Hash, error) {
 	tx := new(types.Transaction)
-	if err := rlp.DecodeBytes(rawTx, tx); err != nil {
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
 		// Return nil is not by mistake - some tests include sending transaction where gasLimit overflows uint64
 		return common.Hash{}, nil
 	}
@@ -72,6 +72,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.NoUSBFlag,
 			utils.SmartCardDaemonPathFlag,
 			utils.NetworkIdFlag,
+			utils.NetworkFlag,
+			utils.NetworkDirFlag,
 			utils.ClassicFlag,
 			utils.MordorFlag,
 			utils.SocialFlag,
@@ -86,6 +88,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.ExitWhenSyncedFlag,
 			utils.GCModeFlag,
 			utils.TxLookupLimitFlag,
+			utils.SupplyIndexFlag,
 			utils.EthStatsURLFlag,
 			utils.IdentityFlag,
 			utils.LightKDFFlag,
@@ -109,6 +112,7 @@ var AppHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			utils.DeveloperFlag,
 			utils.DeveloperPeriodFlag,
+			utils.DeveloperInstantSealFlag,
 		},
 	},
 	{
@@ -218,6 +222,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.MinerExtraDataFlag,
 			utils.MinerRecommitIntervalFlag,
 			utils.MinerNoVerfiyFlag,
+			utils.MinerStratumFlag,
+			utils.MinerStratumDifficultyFlag,
 		},
 	},
 	{
@@ -250,6 +256,10 @@ var AppHelpFlagGroups = []flagGroup{
 		Name:  "WHISPER (EXPERIMENTAL)",
 		Flags: whisperFlags,
 	},
+	{
+		Name:  "TRANSITION OVERRIDES",
+		Flags: utils.OverrideTransitionFlags,
+	},
 	{
 		Name: "ALIASED (deprecated)",
 		Flags: append([]cli.Flag{
@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth"
@@ -204,6 +205,14 @@ var (
 		Name:  "goerli",
 		Usage: "Görli network: pre-configured proof-of-authority test network",
 	}
+	NetworkFlag = cli.StringFlag{
+		Name:  "network",
+		Usage: fmt.Sprintf("Network to join: one of the built-in networks (%s) or the name of a network definition in --%s", strings.Join(params.NetworkNames(), ", "), NetworkDirFlag.Name),
+	}
+	NetworkDirFlag = DirectoryFlag{
+		Name:  "networkdir",
+		Usage: "Directory containing network definitions (default = inside the datadir)",
+	}
 	DeveloperFlag = cli.BoolFlag{
 		Name:  "dev",
 		Usage: "Ephemeral proof-of-authority network with a pre-funded developer account, mining enabled",
@@ -213,6 +222,10 @@ var (
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
 	}
+	DeveloperInstantSealFlag = cli.BoolFlag{
+		Name:  "dev.instantseal",
+		Usage: "Seal blocks without proof-of-work in developer mode, following the rules of the chain selected by --classic, --mordor, --network or initialized in --datadir",
+	}
 	IdentityFlag = cli.StringFlag{
 		Name:  "identity",
 		Usage: "Custom node name",
@@ -257,11 +270,37 @@ var (
 		Name:  "snapshot",
 		Usage: `Enables snapshot-database mode -- experimental work in progress feature`,
 	}
+	BloomFilterSizeFlag = cli.Uint64Flag{
+		Name:  "bloomfilter.size",
+		Usage: "Megabytes of memory allocated to the bloom filter of the offline state pruning",
+		Value: 2048,
+	}
+	ReexecFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Number of the block whose state the re-execution starts from",
+	}
+	ReexecToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Number of the last block re-executed (default = head block)",
+	}
+	ReexecConfigFlag = cli.StringFlag{
+		Name:  "config",
+		Usage: "Chain spec file whose configuration the blocks are re-executed under (default = stored configuration)",
+	}
+	ReexecDepthFlag = cli.Uint64Flag{
+		Name:  "reexec",
+		Usage: "Maximum number of blocks re-executed to regenerate a missing state",
+		Value: 1024,
+	}
 	TxLookupLimitFlag = cli.Int64Flag{
 		Name:  "txlookuplimit",
 		Usage: "Number of recent blocks to maintain transactions index by-hash for (default = index all blocks)",
 		Value: 0,
 	}
+	SupplyIndexFlag = cli.BoolFlag{
+		Name:  "supplyindex",
+		Usage: "Enables indexing of the ether supply and issuance of each block (re-executes the chain from genesis)",
+	}
 	LightKDFFlag = cli.BoolFlag{
 		Name:  "lightkdf",
 		Usage: "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -474,6 +513,15 @@ var (
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
 	}
+	MinerStratumFlag = cli.StringFlag{
+		Name:  "miner.stratum",
+		Usage: "Listening address of the ethash stratum server for remote miners (e.g. 0.0.0.0:8008, default = disabled)",
+	}
+	MinerStratumDifficultyFlag = cli.Uint64Flag{
+		Name:  "miner.stratum.difficulty",
+		Usage: "Difficulty of the shares accepted by the ethash stratum server (0 = block difficulty)",
+		Value: eth.DefaultConfig.Ethash.StratumDifficulty,
+	}
 	// Account settings
 	UnlockedAccountFlag = cli.StringFlag{
 		Name:  "unlock",
@@ -769,7 +817,7 @@ func MakeDataDir(ctx *cli.Context) string {
 				return filepath.Join(path, "ropsten")
 			}
 		}
-		return dataDirPathForCtxChainConfig(ctx, path)
+		return DataDirPathForCtxChainConfig(ctx, path)
 	}
 	Fatalf("Cannot determine default data directory, please set manually (--%s)", DataDirFlag.Name)
 	return ""
@@ -812,6 +860,7 @@ func setNodeUserIdent(ctx *cli.Context, cfg *node.Config) {
 // flags, reverting to pre-configured ones if none have been specified.
 func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 	urls := params.MainnetBootnodes
+	network := NetworkForCtx(ctx)
 	switch {
 	case ctx.GlobalIsSet(BootnodesFlag.Name) || ctx.GlobalIsSet(LegacyBootnodesV4Flag.Name):
 		if ctx.GlobalIsSet(LegacyBootnodesV4Flag.Name) {
@@ -819,24 +868,8 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 		} else {
 			urls = splitAndTrim(ctx.GlobalString(BootnodesFlag.Name))
 		}
-	case ctx.GlobalBool(ClassicFlag.Name):
-		urls = params.ClassicBootnodes
-	case ctx.GlobalBool(MordorFlag.Name):
-		urls = params.MordorBootnodes
-	case ctx.GlobalBool(SocialFlag.Name):
-		urls = params.SocialBootnodes
-	case ctx.GlobalBool(MixFlag.Name):
-		urls = params.MixBootnodes
-	case ctx.GlobalBool(EthersocialFlag.Name):
-		urls = params.EthersocialBootnodes
-	case ctx.GlobalBool(LegacyTestnetFlag.Name) || ctx.GlobalBool(RopstenFlag.Name):
-		urls = params.RopstenBootnodes
-	case ctx.GlobalBool(RinkebyFlag.Name):
-		urls = params.RinkebyBootnodes
-	case ctx.GlobalBool(KottiFlag.Name):
-		urls = params.KottiBootnodes
-	case ctx.GlobalBool(GoerliFlag.Name):
-		urls = params.GoerliBootnodes
+	case network != nil:
+		urls = network.Bootnodes
 	case cfg.BootstrapNodes != nil:
 		return // already set, don't apply defaults.
 	}
@@ -858,6 +891,7 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 // flags, reverting to pre-configured ones if none have been specified.
 func setBootstrapNodesV5(ctx *cli.Context, cfg *p2p.Config) {
 	urls := params.MainnetBootnodes
+	network := NetworkForCtx(ctx)
 	switch {
 	case ctx.GlobalIsSet(BootnodesFlag.Name) || ctx.GlobalIsSet(LegacyBootnodesV5Flag.Name):
 		if ctx.GlobalIsSet(LegacyBootnodesV5Flag.Name) {
@@ -865,14 +899,8 @@ func setBootstrapNodesV5(ctx *cli.Context, cfg *p2p.Config) {
 		} else {
 			urls = splitAndTrim(ctx.GlobalString(BootnodesFlag.Name))
 		}
-	case ctx.GlobalBool(RopstenFlag.Name):
-		urls = params.RopstenBootnodes
-	case ctx.GlobalBool(RinkebyFlag.Name):
-		urls = params.RinkebyBootnodes
-	case ctx.GlobalBool(KottiFlag.Name):
-		urls = params.KottiBootnodes
-	case ctx.GlobalBool(GoerliFlag.Name):
-		urls = params.GoerliBootnodes
+	case network != nil:
+		urls = network.Bootnodes
 	case cfg.BootstrapNodesV5 != nil:
 		return // already set, don't apply defaults.
 	}
@@ -1283,26 +1311,11 @@ func setSmartCard(ctx *cli.Context, cfg *node.Config) {
 	cfg.SmartCardDaemonPath = path
 }
 
-func dataDirPathForCtxChainConfig(ctx *cli.Context, baseDataDirPath string) string {
-	switch {
-	case ctx.GlobalBool(RopstenFlag.Name):
-		return filepath.Join(baseDataDirPath, "ropsten")
-	case ctx.GlobalBool(ClassicFlag.Name):
-		return filepath.Join(baseDataDirPath, "classic")
-	case ctx.GlobalBool(MordorFlag.Name):
-		return filepath.Join(baseDataDirPath, "mordor")
-	case ctx.GlobalBool(SocialFlag.Name):
-		return filepath.Join(baseDataDirPath, "social")
-	case ctx.GlobalBool(MixFlag.Name):
-		return filepath.Join(baseDataDirPath, "mix")
-	case ctx.GlobalBool(EthersocialFlag.Name):
-		return filepath.Join(baseDataDirPath, "ethersocial")
-	case ctx.GlobalBool(RinkebyFlag.Name):
-		return filepath.Join(baseDataDirPath, "rinkeby")
-	case ctx.GlobalBool(KottiFlag.Name):
-		return filepath.Join(baseDataDirPath, "kotti")
-	case ctx.GlobalBool(GoerliFlag.Name):
-		return filepath.Join(baseDataDirPath, "goerli")
+// DataDirPathForCtxChainConfig returns the data directory of the network selected
+// by the context within the given base data directory.
+func DataDirPathForCtxChainConfig(ctx *cli.Context, baseDataDirPath string) string {
+	if name := ctxNetworkName(ctx); name != "" && name != params.MainnetNetworkName {
+		return filepath.Join(baseDataDirPath, name)
 	}
 	return baseDataDirPath
 }
@@ -1332,7 +1345,7 @@ func setDataDir(ctx *cli.Context, cfg *node.Config) {
 		}
 
 	case cfg.DataDir == node.DefaultDataDir():
-		cfg.DataDir = dataDirPathForCtxChainConfig(ctx, node.DefaultDataDir())
+		cfg.DataDir = DataDirPathForCtxChainConfig(ctx, node.DefaultDataDir())
 	}
 }
 
@@ -1422,6 +1435,12 @@ func setEthash(ctx *cli.Context, cfg *eth.Config) {
 	if ctx.GlobalIsSet(EthashDatasetsLockMmapFlag.Name) {
 		cfg.Ethash.DatasetsLockMmap = ctx.GlobalBool(EthashDatasetsLockMmapFlag.Name)
 	}
+	if ctx.GlobalIsSet(MinerStratumFlag.Name) {
+		cfg.Ethash.StratumAddr = ctx.GlobalString(MinerStratumFlag.Name)
+	}
+	if ctx.GlobalIsSet(MinerStratumDifficultyFlag.Name) {
+		cfg.Ethash.StratumDifficulty = ctx.GlobalUint64(MinerStratumDifficultyFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -1539,8 +1558,11 @@ func SetShhConfig(ctx *cli.Context, stack *node.Node, cfg *whisper.Config) {
 
 // SetEthConfig applies eth-related command line flags to the config.
 func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
-	// Avoid conflicting network flags
-	CheckExclusive(ctx, DeveloperFlag, LegacyTestnetFlag, RopstenFlag, RinkebyFlag, GoerliFlag)
+	// Avoid conflicting network flags, the instant-seal developer mode follows the
+	// rules of the selected network
+	if !ctx.GlobalBool(DeveloperInstantSealFlag.Name) {
+		CheckExclusive(ctx, DeveloperFlag, NetworkFlag, LegacyTestnetFlag, RopstenFlag, RinkebyFlag, GoerliFlag)
+	}
 	CheckExclusive(ctx, LegacyLightServFlag, LightServeFlag, SyncModeFlag, "light")
 	CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
 	CheckExclusive(ctx, GCModeFlag, "archive", TxLookupLimitFlag)
@@ -1583,6 +1605,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	if ctx.GlobalIsSet(TxLookupLimitFlag.Name) {
 		cfg.TxLookupLimit = ctx.GlobalUint64(TxLookupLimitFlag.Name)
 	}
+	if ctx.GlobalIsSet(SupplyIndexFlag.Name) {
+		cfg.SupplyIndex = ctx.GlobalBool(SupplyIndexFlag.Name)
+	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
 	}
@@ -1624,9 +1649,20 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 
 	// Override any default configs for hard coded networks.
 
-	// Override genesis configuration if a --<chain> flag.
-	if gen := genesisForCtxChainConfig(ctx); gen != nil {
-		cfg.Genesis = gen
+	// Override genesis configuration and light client checkpoints if a --<chain> or --network flag.
+	network := NetworkForCtx(ctx)
+	if network != nil {
+		cfg.Genesis = network.Genesis()
+		if cfg.Checkpoint == nil {
+			cfg.Checkpoint = network.Checkpoint
+		}
+		if cfg.CheckpointOracle == nil {
+			cfg.CheckpointOracle = network.CheckpointOracle
+		}
+	}
+	// Override individual transitions if any --override.<name> flags.
+	if overrides := overrideTransitions(ctx); overrides != nil {
+		cfg.OverrideTransitions = overrides
 	}
 
 	// Establish NetworkID.
@@ -1639,24 +1675,10 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 
 	// Set DNS discovery defaults for hard coded networks with DNS defaults.
 	switch {
-	case ctx.GlobalBool(RopstenFlag.Name):
-		setDNSDiscoveryDefaults(cfg, params.KnownDNSNetworks[params.RopstenGenesisHash])
-	case ctx.GlobalBool(LegacyTestnetFlag.Name) || ctx.GlobalBool(RopstenFlag.Name):
-		if !ctx.GlobalIsSet(NetworkIdFlag.Name) {
-			cfg.NetworkId = 3
+	case network != nil:
+		if network.DNSDiscovery != "" {
+			setDNSDiscoveryDefaults(cfg, network.DNSDiscovery)
 		}
-		cfg.Genesis = params.DefaultRopstenGenesisBlock()
-		setDNSDiscoveryDefaults(cfg, params.KnownDNSNetworks[params.RopstenGenesisHash])
-	case ctx.GlobalBool(RinkebyFlag.Name):
-		setDNSDiscoveryDefaults(cfg, params.KnownDNSNetworks[params.RinkebyGenesisHash])
-	case ctx.GlobalBool(GoerliFlag.Name):
-		setDNSDiscoveryDefaults(cfg, params.KnownDNSNetworks[params.GoerliGenesisHash])
-	case ctx.GlobalBool(ClassicFlag.Name):
-		setDNSDiscoveryDefaults(cfg, params.ClassicDNSNetwork1)
-	case ctx.GlobalBool(KottiFlag.Name):
-		setDNSDiscoveryDefaults(cfg, params.KottiDNSNetwork1)
-	case ctx.GlobalBool(MordorFlag.Name):
-		setDNSDiscoveryDefaults(cfg, params.MordorDNSNetwork1)
 	default:
 		if cfg.NetworkId == 1 {
 			setDNSDiscoveryDefaults(cfg, params.KnownDNSNetworks[params.MainnetGenesisHash])
@@ -1684,7 +1706,23 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 		}
 		log.Info("Using developer account", "address", developer.Address)
 
-		cfg.Genesis = params.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address)
+		switch {
+		case !ctx.GlobalBool(DeveloperInstantSealFlag.Name):
+			cfg.Genesis = params.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address)
+
+		case network != nil:
+			cfg.Genesis = params.DeveloperFundedGenesis(network.Genesis(), developer.Address)
+
+		case ctx.GlobalIsSet(DataDirFlag.Name) && hasStoredGenesis(ctx, stack):
+			// Use the genesis the datadir was initialized with
+
+		default:
+			cfg.Genesis = params.DeveloperGenesisBlock(0, developer.Address)
+		}
+		if ctx.GlobalBool(DeveloperInstantSealFlag.Name) {
+			cfg.InstantSeal = true
+			cfg.InstantSealPeriod = uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name))
+		}
 		if !ctx.GlobalIsSet(MinerGasPriceFlag.Name) && !ctx.GlobalIsSet(LegacyMinerGasPriceFlag.Name) {
 			cfg.Miner.GasPrice = big.NewInt(1)
 		}
@@ -1824,33 +1862,94 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node) ethdb.Database {
 	return chainDb
 }
 
-// genesisForCtxChainConfig returns the corresponding Genesis for a non-default flag chain value.
-// If no --<chain> flag is set in the global context, a nil value is returned.
-// It does not handle genesis for --dev mode, since that mode includes but also exceeds
-// chain configuration.
-func genesisForCtxChainConfig(ctx *cli.Context) *genesisT.Genesis {
-	var genesis *genesisT.Genesis
+// hasStoredGenesis returns whether the chain database of the datadir was
+// initialized with a genesis block.
+func hasStoredGenesis(ctx *cli.Context, stack *node.Node) bool {
+	chainDb := MakeChainDatabase(ctx, stack)
+	defer chainDb.Close()
+
+	return rawdb.ReadCanonicalHash(chainDb, 0) != (common.Hash{})
+}
+
+// ctxNetworkName returns the name of the network selected by the --network flag
+// or one of the network preset flags, or an empty string if none is selected.
+func ctxNetworkName(ctx *cli.Context) string {
 	switch {
+	case ctx.GlobalIsSet(NetworkFlag.Name):
+		return ctx.GlobalString(NetworkFlag.Name)
 	case ctx.GlobalBool(ClassicFlag.Name):
-		genesis = params.DefaultClassicGenesisBlock()
+		return "classic"
 	case ctx.GlobalBool(MordorFlag.Name):
-		genesis = params.DefaultMordorGenesisBlock()
+		return "mordor"
 	case ctx.GlobalBool(SocialFlag.Name):
-		genesis = params.DefaultSocialGenesisBlock()
+		return "social"
 	case ctx.GlobalBool(MixFlag.Name):
-		genesis = params.DefaultMixGenesisBlock()
+		return "mix"
 	case ctx.GlobalBool(EthersocialFlag.Name):
-		genesis = params.DefaultEthersocialGenesisBlock()
+		return "ethersocial"
 	case ctx.GlobalBool(LegacyTestnetFlag.Name) || ctx.GlobalBool(RopstenFlag.Name):
-		genesis = params.DefaultRopstenGenesisBlock()
+		return "ropsten"
 	case ctx.GlobalBool(RinkebyFlag.Name):
-		genesis = params.DefaultRinkebyGenesisBlock()
+		return "rinkeby"
 	case ctx.GlobalBool(KottiFlag.Name):
-		genesis = params.DefaultKottiGenesisBlock()
+		return "kotti"
 	case ctx.GlobalBool(GoerliFlag.Name):
-		genesis = params.DefaultGoerliGenesisBlock()
+		return "goerli"
+	}
+	return ""
+}
+
+// MakeNetworkName returns the name of the network selected by the flags, which
+// defaults to mainnet.
+func MakeNetworkName(ctx *cli.Context) string {
+	if name := ctxNetworkName(ctx); name != "" {
+		return name
+	}
+	return params.MainnetNetworkName
+}
+
+// networkDir returns the directory containing the network definitions.
+func networkDir(ctx *cli.Context) string {
+	if ctx.GlobalIsSet(NetworkDirFlag.Name) {
+		return ctx.GlobalString(NetworkDirFlag.Name)
+	}
+	base := node.DefaultDataDir()
+	if ctx.GlobalIsSet(DataDirFlag.Name) {
+		base = ctx.GlobalString(DataDirFlag.Name)
 	}
-	return genesis
+	return filepath.Join(base, "networks")
+}
+
+// NetworkForCtx returns the network selected by the --network flag or one of the
+// network preset flags, or nil if none is selected. Networks which are not built in
+// are loaded from their definition in the network directory.
+func NetworkForCtx(ctx *cli.Context) *params.Network {
+	name := ctxNetworkName(ctx)
+	if name == "" {
+		return nil
+	}
+	if network := params.NetworkByName(name); network != nil {
+		return network
+	}
+	if name != filepath.Base(name) {
+		Fatalf("Invalid network name: %q", name)
+	}
+	network, err := params.LoadNetwork(filepath.Join(networkDir(ctx), name))
+	if err != nil {
+		Fatalf("Failed to load network %q: %v", name, err)
+	}
+	return network
+}
+
+// genesisForCtxChainConfig returns the corresponding Genesis for a non-default flag chain value.
+// If no --<chain> or --network flag is set in the global context, a nil value is returned.
+// It does not handle genesis for --dev mode, since that mode includes but also exceeds
+// chain configuration.
+func genesisForCtxChainConfig(ctx *cli.Context) *genesisT.Genesis {
+	if network := NetworkForCtx(ctx); network != nil {
+		return network.Genesis()
+	}
+	return nil
 }
 
 func MakeGenesis(ctx *cli.Context) *genesisT.Genesis {
@@ -1862,17 +1961,29 @@ func MakeGenesis(ctx *cli.Context) *genesisT.Genesis {
 
 // MakeChain creates a chain manager from set command line flags.
 func MakeChain(ctx *cli.Context, stack *node.Node, readOnly bool) (chain *core.BlockChain, chainDb ethdb.Database) {
+	return MakeChainWithConfig(ctx, stack, readOnly, nil)
+}
+
+// MakeChainWithConfig creates a chain manager from set command line flags, which
+// runs under the given chain configuration instead of the stored one if not nil.
+func MakeChainWithConfig(ctx *cli.Context, stack *node.Node, readOnly bool, override ctypes.ChainConfigurator) (chain *core.BlockChain, chainDb ethdb.Database) {
 	var err error
 	chainDb = MakeChainDatabase(ctx, stack)
-	config, _, err := core.SetupGenesisBlock(chainDb, MakeGenesis(ctx))
+	config, _, err := core.SetupGenesisBlockWithOverride(chainDb, MakeGenesis(ctx), overrideTransitions(ctx))
 	if err != nil {
 		Fatalf("%v", err)
 	}
+	if override != nil {
+		config = override
+	}
 	var engine consensus.Engine
 	if config.GetConsensusEngineType().IsClique() {
 		engine = clique.New(&ctypes.CliqueConfig{
 			Period: config.GetCliquePeriod(),
 			Epoch:  config.GetCliqueEpoch(),
+
+			OnDemandBlock: config.GetCliqueOnDemandTransition(),
+			MaxIdle:       config.GetCliqueMaxIdle(),
 		}, chainDb)
 	} else {
 		engine = ethash.NewFaker()
@@ -1886,6 +1997,8 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readOnly bool) (chain *core.B
 				DatasetsInMem:    eth.DefaultConfig.Ethash.DatasetsInMem,
 				DatasetsOnDisk:   eth.DefaultConfig.Ethash.DatasetsOnDisk,
 				DatasetsLockMmap: eth.DefaultConfig.Ethash.DatasetsLockMmap,
+				ECIP1099Block:    config.GetEthashECIP1099Transition(),
+				ECIP1049Block:    config.GetEthashECIP1049Transition(),
 			}, nil, false)
 		}
 	}
@@ -1962,3 +2075,20 @@ func MigrateFlags(action func(ctx *cli.Context) error) func(*cli.Context) error
 		return action(ctx)
 	}
 }
+
+// MigrateFlagsExcept is like MigrateFlags, but leaves the given flags local to
+// the command, for command flags clashing with global flags of another meaning.
+func MigrateFlagsExcept(action func(ctx *cli.Context) error, except ...string) func(*cli.Context) error {
+	local := make(map[string]bool, len(except))
+	for _, name := range except {
+		local[name] = true
+	}
+	return func(ctx *cli.Context) error {
+		for _, name := range ctx.FlagNames() {
+			if ctx.IsSet(name) && !local[name] {
+				ctx.GlobalSet(name, ctx.String(name))
+			}
+		}
+		return action(ctx)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// OverrideTransitionFlagPrefix prefixes the names of the transition override flags.
+const OverrideTransitionFlagPrefix = "override."
+
+var (
+	// OverrideTransitionFlags are the --override.<name>=<block> flags, one for each
+	// transition (fork) of the chain configurator interface.
+	OverrideTransitionFlags []cli.Flag
+
+	// overrideTransitionNames maps the override flag names to their transition names.
+	overrideTransitionNames = make(map[string]string)
+)
+
+func init() {
+	for _, name := range confp.TransitionNames() {
+		flag := cli.Uint64Flag{
+			Name:  OverrideTransitionFlagPrefix + strings.ToLower(name),
+			Usage: fmt.Sprintf("Manually specify the %s transition block, overriding the configured setting", name),
+		}
+		OverrideTransitionFlags = append(OverrideTransitionFlags, flag)
+		overrideTransitionNames[flag.Name] = name
+	}
+}
+
+// overrideTransitions returns the transition overrides set on the command line,
+// keyed by transition name, or nil if there are none.
+func overrideTransitions(ctx *cli.Context) map[string]uint64 {
+	var overrides map[string]uint64
+	for _, flag := range OverrideTransitionFlags {
+		name := flag.GetName()
+		if !ctx.GlobalIsSet(name) {
+			continue
+		}
+		if overrides == nil {
+			overrides = make(map[string]uint64)
+		}
+		overrides[overrideTransitionNames[name]] = ctx.GlobalUint64(name)
+	}
+	return overrides
+}
@@ -0,0 +1,425 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ExportHistory exports the epochs of the ancient store covering the blocks from
+// first to last into history archives in the given directory, and records
+// their checksums. Epochs are always exported whole, as far as the ancient store goes.
+func ExportHistory(db ethdb.Database, dir, network string, first, last uint64) error {
+	frozen, err := db.Ancients()
+	if err != nil {
+		return err
+	}
+	if frozen == 0 {
+		return errors.New("ancient store is empty")
+	}
+	if last >= frozen {
+		log.Warn("Last block beyond the ancient store, clamping", "last", last, "frozen", frozen-1)
+		last = frozen - 1
+	}
+	if first > last {
+		return fmt.Errorf("invalid range: first %d > last %d", first, last)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	checksums, err := readChecksums(filepath.Join(dir, era.ChecksumsFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if checksums == nil {
+		checksums = make(map[string]common.Hash)
+	}
+	log.Info("Exporting history", "dir", dir, "first", first, "last", last)
+
+	start := time.Now()
+	for epoch := first / era.MaxSize; epoch <= last/era.MaxSize; epoch++ {
+		from, to := epoch*era.MaxSize, (epoch+1)*era.MaxSize
+		if to > frozen {
+			to = frozen
+		}
+		name, checksum, err := exportEpoch(db, dir, network, int(epoch), from, to)
+		if err != nil {
+			return err
+		}
+		// Drop any stale export of the same epoch, eg. a partial last epoch
+		stale, _ := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%s-%05d-*%s", network, epoch, era.Extension)))
+		for _, path := range stale {
+			if filepath.Base(path) != name {
+				os.Remove(path)
+				delete(checksums, filepath.Base(path))
+			}
+		}
+		checksums[name] = checksum
+		log.Info("Exported history epoch", "epoch", epoch, "file", name, "blocks", to-from, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return writeChecksums(filepath.Join(dir, era.ChecksumsFile), checksums)
+}
+
+// exportEpoch writes the blocks [from, to) into the history archive of the
+// epoch, returning its name and checksum.
+func exportEpoch(db ethdb.Database, dir, network string, epoch int, from, to uint64) (string, common.Hash, error) {
+	tmp := filepath.Join(dir, era.Filename(network, epoch, common.Hash{})+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	var (
+		hasher  = sha256.New()
+		builder = era.NewBuilder(io.MultiWriter(f, hasher))
+	)
+	for n := from; n < to; n++ {
+		hash := rawdb.ReadCanonicalHash(db, n)
+		var (
+			header   = rawdb.ReadHeaderRLP(db, hash, n)
+			body     = rawdb.ReadBodyRLP(db, hash, n)
+			receipts = rawdb.ReadReceiptsRLP(db, hash, n)
+			td       = rawdb.ReadTd(db, hash, n)
+		)
+		if len(header) == 0 || len(body) == 0 || len(receipts) == 0 || td == nil {
+			return "", common.Hash{}, fmt.Errorf("block %d missing from the ancient store", n)
+		}
+		if err := builder.Add(n, hash, header, body, receipts, td); err != nil {
+			return "", common.Hash{}, err
+		}
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	if err := f.Sync(); err != nil {
+		return "", common.Hash{}, err
+	}
+	name := era.Filename(network, epoch, root)
+	if err := os.Rename(tmp, filepath.Join(dir, name)); err != nil {
+		return "", common.Hash{}, err
+	}
+	return name, common.BytesToHash(hasher.Sum(nil)), nil
+}
+
+// ImportHistory imports the history archives of the given directory into the
+// ancient store, continuing from its current end. The files are checked against their
+// recorded checksums and accumulators, and each block against its header: the
+// parent hash, the transaction, uncle and receipt roots, the bloom and the total
+// difficulty must all match. Blocks are not executed.
+func ImportHistory(db ethdb.Database, dir, network string) error {
+	files, err := era.ReadDir(dir, network)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no %s history archives found in %s", network, dir)
+	}
+	checksums, err := readChecksums(filepath.Join(dir, era.ChecksumsFile))
+	if err != nil {
+		return fmt.Errorf("failed to read checksums: %v", err)
+	}
+	frozen, err := db.Ancients()
+	if err != nil {
+		return err
+	}
+	// Blocks are appended to the ancient store, so the chain in the key-value
+	// store must not extend beyond it
+	var (
+		genesis = rawdb.ReadCanonicalHash(db, 0)
+		head    = rawdb.ReadHeadHeaderHash(db)
+	)
+	if number := rawdb.ReadHeaderNumber(db, head); number != nil && *number > 0 && *number+1 > frozen {
+		return fmt.Errorf("chain head #%d beyond the ancient store (%d blocks)", *number, frozen)
+	}
+	// A database only holding the genesis is initialized from the ancient store by
+	// the node on startup, otherwise the key-value store is updated here
+	fresh := head == genesis && rawdb.ReadHeadFastBlockHash(db) == genesis && rawdb.ReadHeadBlockHash(db) == genesis
+
+	imp := &historyImporter{db: db, fresh: fresh, genesis: genesis, next: frozen, start: time.Now(), logged: time.Now()}
+	if frozen > 0 {
+		imp.parent = rawdb.ReadCanonicalHash(db, frozen-1)
+		imp.td = rawdb.ReadTd(db, imp.parent, frozen-1)
+		if imp.td == nil {
+			return fmt.Errorf("total difficulty of block %d missing", frozen-1)
+		}
+	}
+	log.Info("Importing history", "dir", dir, "files", len(files), "frozen", frozen)
+	for _, name := range files {
+		if err := imp.importFile(filepath.Join(dir, name), checksums); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	log.Info("Imported history", "blocks", imp.next-frozen, "head", imp.next-1, "elapsed", common.PrettyDuration(time.Since(imp.start)))
+	if fresh {
+		log.Info("Database will be initialized from the ancient store on the next start")
+	}
+	return nil
+}
+
+// historyImporter appends verified blocks of history archives to the ancient
+// store.
+type historyImporter struct {
+	db      ethdb.Database
+	fresh   bool        // Whether the key-value store is left to the node to initialize
+	genesis common.Hash // Genesis hash of the key-value store, if any
+
+	next   uint64      // Number of the next block to import
+	parent common.Hash // Hash of the last block of the ancient store
+	td     *big.Int    // Total difficulty of the last block of the ancient store
+
+	start, logged time.Time
+}
+
+// importFile verifies a history archive, and appends its blocks not yet in the
+// ancient store.
+func (imp *historyImporter) importFile(path string, checksums map[string]common.Hash) error {
+	want, ok := checksums[filepath.Base(path)]
+	if !ok {
+		return errors.New("checksum missing")
+	}
+	if have, err := fileChecksum(path); err != nil {
+		return err
+	} else if have != want {
+		return fmt.Errorf("checksum mismatch: have %x, want %x", have, want)
+	}
+	e, err := era.Open(path)
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+
+	if e.Start()%era.MaxSize != 0 {
+		return fmt.Errorf("misaligned start block %d", e.Start())
+	}
+	if e.Start()+e.Count() <= imp.next {
+		log.Debug("Skipping imported history", "file", filepath.Base(path))
+		return nil
+	}
+	if e.Start() > imp.next {
+		return fmt.Errorf("gap in history: have block %d, want %d", e.Start(), imp.next)
+	}
+	root, err := e.Verify()
+	if err != nil {
+		return err
+	}
+	network := strings.SplitN(filepath.Base(path), "-", 2)[0]
+	if want := era.Filename(network, int(e.Start()/era.MaxSize), root); filepath.Base(path) != want {
+		return fmt.Errorf("filename mismatch: want %s", want)
+	}
+	var (
+		batch = imp.db.NewBatch()
+		first = imp.next
+	)
+	for ; imp.next < e.Start()+e.Count(); imp.next++ {
+		if err := imp.importBlock(e, batch); err != nil {
+			return fmt.Errorf("block %d: %v", imp.next, err)
+		}
+		if time.Since(imp.logged) > 8*time.Second {
+			log.Info("Importing history", "number", imp.next, "hash", imp.parent, "elapsed", common.PrettyDuration(time.Since(imp.start)))
+			imp.logged = time.Now()
+		}
+	}
+	if err := imp.db.Sync(); err != nil {
+		return err
+	}
+	if imp.fresh {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	rawdb.WriteHeadHeaderHash(imp.db, imp.parent)
+	rawdb.WriteHeadFastBlockHash(imp.db, imp.parent)
+	rawdb.IndexTransactions(imp.db, first, imp.next)
+	return nil
+}
+
+// importBlock verifies the next block of the file against its header and parent,
+// and appends it to the ancient store.
+func (imp *historyImporter) importBlock(e *era.Era, batch ethdb.Batch) error {
+	n := imp.next
+	headerRLP, err := e.GetRawHeaderByNumber(n)
+	if err != nil {
+		return err
+	}
+	bodyRLP, err := e.GetRawBodyByNumber(n)
+	if err != nil {
+		return err
+	}
+	receiptsRLP, err := e.GetRawReceiptsByNumber(n)
+	if err != nil {
+		return err
+	}
+	td, err := e.GetTdByNumber(n)
+	if err != nil {
+		return err
+	}
+	var (
+		header   types.Header
+		body     types.Body
+		receipts []*types.ReceiptForStorage
+	)
+	if err := rlp.DecodeBytes(headerRLP, &header); err != nil {
+		return fmt.Errorf("invalid header: %v", err)
+	}
+	if err := rlp.DecodeBytes(bodyRLP, &body); err != nil {
+		return fmt.Errorf("invalid body: %v", err)
+	}
+	if err := rlp.DecodeBytes(receiptsRLP, &receipts); err != nil {
+		return fmt.Errorf("invalid receipts: %v", err)
+	}
+	hash := crypto.Keccak256Hash(headerRLP)
+	if header.Number == nil || header.Number.Uint64() != n {
+		return fmt.Errorf("number mismatch: have %v", header.Number)
+	}
+	// Check the block links to the ancient store, or is the genesis of the database
+	if n == 0 {
+		if imp.genesis != (common.Hash{}) && hash != imp.genesis {
+			return fmt.Errorf("genesis mismatch: have %x, want %x", hash, imp.genesis)
+		}
+	} else if header.ParentHash != imp.parent {
+		return fmt.Errorf("parent hash mismatch: have %x, want %x", header.ParentHash, imp.parent)
+	}
+	// Check the body and receipts against the header
+	if root := types.DeriveSha(types.Transactions(body.Transactions)); root != header.TxHash {
+		return fmt.Errorf("transaction root mismatch: have %x, want %x", root, header.TxHash)
+	}
+	if root := types.CalcUncleHash(body.Uncles); root != header.UncleHash {
+		return fmt.Errorf("uncle root mismatch: have %x, want %x", root, header.UncleHash)
+	}
+	if len(receipts) != len(body.Transactions) {
+		return fmt.Errorf("receipt count mismatch: have %d, want %d", len(receipts), len(body.Transactions))
+	}
+	rs := make(types.Receipts, len(receipts))
+	for i, receipt := range receipts {
+		rs[i] = (*types.Receipt)(receipt)
+		rs[i].Bloom = types.CreateBloom(types.Receipts{rs[i]})
+	}
+	if root := types.DeriveSha(rs); root != header.ReceiptHash {
+		return fmt.Errorf("receipt root mismatch: have %x, want %x", root, header.ReceiptHash)
+	}
+	if bloom := types.CreateBloom(rs); bloom != header.Bloom {
+		return errors.New("bloom mismatch")
+	}
+	// Check the total difficulty accumulates the header difficulties
+	want := new(big.Int).Set(header.Difficulty)
+	if n > 0 {
+		want.Add(want, imp.td)
+	}
+	if td.Cmp(want) != 0 {
+		return fmt.Errorf("total difficulty mismatch: have %v, want %v", td, want)
+	}
+	tdRLP, err := rlp.EncodeToBytes(td)
+	if err != nil {
+		return err
+	}
+	if err := imp.db.AppendAncient(n, hash.Bytes(), headerRLP, bodyRLP, receiptsRLP, tdRLP); err != nil {
+		return err
+	}
+	if !imp.fresh {
+		rawdb.WriteHeaderNumber(batch, hash, n)
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	imp.parent, imp.td = hash, td
+	return nil
+}
+
+// fileChecksum returns the SHA-256 checksum of the given file.
+func fileChecksum(path string) (common.Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hasher.Sum(nil)), nil
+}
+
+// readChecksums reads a checksums file, in the format of sha256sum.
+func readChecksums(path string) (map[string]common.Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	checksums := make(map[string]common.Hash)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var checksum common.Hash
+		if len(fields) != 2 || checksum.UnmarshalText([]byte("0x"+fields[0])) != nil {
+			return nil, fmt.Errorf("malformed checksum line: %q", scanner.Text())
+		}
+		checksums[fields[1]] = checksum
+	}
+	return checksums, scanner.Err()
+}
+
+// writeChecksums writes a checksums file, in the format of sha256sum.
+func writeChecksums(path string, checksums map[string]common.Hash) error {
+	names := make([]string, 0, len(checksums))
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%x  %s\n", checksums[name], name)
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// newHistoryTestDB creates a database with a freezer in the given directory and
+// the genesis of the given spec.
+func newHistoryTestDB(t *testing.T, dir string, gspec *genesisT.Genesis) (ethdb.Database, *types.Block) {
+	db, err := rawdb.NewDatabaseWithFreezer(memorydb.New(), dir, "")
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	return db, core.MustCommitGenesis(db, gspec)
+}
+
+// Tests that the ancient store is exported into history archives, and that the
+// files are imported back into an empty database.
+func TestHistoryExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &genesisT.Genesis{
+			Config: params.AllEthashProtocolChanges,
+			Alloc:  genesisT.GenesisAlloc{address: {Balance: big.NewInt(vars.Ether)}},
+		}
+		signer = types.NewEIP155Signer(gspec.Config.GetChainID())
+	)
+	src, genesis := newHistoryTestDB(t, filepath.Join(dir, "src"), gspec)
+	defer src.Close()
+
+	blocks, receipts := core.GenerateChain(gspec.Config, genesis, ethash.NewFaker(), src, 20, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{byte(i)}, big.NewInt(1), vars.TxGas, nil, nil), signer, key)
+		gen.AddTx(tx)
+		if i%5 == 0 {
+			gen.AddUncle(&types.Header{ParentHash: gen.PrevBlock(i - 1).Hash(), Number: big.NewInt(int64(i)), Difficulty: big.NewInt(1)})
+		}
+	})
+	td := new(big.Int).Set(genesis.Difficulty())
+	rawdb.WriteAncientBlock(src, genesis, nil, td)
+	for i, block := range blocks {
+		td.Add(td, block.Difficulty())
+		rawdb.WriteAncientBlock(src, block, receipts[i], td)
+	}
+	archive := filepath.Join(dir, "era")
+	if err := ExportHistory(src, archive, "test", 0, math.MaxUint64); err != nil {
+		t.Fatalf("failed to export history: %v", err)
+	}
+	files, err := era.ReadDir(archive, "test")
+	if err != nil || len(files) != 1 {
+		t.Fatalf("history archives mismatch: have %v (%v), want 1", files, err)
+	}
+	// Import into a fresh database of the same chain
+	dst, _ := newHistoryTestDB(t, filepath.Join(dir, "dst"), gspec)
+	defer dst.Close()
+
+	if err := ImportHistory(dst, archive, "test"); err != nil {
+		t.Fatalf("failed to import history: %v", err)
+	}
+	if frozen, _ := dst.Ancients(); frozen != 21 {
+		t.Fatalf("ancient count mismatch: have %d, want 21", frozen)
+	}
+	for n := uint64(0); n <= 20; n++ {
+		hash := rawdb.ReadCanonicalHash(src, n)
+		if have := rawdb.ReadCanonicalHash(dst, n); have != hash {
+			t.Fatalf("block %d: hash mismatch: have %x, want %x", n, have, hash)
+		}
+		if !bytes.Equal(rawdb.ReadBodyRLP(dst, hash, n), rawdb.ReadBodyRLP(src, hash, n)) {
+			t.Errorf("block %d: body mismatch", n)
+		}
+		if !bytes.Equal(rawdb.ReadReceiptsRLP(dst, hash, n), rawdb.ReadReceiptsRLP(src, hash, n)) {
+			t.Errorf("block %d: receipts mismatch", n)
+		}
+		if have, want := rawdb.ReadTd(dst, hash, n), rawdb.ReadTd(src, hash, n); have.Cmp(want) != 0 {
+			t.Errorf("block %d: td mismatch: have %v, want %v", n, have, want)
+		}
+	}
+	// Importing again is a no-op
+	if err := ImportHistory(dst, archive, "test"); err != nil {
+		t.Fatalf("failed to reimport history: %v", err)
+	}
+	if frozen, _ := dst.Ancients(); frozen != 21 {
+		t.Fatalf("ancient count mismatch after reimport: have %d, want 21", frozen)
+	}
+	// Databases of other chains are rejected
+	other, _ := newHistoryTestDB(t, filepath.Join(dir, "other"), &genesisT.Genesis{Config: params.AllEthashProtocolChanges, ExtraData: []byte{0x01}})
+	defer other.Close()
+
+	if err := ImportHistory(other, archive, "test"); err == nil {
+		t.Error("imported history of another chain")
+	}
+	// Tampered files are rejected
+	path := filepath.Join(archive, files[0])
+	blob, _ := ioutil.ReadFile(path)
+	blob[100] ^= 0xff
+	ioutil.WriteFile(path, blob, 0644)
+
+	third, _ := newHistoryTestDB(t, filepath.Join(dir, "third"), gspec)
+	defer third.Close()
+
+	if err := ImportHistory(third, archive, "test"); err == nil {
+		t.Error("imported tampered history")
+	}
+	if frozen, _ := third.Ancients(); frozen != 0 {
+		t.Errorf("ancient count mismatch after failed import: have %d, want 0", frozen)
+	}
+	// Archives of blocks not matching their headers are rejected, though their
+	// checksums and accumulators are valid
+	bad, _ := newHistoryTestDB(t, filepath.Join(dir, "bad"), gspec)
+	defer bad.Close()
+
+	td = new(big.Int).Set(genesis.Difficulty())
+	rawdb.WriteAncientBlock(bad, genesis, nil, td)
+	for i, block := range blocks {
+		td.Add(td, block.Difficulty())
+		if i == 10 {
+			receipt := *receipts[i][0]
+			receipt.CumulativeGasUsed++
+			rawdb.WriteAncientBlock(bad, block, types.Receipts{&receipt}, td)
+		} else {
+			rawdb.WriteAncientBlock(bad, block, receipts[i], td)
+		}
+	}
+	archive = filepath.Join(dir, "bad-era")
+	if err := ExportHistory(bad, archive, "test", 0, math.MaxUint64); err != nil {
+		t.Fatalf("failed to export history: %v", err)
+	}
+	fourth, _ := newHistoryTestDB(t, filepath.Join(dir, "fourth"), gspec)
+	defer fourth.Close()
+
+	if err := ImportHistory(fourth, archive, "test"); err == nil {
+		t.Error("imported invalid history")
+	}
+	if frozen, _ := fourth.Ancients(); frozen != 11 {
+		t.Errorf("ancient count mismatch after invalid import: have %d, want 11", frozen)
+	}
+}
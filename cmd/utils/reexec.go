@@ -0,0 +1,377 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Divergence is the first block whose re-execution doesn't match its canonical
+// header.
+type Divergence struct {
+	Number uint64
+	Hash   common.Hash
+	Err    error // Error processing the block, if it couldn't be re-executed
+
+	Mismatches []Mismatch    // Header fields not matching the re-execution
+	Accounts   []AccountDiff // Accounts changed by the re-execution or the canonical block, by address
+}
+
+// Mismatch is a header field whose re-executed value differs from the canonical
+// one.
+type Mismatch struct {
+	Field string
+	Have  string // Value resulting from the re-execution
+	Want  string // Value of the canonical header
+}
+
+// AccountState is the state of an account, along with the storage slots changed
+// by the block.
+type AccountState struct {
+	Balance  *big.Int
+	Nonce    uint64
+	CodeHash common.Hash
+	Storage  map[common.Hash]common.Hash
+}
+
+// AccountDiff is an account changed by a diverging block.
+type AccountDiff struct {
+	Address   common.Address
+	Pre       AccountState  // State before the block
+	Post      AccountState  // State after the re-execution
+	Canonical *AccountState // State after the canonical execution, nil if unavailable
+}
+
+// ReexecBlocks re-executes the canonical blocks from+1 to to on top of the state
+// of block from, and compares the state root, receipts root, bloom and gas used
+// resulting from each of them to its header. The state of block from is
+// regenerated from the nearest available state at most reexec blocks before it,
+// if it's missing. The blocks are processed under the configuration of the chain.
+//
+// The first diverging block is returned, along with the accounts changed by it,
+// or nil if all the blocks match their headers.
+func ReexecBlocks(db ethdb.Database, chain *core.BlockChain, from, to, reexec uint64) (*Divergence, error) {
+	if from >= to {
+		return nil, fmt.Errorf("invalid range: from %d >= to %d", from, to)
+	}
+	if head := chain.CurrentBlock().NumberU64(); to > head {
+		return nil, fmt.Errorf("block #%d beyond the head of the chain #%d", to, head)
+	}
+	base := chain.GetBlockByNumber(from)
+	if base == nil {
+		return nil, fmt.Errorf("block #%d not found", from)
+	}
+	database := state.NewDatabaseWithCache(db, 256)
+	statedb, root, err := reexecState(chain, database, base, reexec)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config = chain.Config()
+		start  = time.Now()
+		logged time.Time
+	)
+	log.Info("Re-executing blocks", "from", from, "to", to)
+	for number := from + 1; number <= to; number++ {
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Re-executing blocks", "number", number, "to", to, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		receipts, _, gasUsed, err := chain.Processor().Process(block, statedb, vm.Config{})
+		if err != nil {
+			return newDivergence(chain, database, block, root, nil, err)
+		}
+		post, err := statedb.Commit(config.IsEnabled(config.GetEIP161dTransition, block.Number()))
+		if err != nil {
+			return nil, err
+		}
+		database.TrieDB().Reference(post, common.Hash{})
+		if mismatches := compareHeader(block.Header(), post, receipts, gasUsed); len(mismatches) > 0 {
+			return newDivergence(chain, database, block, root, mismatches, nil)
+		}
+		if err := statedb.Reset(post); err != nil {
+			return nil, fmt.Errorf("state reset after block %d failed: %v", number, err)
+		}
+		database.TrieDB().Dereference(root)
+		root = post
+	}
+	log.Info("Re-executed blocks", "from", from, "to", to, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil, nil
+}
+
+// reexecState returns the state of the given block along with its root,
+// regenerating it from the nearest available state at most reexec blocks before
+// it if it's missing.
+func reexecState(chain *core.BlockChain, database state.Database, block *types.Block, reexec uint64) (*state.StateDB, common.Hash, error) {
+	if statedb, err := state.New(block.Root(), database, nil); err == nil {
+		return statedb, block.Root(), nil
+	}
+	var (
+		origin  = block.NumberU64()
+		statedb *state.StateDB
+	)
+	for i := uint64(0); i < reexec && block.NumberU64() > 0; i++ {
+		if block = chain.GetBlock(block.ParentHash(), block.NumberU64()-1); block == nil {
+			break
+		}
+		if s, err := state.New(block.Root(), database, nil); err == nil {
+			statedb = s
+			break
+		}
+	}
+	if statedb == nil {
+		return nil, common.Hash{}, fmt.Errorf("state of block #%d unavailable within %d blocks", origin, reexec)
+	}
+	var (
+		config = chain.Config()
+		root   = block.Root()
+		start  = time.Now()
+		logged time.Time
+	)
+	for number := block.NumberU64() + 1; number <= origin; number++ {
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Regenerating historical state", "block", number, "target", origin, "remaining", origin-number, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+		if block = chain.GetBlockByNumber(number); block == nil {
+			return nil, common.Hash{}, fmt.Errorf("block #%d not found", number)
+		}
+		if _, _, _, err := chain.Processor().Process(block, statedb, vm.Config{}); err != nil {
+			return nil, common.Hash{}, fmt.Errorf("processing block %d failed: %v", number, err)
+		}
+		post, err := statedb.Commit(config.IsEnabled(config.GetEIP161dTransition, block.Number()))
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+		if err := statedb.Reset(post); err != nil {
+			return nil, common.Hash{}, fmt.Errorf("state reset after block %d failed: %v", number, err)
+		}
+		database.TrieDB().Reference(post, common.Hash{})
+		database.TrieDB().Dereference(root)
+		root = post
+	}
+	log.Info("Historical state regenerated", "block", origin, "elapsed", common.PrettyDuration(time.Since(start)))
+	return statedb, root, nil
+}
+
+// compareHeader returns the fields of the header not matching the results of
+// the re-execution of its block.
+func compareHeader(header *types.Header, root common.Hash, receipts types.Receipts, gasUsed uint64) []Mismatch {
+	var mismatches []Mismatch
+	if root != header.Root {
+		mismatches = append(mismatches, Mismatch{"stateRoot", root.Hex(), header.Root.Hex()})
+	}
+	if hash := types.DeriveSha(receipts); hash != header.ReceiptHash {
+		mismatches = append(mismatches, Mismatch{"receiptsRoot", hash.Hex(), header.ReceiptHash.Hex()})
+	}
+	if bloom := types.CreateBloom(receipts); bloom != header.Bloom {
+		mismatches = append(mismatches, Mismatch{"logsBloom", hexutil.Encode(bloom[:]), hexutil.Encode(header.Bloom[:])})
+	}
+	if gasUsed != header.GasUsed {
+		mismatches = append(mismatches, Mismatch{"gasUsed", strconv.FormatUint(gasUsed, 10), strconv.FormatUint(header.GasUsed, 10)})
+	}
+	return mismatches
+}
+
+// newDivergence processes the diverging block again on top of the state of its
+// parent, and diffs the accounts differing between the parent state and the
+// re-executed or canonical ones. The accounts are taken from the states rather
+// than the execution, so changes made outside of the EVM, like block rewards or
+// irregular state changes, are included.
+func newDivergence(chain *core.BlockChain, database state.Database, block *types.Block, parent common.Hash, mismatches []Mismatch, err error) (*Divergence, error) {
+	d := &Divergence{
+		Number:     block.NumberU64(),
+		Hash:       block.Hash(),
+		Err:        err,
+		Mismatches: mismatches,
+	}
+	pre, err := state.New(parent, database, nil)
+	if err != nil {
+		return nil, err
+	}
+	post := pre.Copy()
+	chain.Processor().Process(block, post, vm.Config{}) // Errors are already reported
+
+	config := chain.Config()
+	root, err := post.Commit(config.IsEnabled(config.GetEIP161dTransition, block.Number()))
+	if err != nil {
+		return nil, err
+	}
+	touched, err := stateDiff(database, parent, root)
+	if err != nil {
+		return nil, err
+	}
+	canonical, _ := state.New(block.Root(), database, nil)
+	if canonical != nil {
+		diff, err := stateDiff(database, parent, block.Root())
+		if err != nil {
+			return nil, err
+		}
+		for addr, slots := range diff {
+			if touched[addr] == nil {
+				touched[addr] = make(map[common.Hash]struct{})
+			}
+			for slot := range slots {
+				touched[addr][slot] = struct{}{}
+			}
+		}
+	}
+	for addr, slots := range touched {
+		diff := AccountDiff{
+			Address: addr,
+			Pre:     readAccount(pre, addr, slots),
+			Post:    readAccount(post, addr, slots),
+		}
+		if canonical != nil {
+			account := readAccount(canonical, addr, slots)
+			diff.Canonical = &account
+		}
+		d.Accounts = append(d.Accounts, diff)
+	}
+	sort.Slice(d.Accounts, func(i, j int) bool {
+		return bytes.Compare(d.Accounts[i].Address[:], d.Accounts[j].Address[:]) < 0
+	})
+	return d, nil
+}
+
+// stateDiff returns the accounts differing between two states, along with their
+// storage slots differing between them. Accounts and slots are recovered from
+// the preimages of their hashes, those missing one are skipped.
+func stateDiff(database state.Database, a, b common.Hash) (map[common.Address]map[common.Hash]struct{}, error) {
+	ta, err := database.OpenTrie(a)
+	if err != nil {
+		return nil, err
+	}
+	tb, err := database.OpenTrie(b)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make(map[common.Address]map[common.Hash]struct{})
+	for _, tries := range [][2]state.Trie{{ta, tb}, {tb, ta}} {
+		from, to := tries[0], tries[1]
+		it, _ := trie.NewDifferenceIterator(from.NodeIterator(nil), to.NodeIterator(nil))
+		for it.Next(true) {
+			if !it.Leaf() {
+				continue
+			}
+			preimage := to.GetKey(it.LeafKey())
+			if preimage == nil {
+				log.Warn("Missing preimage of account", "hash", common.BytesToHash(it.LeafKey()))
+				continue
+			}
+			addr := common.BytesToAddress(preimage)
+			if _, ok := accounts[addr]; ok {
+				continue
+			}
+			// Diff the storage of the account too
+			var account state.Account
+			if err := rlp.DecodeBytes(it.LeafBlob(), &account); err != nil {
+				return nil, err
+			}
+			other := types.EmptyRootHash
+			if enc, err := from.TryGet(addr.Bytes()); err != nil {
+				return nil, err
+			} else if len(enc) > 0 {
+				var prev state.Account
+				if err := rlp.DecodeBytes(enc, &prev); err != nil {
+					return nil, err
+				}
+				other = prev.Root
+			}
+			slots, err := storageDiff(database, common.BytesToHash(it.LeafKey()), other, account.Root)
+			if err != nil {
+				return nil, err
+			}
+			accounts[addr] = slots
+		}
+		if it.Error() != nil {
+			return nil, it.Error()
+		}
+	}
+	return accounts, nil
+}
+
+// storageDiff returns the storage slots differing between two storage tries of
+// an account.
+func storageDiff(database state.Database, addrHash, a, b common.Hash) (map[common.Hash]struct{}, error) {
+	slots := make(map[common.Hash]struct{})
+	if a == b {
+		return slots, nil
+	}
+	ta, err := database.OpenStorageTrie(addrHash, a)
+	if err != nil {
+		return nil, err
+	}
+	tb, err := database.OpenStorageTrie(addrHash, b)
+	if err != nil {
+		return nil, err
+	}
+	for _, tries := range [][2]state.Trie{{ta, tb}, {tb, ta}} {
+		from, to := tries[0], tries[1]
+		it, _ := trie.NewDifferenceIterator(from.NodeIterator(nil), to.NodeIterator(nil))
+		for it.Next(true) {
+			if !it.Leaf() {
+				continue
+			}
+			preimage := to.GetKey(it.LeafKey())
+			if preimage == nil {
+				log.Warn("Missing preimage of storage slot", "account", addrHash, "hash", common.BytesToHash(it.LeafKey()))
+				continue
+			}
+			slots[common.BytesToHash(preimage)] = struct{}{}
+		}
+		if it.Error() != nil {
+			return nil, it.Error()
+		}
+	}
+	return slots, nil
+}
+
+// readAccount returns the state of the account, along with the given slots of
+// its storage.
+func readAccount(statedb *state.StateDB, addr common.Address, slots map[common.Hash]struct{}) AccountState {
+	account := AccountState{
+		Balance:  statedb.GetBalance(addr),
+		Nonce:    statedb.GetNonce(addr),
+		CodeHash: statedb.GetCodeHash(addr),
+		Storage:  make(map[common.Hash]common.Hash, len(slots)),
+	}
+	for slot := range slots {
+		account.Storage[slot] = statedb.GetState(addr, slot)
+	}
+	return account
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of core-geth.
+//
+// core-geth is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// core-geth is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with core-geth. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// Tests that blocks re-executed under their own configuration match the chain,
+// and that the first block diverging under another configuration is reported.
+func TestReexecBlocks(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		db      = rawdb.NewMemoryDatabase()
+
+		// The candidate configuration reduces the block reward from block 5
+		canonical = *params.AllEthashProtocolChanges
+		candidate = *params.AllEthashProtocolChanges
+	)
+	canonical.ConstantinopleBlock, canonical.PetersburgBlock = nil, nil
+	candidate.ConstantinopleBlock, candidate.PetersburgBlock = big.NewInt(5), big.NewInt(5)
+
+	gspec := &genesisT.Genesis{
+		Config: &canonical,
+		Alloc:  genesisT.GenesisAlloc{address: {Balance: big.NewInt(vars.Ether)}},
+	}
+	core.MustCommitGenesis(db, gspec)
+	signer := types.NewEIP155Signer(gspec.Config.GetChainID())
+
+	// Generate the chain in a separate database, to only keep the states of the
+	// genesis and the latest blocks
+	gendb := rawdb.NewMemoryDatabase()
+	genesis := core.MustCommitGenesis(gendb, gspec)
+	blocks, _ := core.GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, 8, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{byte(i + 1)}, big.NewInt(1), vars.TxGas, nil, nil), signer, key)
+		gen.AddTx(tx)
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.Stop() // Flush the state of the head
+
+	chain, _ = core.NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer chain.Stop()
+
+	if d, err := ReexecBlocks(db, chain, 3, 8, 3); d != nil || err != nil {
+		t.Fatalf("re-execution diverged: %v (%v)", d, err)
+	}
+	if _, err := ReexecBlocks(db, chain, 3, 8, 2); err == nil {
+		t.Fatal("re-executed blocks without starting state")
+	}
+	// Re-executing under the candidate configuration diverges at the first block
+	// with a different reward
+	fork, _ := core.NewBlockChain(db, nil, &candidate, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer fork.Stop()
+
+	d, err := ReexecBlocks(db, fork, 3, 8, 3)
+	if err != nil {
+		t.Fatalf("failed to re-execute blocks: %v", err)
+	}
+	if d == nil || d.Number != 5 || d.Hash != blocks[4].Hash() || d.Err != nil {
+		t.Fatalf("divergence mismatch: have %+v, want block 5", d)
+	}
+	if len(d.Mismatches) != 1 || d.Mismatches[0].Field != "stateRoot" {
+		t.Errorf("mismatches mismatch: have %+v, want stateRoot", d.Mismatches)
+	}
+	// The sender, the recipient and the coinbase are touched, and the canonical
+	// state of block 5 isn't available anymore
+	if len(d.Accounts) != 3 {
+		t.Fatalf("touched accounts mismatch: have %d, want 3", len(d.Accounts))
+	}
+	coinbase := d.Accounts[0]
+	if coinbase.Address != (common.Address{}) || coinbase.Canonical != nil {
+		t.Fatalf("coinbase mismatch: have %x (canonical %v)", coinbase.Address, coinbase.Canonical)
+	}
+	if reward := new(big.Int).Sub(coinbase.Post.Balance, coinbase.Pre.Balance); reward.Cmp(vars.EIP1234FBlockReward) != 0 {
+		t.Errorf("coinbase reward mismatch: have %v, want %v", reward, vars.EIP1234FBlockReward)
+	}
+	// The canonical state of the head is still available to diff against
+	d, err = ReexecBlocks(db, fork, 7, 8, 0)
+	if err != nil || d == nil || d.Number != 8 {
+		t.Fatalf("divergence mismatch: have %+v (%v), want block 8", d, err)
+	}
+	coinbase = d.Accounts[0]
+	if coinbase.Canonical == nil {
+		t.Fatal("canonical coinbase state missing")
+	}
+	if diff := new(big.Int).Sub(coinbase.Canonical.Balance, coinbase.Post.Balance); diff.Cmp(big.NewInt(vars.Ether)) != 0 {
+		t.Errorf("coinbase canonical difference mismatch: have %v, want %v", diff, vars.Ether)
+	}
+}
+
+// Tests that accounts changed outside of the EVM are reported, by re-executing
+// blocks under a configuration paying a share of the block reward to a treasury.
+func TestReexecBlocksRewardSplit(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		treasury = common.HexToAddress("0xcc")
+
+		canonical = &coregeth.CoreGethChainConfig{
+			NetworkID:           1,
+			ChainID:             big.NewInt(1),
+			Ethash:              new(ctypes.EthashConfig),
+			BlockRewardSchedule: ctypes.Uint64BigMapEncodesHex{0: vars.FrontierBlockReward},
+		}
+		candidate = &coregeth.CoreGethChainConfig{
+			NetworkID:                1,
+			ChainID:                  big.NewInt(1),
+			Ethash:                   new(ctypes.EthashConfig),
+			BlockRewardSchedule:      ctypes.Uint64BigMapEncodesHex{0: vars.FrontierBlockReward},
+			BlockRewardSplitSchedule: ctypes.RewardSplitSchedule{3: {Address: treasury, Percentage: 10}},
+		}
+	)
+	gspec := &genesisT.Genesis{Config: canonical}
+	genesis := core.MustCommitGenesis(db, gspec)
+	blocks, _ := core.GenerateChain(canonical, genesis, ethash.NewFaker(), db, 4, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.Address{0x01})
+	})
+	chain, _ := core.NewBlockChain(db, nil, canonical, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.Stop()
+
+	fork, _ := core.NewBlockChain(db, nil, candidate, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer fork.Stop()
+
+	d, err := ReexecBlocks(db, fork, 0, 4, 0)
+	if err != nil {
+		t.Fatalf("failed to re-execute blocks: %v", err)
+	}
+	if d == nil || d.Number != 3 {
+		t.Fatalf("divergence mismatch: have %+v, want block 3", d)
+	}
+	// Both the coinbase and the treasury are changed, though the block doesn't
+	// execute any transaction
+	if len(d.Accounts) != 2 {
+		t.Fatalf("changed accounts mismatch: have %d, want 2", len(d.Accounts))
+	}
+	paid, coinbase := d.Accounts[0], d.Accounts[1]
+	if coinbase.Address != (common.Address{0x01}) || paid.Address != treasury {
+		t.Fatalf("changed accounts mismatch: have %x, %x", coinbase.Address, paid.Address)
+	}
+	share := new(big.Int).Div(vars.FrontierBlockReward, big.NewInt(10))
+	if paid.Pre.Balance.Sign() != 0 || paid.Post.Balance.Cmp(share) != 0 {
+		t.Errorf("treasury balance mismatch: have %v -> %v, want 0 -> %v", paid.Pre.Balance, paid.Post.Balance, share)
+	}
+	if paid.Canonical == nil || paid.Canonical.Balance.Sign() != 0 {
+		t.Errorf("treasury canonical balance mismatch: have %+v, want 0", paid.Canonical)
+	}
+	if reward := new(big.Int).Sub(coinbase.Post.Balance, coinbase.Pre.Balance); reward.Cmp(new(big.Int).Sub(vars.FrontierBlockReward, share)) != 0 {
+		t.Errorf("coinbase reward mismatch: have %v, want %v", reward, new(big.Int).Sub(vars.FrontierBlockReward, share))
+	}
+}
@@ -126,6 +126,10 @@ var (
 	// the previous block's timestamp + the minimum block period.
 	ErrInvalidTimestamp = errors.New("invalid timestamp")
 
+	// errEmptyBlock is returned if a block without transactions is sealed on an
+	// on-demand chain before the maximum idle interval elapsed since its parent.
+	errEmptyBlock = errors.New("empty block before idle interval")
+
 	// errInvalidVotingChain is returned if an authorization list is attempted to
 	// be modified via out-of-range or non-contiguous headers.
 	errInvalidVotingChain = errors.New("invalid voting chain")
@@ -182,6 +186,9 @@ type Clique struct {
 	signFn SignerFn       // Signer function to authorize hashes with
 	lock   sync.RWMutex   // Protects the signer fields
 
+	quit      chan struct{} // Terminates the background status metrics reporting
+	closeOnce sync.Once
+
 	// The fields below are for testing only
 	fakeDiff bool // Skip difficulty verifications
 }
@@ -204,6 +211,7 @@ func New(config *ctypes.CliqueConfig, db ethdb.Database) *Clique {
 		recents:    recents,
 		signatures: signatures,
 		proposals:  make(map[common.Address]bool),
+		quit:       make(chan struct{}),
 	}
 }
 
@@ -325,6 +333,10 @@ func (c *Clique) verifyCascadingFields(chain consensus.ChainReader, header *type
 	if parent.Time+c.config.Period > header.Time {
 		return ErrInvalidTimestamp
 	}
+	// On-demand chains only allow empty blocks once the chain was idle for long
+	if header.TxHash == types.EmptyRootHash && !c.emptyAllowed(parent, header) {
+		return errEmptyBlock
+	}
 	// Retrieve the snapshot needed to verify this header and cache it
 	snap, err := c.snapshot(chain, number-1, header.ParentHash, parents)
 	if err != nil {
@@ -580,6 +592,26 @@ func (c *Clique) Authorize(signer common.Address, signFn SignerFn) {
 	c.signFn = signFn
 }
 
+// OnDemand returns whether blocks with the given number are only sealed if they
+// contain transactions, or once the chain was idle for the maximum interval.
+func (c *Clique) OnDemand(number uint64) bool {
+	return c.config.OnDemandBlock != nil && *c.config.OnDemandBlock <= number
+}
+
+// MaxIdle returns the number of seconds after its parent an empty block may be
+// sealed on an on-demand chain, or zero if empty blocks are never sealed.
+func (c *Clique) MaxIdle() uint64 {
+	return c.config.MaxIdle
+}
+
+// emptyAllowed returns whether a block without transactions may follow parent.
+func (c *Clique) emptyAllowed(parent, header *types.Header) bool {
+	if !c.OnDemand(header.Number.Uint64()) {
+		return true
+	}
+	return c.config.MaxIdle > 0 && parent.Time+c.config.MaxIdle <= header.Time
+}
+
 // Seal implements consensus.Engine, attempting to create a sealed block using
 // the local signing credentials.
 func (c *Clique) Seal(chain consensus.ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
@@ -590,10 +622,17 @@ func (c *Clique) Seal(chain consensus.ChainReader, block *types.Block, results c
 	if number == 0 {
 		return errUnknownBlock
 	}
-	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing)
-	if c.config.Period == 0 && len(block.Transactions()) == 0 {
-		log.Info("Sealing paused, waiting for transactions")
-		return nil
+	// For 0-period and on-demand chains, refuse to seal empty blocks (no reward
+	// but would spin sealing), unless the chain was idle for long enough
+	if len(block.Transactions()) == 0 {
+		if c.config.Period == 0 && !c.OnDemand(number) {
+			log.Info("Sealing paused, waiting for transactions")
+			return nil
+		}
+		if parent := chain.GetHeader(header.ParentHash, number-1); parent != nil && !c.emptyAllowed(parent, header) {
+			log.Info("Sealing paused, waiting for transactions")
+			return nil
+		}
 	}
 	// Don't hold the signer fields for the entire sealing procedure
 	c.lock.RLock()
@@ -678,8 +717,12 @@ func (c *Clique) SealHash(header *types.Header) common.Hash {
 	return SealHash(header)
 }
 
-// Close implements consensus.Engine. It's a noop for clique as there are no background threads.
+// Close implements consensus.Engine, terminating the status metrics reporting,
+// if started.
 func (c *Clique) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+	})
 	return nil
 }
 
@@ -19,6 +19,7 @@ package clique
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"math/big"
 	"sort"
 	"testing"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
 )
 
 // testerAccountPool is a pool to maintain currently active tester accounts,
@@ -94,6 +96,7 @@ type testerVote struct {
 	auth       bool
 	checkpoint []string
 	newbatch   bool
+	txs        bool
 }
 
 // Tests that Clique signer voting is evaluated correctly for various simple and
@@ -101,11 +104,13 @@ type testerVote struct {
 func TestClique(t *testing.T) {
 	// Define the various voting scenarios to test
 	tests := []struct {
-		epoch   uint64
-		signers []string
-		votes   []testerVote
-		results []string
-		failure error
+		epoch    uint64
+		onDemand uint64 // Block from which empty blocks are only sealed once idle (0 = never)
+		maxIdle  uint64
+		signers  []string
+		votes    []testerVote
+		results  []string
+		failure  error
 	}{
 		{
 			// Single signer, no votes cast
@@ -376,6 +381,56 @@ func TestClique(t *testing.T) {
 				{signer: "A", newbatch: true},
 			},
 			failure: errRecentlySigned,
+		}, {
+			// On-demand blocks with transactions are sealed and tallied as usual
+			onDemand: 1,
+			signers:  []string{"A", "B"},
+			votes: []testerVote{
+				{signer: "A", voted: "C", auth: true, txs: true},
+				{signer: "B", voted: "C", auth: true, txs: true},
+			},
+			results: []string{"A", "B", "C"},
+		}, {
+			// Empty blocks are allowed before the on-demand transition
+			onDemand: 3,
+			signers:  []string{"A", "B"},
+			votes: []testerVote{
+				{signer: "A"},
+				{signer: "B"},
+				{signer: "A", txs: true},
+			},
+			results: []string{"A", "B"},
+		}, {
+			// Empty blocks are rejected after the on-demand transition
+			onDemand: 3,
+			signers:  []string{"A", "B"},
+			votes: []testerVote{
+				{signer: "A"},
+				{signer: "B"},
+				{signer: "A"},
+			},
+			failure: errEmptyBlock,
+		}, {
+			// Empty blocks are allowed once the chain was idle for long enough
+			onDemand: 1,
+			maxIdle:  10,
+			signers:  []string{"A", "B"},
+			votes: []testerVote{
+				{signer: "A"},
+				{signer: "B", txs: true},
+				{signer: "A"},
+			},
+			results: []string{"A", "B"},
+		}, {
+			// Empty blocks are rejected before the chain was idle for long enough
+			onDemand: 1,
+			maxIdle:  20,
+			signers:  []string{"A", "B"},
+			votes: []testerVote{
+				{signer: "A", txs: true},
+				{signer: "B"},
+			},
+			failure: errEmptyBlock,
 		},
 	}
 	// Run through the scenarios and test them
@@ -394,9 +449,12 @@ func TestClique(t *testing.T) {
 				}
 			}
 		}
-		// Create the genesis block with the initial set of signers
+		// Create the genesis block with the initial set of signers, and a funded
+		// account to send transactions from
+		sender := accounts.address("sender")
 		genesis := &genesisT.Genesis{
 			ExtraData: make([]byte, extraVanity+common.AddressLength*len(signers)+extraSeal),
+			Alloc:     genesisT.GenesisAlloc{sender: {Balance: big.NewInt(vars.Ether)}},
 		}
 		for j, signer := range signers {
 			copy(genesis.ExtraData[extraVanity+j*common.AddressLength:], signer[:])
@@ -408,8 +466,12 @@ func TestClique(t *testing.T) {
 		// Assemble a chain of headers from the cast votes
 		config := *params.TestChainConfig
 		config.Clique = &ctypes.CliqueConfig{
-			Period: 1,
-			Epoch:  tt.epoch,
+			Period:  1,
+			Epoch:   tt.epoch,
+			MaxIdle: tt.maxIdle,
+		}
+		if tt.onDemand > 0 {
+			config.Clique.OnDemandBlock = &tt.onDemand
 		}
 		engine := New(config.Clique, db)
 		engine.fakeDiff = true
@@ -422,6 +484,13 @@ func TestClique(t *testing.T) {
 				copy(nonce[:], nonceAuthVote)
 				gen.SetNonce(nonce)
 			}
+			// Include a transaction if requested, free as fees are credited to the
+			// coinbase here, but to the signer on import
+			if tt.votes[j].txs {
+				gen.SetDifficulty(diffInTurn) // Ignored, we just need a valid number
+				tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), common.Address{}, common.Big1, vars.TxGas, common.Big0, nil), types.HomesteadSigner{}, accounts.accounts["sender"])
+				gen.AddTx(tx)
+			}
 		})
 		// Iterate through the blocks and seal them individually
 		for j, block := range blocks {
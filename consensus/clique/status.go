@@ -0,0 +1,215 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	defaultStatusBlocks = 64          // Number of blocks the status is reported over by default
+	statusMetricsBlocks = 256         // Number of blocks the status metrics are reported over
+	statusMetricsPeriod = time.Second // Minimum interval between status metrics updates
+)
+
+var (
+	statusInturnGauge   = metrics.NewRegisteredGaugeFloat64("clique/status/inturn", nil)
+	statusSignersGauge  = metrics.NewRegisteredGauge("clique/status/signers", nil)
+	statusInactiveGauge = metrics.NewRegisteredGauge("clique/status/inactive", nil)
+)
+
+type status struct {
+	InturnPercent float64                          `json:"inturnPercent"`
+	SigningStatus map[common.Address]int           `json:"sealerActivity"`
+	NumBlocks     uint64                           `json:"numBlocks"`
+	Signers       map[common.Address]*signerStatus `json:"signers"`
+}
+
+// signerStatus is the sealing activity of a signer over a range of blocks.
+type signerStatus struct {
+	Sealed      uint64  `json:"sealed"`      // Number of blocks sealed
+	Inturn      uint64  `json:"inturn"`      // Number of blocks sealed in-turn
+	Outturn     uint64  `json:"outturn"`     // Number of blocks sealed out-of-turn
+	InturnRatio float64 `json:"inturnRatio"` // Ratio of in-turn to sealed blocks
+	LastSealed  *uint64 `json:"lastSealed"`  // Last block sealed, nil if none in range
+	MissedTurns uint64  `json:"missedTurns"` // Number of in-turn blocks sealed by others
+}
+
+// status computes the sealing status over the last numBlocks blocks, up to and
+// including head. The signers are the ones authorized at head, along with any
+// which sealed a block in range.
+func (c *Clique) status(chain consensus.ChainReader, head *types.Header, numBlocks uint64) (*status, error) {
+	end := head.Number.Uint64()
+	if numBlocks > end {
+		numBlocks = end // Genesis is not sealed
+	}
+	start := end - numBlocks + 1
+
+	snap, err := c.snapshot(chain, end, head.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	signers := make(map[common.Address]*signerStatus)
+	for _, signer := range snap.signers() {
+		signers[signer] = new(signerStatus)
+	}
+	get := func(signer common.Address) *signerStatus {
+		if signers[signer] == nil {
+			signers[signer] = new(signerStatus)
+		}
+		return signers[signer]
+	}
+	// Collect the headers in range, backwards from head to follow its ancestry.
+	headers := make([]*types.Header, numBlocks)
+	for i, h := int(numBlocks)-1, head; i >= 0; i-- {
+		if h == nil {
+			return nil, fmt.Errorf("missing block %d", start+uint64(i))
+		}
+		headers[i] = h
+		if i > 0 {
+			h = chain.GetHeader(h.ParentHash, h.Number.Uint64()-1)
+		}
+	}
+	var inturns int
+	for _, h := range headers {
+		number := h.Number.Uint64()
+		sealer, err := c.Author(h)
+		if err != nil {
+			return nil, err
+		}
+		s := get(sealer)
+		s.Sealed++
+		s.LastSealed = &number
+
+		if h.Difficulty.Cmp(diffInTurn) == 0 {
+			s.Inturn++
+			inturns++
+			continue
+		}
+		s.Outturn++
+
+		// The block was sealed out-of-turn, the in-turn signer missed its turn.
+		parent, err := c.snapshot(chain, number-1, h.ParentHash, nil)
+		if err != nil {
+			return nil, err
+		}
+		if parentSigners := parent.signers(); len(parentSigners) > 0 {
+			get(parentSigners[number%uint64(len(parentSigners))]).MissedTurns++
+		}
+	}
+	st := &status{
+		SigningStatus: make(map[common.Address]int),
+		NumBlocks:     numBlocks,
+		Signers:       signers,
+	}
+	if numBlocks > 0 {
+		st.InturnPercent = float64(100*inturns) / float64(numBlocks)
+	}
+	for signer, s := range signers {
+		st.SigningStatus[signer] = int(s.Sealed)
+		if s.Sealed > 0 {
+			s.InturnRatio = float64(s.Inturn) / float64(s.Sealed)
+		}
+	}
+	return st, nil
+}
+
+// StartStatusMetrics reports the sealing status over the last blocks of the
+// chain as metrics, until the engine is closed. It is a no-op if metrics
+// collection is disabled.
+func (c *Clique) StartStatusMetrics(chain consensus.ChainReader) {
+	if !metrics.Enabled {
+		return
+	}
+	go c.statusMetricsLoop(chain)
+}
+
+func (c *Clique) statusMetricsLoop(chain consensus.ChainReader) {
+	ticker := time.NewTicker(statusMetricsPeriod)
+	defer ticker.Stop()
+
+	var (
+		last    common.Hash
+		signers = make(map[common.Address]bool) // Signers with registered metrics
+	)
+	for {
+		select {
+		case <-ticker.C:
+			head := chain.CurrentHeader()
+			if head == nil || head.Hash() == last {
+				continue
+			}
+			last = head.Hash()
+
+			st, err := c.status(chain, head, statusMetricsBlocks)
+			if err != nil {
+				log.Debug("Failed to compute clique status", "number", head.Number, "err", err)
+				continue
+			}
+			c.reportStatus(st, signers)
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// reportStatus updates the status metrics, registering the metrics of new
+// signers and unregistering those of signers gone.
+func (c *Clique) reportStatus(st *status, registered map[common.Address]bool) {
+	statusInturnGauge.Update(st.InturnPercent)
+	statusSignersGauge.Update(int64(len(st.Signers)))
+
+	var inactive int64
+	for signer, s := range st.Signers {
+		if s.Sealed == 0 {
+			inactive++
+		}
+		registered[signer] = true
+
+		metrics.GetOrRegisterGauge(signerMetric(signer, "sealed"), nil).Update(int64(s.Sealed))
+		metrics.GetOrRegisterGauge(signerMetric(signer, "inturn"), nil).Update(int64(s.Inturn))
+		metrics.GetOrRegisterGauge(signerMetric(signer, "outturn"), nil).Update(int64(s.Outturn))
+		metrics.GetOrRegisterGauge(signerMetric(signer, "missed"), nil).Update(int64(s.MissedTurns))
+		if s.LastSealed != nil {
+			metrics.GetOrRegisterGauge(signerMetric(signer, "lastsealed"), nil).Update(int64(*s.LastSealed))
+		}
+	}
+	statusInactiveGauge.Update(inactive)
+
+	for signer := range registered {
+		if st.Signers[signer] != nil {
+			continue
+		}
+		for _, name := range []string{"sealed", "inturn", "outturn", "missed", "lastsealed"} {
+			metrics.DefaultRegistry.Unregister(signerMetric(signer, name))
+		}
+		delete(registered, signer)
+	}
+}
+
+// signerMetric returns the name of a status metric of a signer.
+func signerMetric(signer common.Address, name string) string {
+	return fmt.Sprintf("clique/status/signers/%x/%s", signer, name)
+}
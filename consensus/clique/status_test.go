@@ -0,0 +1,149 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// Tests that the sealing status of the signers is tallied correctly, with one
+// of three signers inactive.
+func TestStatus(t *testing.T) {
+	// Create three signers, in the ascending order of their turns.
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(crypto.PubkeyToAddress(keys[i].PublicKey).Bytes(), crypto.PubkeyToAddress(keys[j].PublicKey).Bytes()) < 0
+	})
+	addrs := make([]common.Address, len(keys))
+	genesis := &genesisT.Genesis{
+		ExtraData: make([]byte, extraVanity+common.AddressLength*len(keys)+extraSeal),
+	}
+	for i, key := range keys {
+		addrs[i] = crypto.PubkeyToAddress(key.PublicKey)
+		copy(genesis.ExtraData[extraVanity+i*common.AddressLength:], addrs[i][:])
+	}
+	db := rawdb.NewMemoryDatabase()
+	core.MustCommitGenesis(db, genesis)
+
+	config := *params.TestChainConfig
+	config.Clique = &ctypes.CliqueConfig{Period: 1, Epoch: 30000}
+	engine := New(config.Clique, db)
+	engine.fakeDiff = true
+	defer engine.Close()
+
+	// Signer 2 is inactive, signers 0 and 1 take turns: block n is in-turn for
+	// signer n%3.
+	sealers := []int{1, 0, 1, 0, 1, 0}
+	blocks, _ := core.GenerateChain(&config, core.GenesisToBlock(genesis, db), engine, db, len(sealers), nil)
+	for i, block := range blocks {
+		header := block.Header()
+		if i > 0 {
+			header.ParentHash = blocks[i-1].Hash()
+		}
+		header.Extra = make([]byte, extraVanity+extraSeal)
+		header.Difficulty = diffNoTurn
+		if (i+1)%len(keys) == sealers[i] {
+			header.Difficulty = diffInTurn
+		}
+		sig, _ := crypto.Sign(SealHash(header).Bytes(), keys[sealers[i]])
+		copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+		blocks[i] = block.WithSeal(header)
+	}
+	chain, err := core.NewBlockChain(db, nil, &config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create test chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	uint64p := func(n uint64) *uint64 { return &n }
+
+	tests := []struct {
+		numBlocks uint64
+		blocks    uint64
+		inturn    float64
+		signers   []signerStatus
+	}{
+		// Blocks 1 and 6 are in-turn, signer 0 missed block 3, signer 1 block 4,
+		// signer 2 blocks 2 and 5.
+		{
+			numBlocks: 64, blocks: 6, inturn: 100 * 2 / 6.0,
+			signers: []signerStatus{
+				{Sealed: 3, Inturn: 1, Outturn: 2, InturnRatio: 1 / 3.0, LastSealed: uint64p(6), MissedTurns: 1},
+				{Sealed: 3, Inturn: 1, Outturn: 2, InturnRatio: 1 / 3.0, LastSealed: uint64p(5), MissedTurns: 1},
+				{MissedTurns: 2},
+			},
+		},
+		{
+			numBlocks: 2, blocks: 2, inturn: 50,
+			signers: []signerStatus{
+				{Sealed: 1, Inturn: 1, InturnRatio: 1, LastSealed: uint64p(6)},
+				{Sealed: 1, Outturn: 1, LastSealed: uint64p(5)},
+				{MissedTurns: 1},
+			},
+		},
+	}
+	api := &API{chain: chain, clique: engine}
+	for i, tt := range tests {
+		st, err := api.Status(&tt.numBlocks)
+		if err != nil {
+			t.Fatalf("test %d: status error: %v", i, err)
+		}
+		if st.NumBlocks != tt.blocks {
+			t.Errorf("test %d: blocks mismatch: have %d, want %d", i, st.NumBlocks, tt.blocks)
+		}
+		if st.InturnPercent != tt.inturn {
+			t.Errorf("test %d: in-turn percentage mismatch: have %v, want %v", i, st.InturnPercent, tt.inturn)
+		}
+		if len(st.Signers) != len(tt.signers) {
+			t.Fatalf("test %d: signers mismatch: have %d, want %d", i, len(st.Signers), len(tt.signers))
+		}
+		for j, want := range tt.signers {
+			have := st.Signers[addrs[j]]
+			if have == nil {
+				t.Errorf("test %d: signer %d missing", i, j)
+				continue
+			}
+			if have.Sealed != want.Sealed || have.Inturn != want.Inturn || have.Outturn != want.Outturn ||
+				have.InturnRatio != want.InturnRatio || have.MissedTurns != want.MissedTurns {
+				t.Errorf("test %d: signer %d status mismatch: have %+v, want %+v", i, j, have, want)
+			}
+			if (have.LastSealed == nil) != (want.LastSealed == nil) || (have.LastSealed != nil && *have.LastSealed != *want.LastSealed) {
+				t.Errorf("test %d: signer %d last sealed mismatch: have %v, want %v", i, j, have.LastSealed, want.LastSealed)
+			}
+			if st.SigningStatus[addrs[j]] != int(want.Sealed) {
+				t.Errorf("test %d: signer %d activity mismatch: have %d, want %d", i, j, st.SigningStatus[addrs[j]], want.Sealed)
+			}
+		}
+	}
+}
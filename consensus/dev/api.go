@@ -0,0 +1,89 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package dev
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+)
+
+// mineStallTimeout is the time after which a mining request is abandoned if the
+// chain doesn't progress, e.g. if the miner isn't running.
+var mineStallTimeout = 10 * time.Second
+
+// errMiningStalled is returned if the chain doesn't progress on mining requests.
+var errMiningStalled = errors.New("mining stalled, is the miner running?")
+
+// API is a user facing RPC API to mine blocks and warp the clock of the
+// developer engine.
+type API struct {
+	chain consensus.ChainReader
+	dev   *Dev
+}
+
+// Mine seals the given number of blocks (one by default) regardless of pending
+// transactions, and returns the hash of the new head block once they are part
+// of the chain.
+func (api *API) Mine(ctx context.Context, n *uint64) (common.Hash, error) {
+	blocks := uint64(1)
+	if n != nil {
+		blocks = *n
+	}
+	var (
+		head   = api.chain.CurrentHeader().Number.Uint64()
+		target = head + blocks
+	)
+	api.dev.Mine(blocks)
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	stall := time.NewTimer(mineStallTimeout)
+	defer stall.Stop()
+
+	for {
+		current := api.chain.CurrentHeader()
+		if number := current.Number.Uint64(); number >= target {
+			return current.Hash(), nil
+		} else if number > head {
+			head = number
+			if !stall.Stop() {
+				<-stall.C
+			}
+			stall.Reset(mineStallTimeout)
+		}
+		select {
+		case <-ticker.C:
+		case <-stall.C:
+			api.dev.cancelMining()
+			return common.Hash{}, errMiningStalled
+		case <-ctx.Done():
+			api.dev.cancelMining()
+			return common.Hash{}, ctx.Err()
+		}
+	}
+}
+
+// SetTime warps the clock of the engine to the given unix time, which must be
+// after the head block. Blocks sealed afterwards have timestamps from there on.
+func (api *API) SetTime(timestamp uint64) error {
+	return api.dev.SetTime(api.chain.CurrentHeader(), timestamp)
+}
@@ -0,0 +1,369 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package dev implements a developer consensus engine, sealing blocks instantly
+// or on a fixed period without proof-of-work, under the rules of any chain
+// configuration.
+package dev
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// errInvalidNumber is returned if a block's number doesn't equal its parent's
+	// plus one.
+	errInvalidNumber = errors.New("invalid block number")
+
+	// errOlderBlockTime is returned if a block's timestamp isn't after its parent's.
+	errOlderBlockTime = errors.New("timestamp older than parent")
+
+	// errUnclesNotAllowed is returned if a block contains uncles, the developer
+	// engine never produces competing blocks.
+	errUnclesNotAllowed = errors.New("uncles not allowed")
+
+	// errTimeNotAfterHead is returned if the clock is set to a time not after the
+	// timestamp of the head block.
+	errTimeNotAfterHead = errors.New("time not after the head block")
+)
+
+// Dev is a consensus engine for development chains. It seals blocks without
+// proof-of-work, every period seconds, or as soon as they have transactions if
+// the period is zero. Proof-of-work chains keep their block rewards and
+// difficulty rules; for other chains there are no rewards and the difficulty is
+// constant.
+//
+// Blocks can also be mined on request, regardless of their transactions, and
+// the clock of the engine can be warped to seal blocks with future timestamps.
+type Dev struct {
+	period uint64         // Seconds between blocks, zero to seal blocks with transactions instantly
+	pow    bool           // Whether the chain follows the proof-of-work rules
+	ethash *ethash.Ethash // Fake proof-of-work engine for the rewards, difficulty and seal hashes
+
+	lock   sync.Mutex
+	offset int64         // Seconds the clock is warped from the system clock
+	warps  uint64        // Number of clock warps, set as nonce of the prepared headers
+	mining uint64        // Number of blocks to seal regardless of their transactions
+	wake   chan struct{} // Closed to wake up the sealing tasks on mining requests and warps
+
+	warpFeed event.Feed
+	scope    event.SubscriptionScope
+}
+
+// New creates a developer engine for the given chain configuration.
+func New(config ctypes.ChainConfigurator, period uint64) *Dev {
+	return &Dev{
+		period: period,
+		pow:    config.GetConsensusEngineType().IsEthash(),
+		ethash: ethash.NewFullFaker(),
+		wake:   make(chan struct{}),
+	}
+}
+
+// Period returns the seconds between blocks, zero if blocks are sealed as soon
+// as they have transactions.
+func (d *Dev) Period() uint64 {
+	return d.period
+}
+
+// Now returns the current time of the engine's clock, in seconds.
+func (d *Dev) Now() int64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return time.Now().Unix() + d.offset
+}
+
+// SetTime warps the clock of the engine to the given time, which must be after
+// the head block. Pending sealing work becomes outdated, subscribers are
+// notified to prepare new work.
+func (d *Dev) SetTime(head *types.Header, now uint64) error {
+	if now <= head.Time {
+		return errTimeNotAfterHead
+	}
+	d.lock.Lock()
+	d.offset = int64(now) - time.Now().Unix()
+	d.warps++
+	d.wakeup()
+	d.lock.Unlock()
+
+	d.warpFeed.Send(struct{}{})
+	return nil
+}
+
+// SubscribeWarp subscribes to warps of the engine's clock, after which pending
+// sealing work has outdated timestamps.
+func (d *Dev) SubscribeWarp(ch chan<- struct{}) event.Subscription {
+	return d.scope.Track(d.warpFeed.Subscribe(ch))
+}
+
+// Mine requests the given number of blocks to be sealed, regardless of their
+// transactions.
+func (d *Dev) Mine(n uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.mining += n
+	d.wakeup()
+}
+
+// wakeup wakes up the waiting sealing tasks. The caller must hold the lock.
+func (d *Dev) wakeup() {
+	close(d.wake)
+	d.wake = make(chan struct{})
+}
+
+// cancelMining drops the outstanding mining requests.
+func (d *Dev) cancelMining() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.mining = 0
+}
+
+// Author implements consensus.Engine, returning the header's coinbase.
+func (d *Dev) Author(header *types.Header) (common.Address, error) {
+	return header.Coinbase, nil
+}
+
+// VerifyHeader implements consensus.Engine, checking whether a header links to
+// its parent. Seals aren't verified, nor are timestamps in the future rejected.
+func (d *Dev) VerifyHeader(chain consensus.ChainReader, header *types.Header, seal bool) error {
+	return d.verifyHeader(chain, header, nil)
+}
+
+// VerifyHeaders is similar to VerifyHeader, but verifies a batch of headers. The
+// method returns a quit channel to abort the operations and a results channel to
+// retrieve the async verifications (the order is that of the input slice).
+func (d *Dev) VerifyHeaders(chain consensus.ChainReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
+	abort := make(chan struct{})
+	results := make(chan error, len(headers))
+
+	go func() {
+		for i, header := range headers {
+			err := d.verifyHeader(chain, header, headers[:i])
+
+			select {
+			case <-abort:
+				return
+			case results <- err:
+			}
+		}
+	}()
+	return abort, results
+}
+
+// verifyHeader checks whether a header links to its parent. The caller may
+// optionally pass in a batch of parents (ascending order) to avoid looking those
+// up from the database.
+func (d *Dev) verifyHeader(chain consensus.ChainReader, header *types.Header, parents []*types.Header) error {
+	if header.Number == nil {
+		return errInvalidNumber
+	}
+	number := header.Number.Uint64()
+
+	var parent *types.Header
+	if len(parents) > 0 {
+		parent = parents[len(parents)-1]
+	} else {
+		parent = chain.GetHeader(header.ParentHash, number-1)
+	}
+	if parent == nil || parent.Hash() != header.ParentHash {
+		return consensus.ErrUnknownAncestor
+	}
+	if parent.Number.Uint64()+1 != number {
+		return errInvalidNumber
+	}
+	if header.Time <= parent.Time {
+		return errOlderBlockTime
+	}
+	if uint64(len(header.Extra)) > vars.MaximumExtraDataSize {
+		return fmt.Errorf("extra-data too long: %d > %d", len(header.Extra), vars.MaximumExtraDataSize)
+	}
+	if header.GasUsed > header.GasLimit {
+		return fmt.Errorf("invalid gasUsed: have %d, gasLimit %d", header.GasUsed, header.GasLimit)
+	}
+	return nil
+}
+
+// VerifyUncles implements consensus.Engine, rejecting any uncles.
+func (d *Dev) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
+	if len(block.Uncles()) > 0 {
+		return errUnclesNotAllowed
+	}
+	return nil
+}
+
+// VerifySeal implements consensus.Engine, accepting any seal.
+func (d *Dev) VerifySeal(chain consensus.ChainReader, header *types.Header) error {
+	return nil
+}
+
+// Prepare implements consensus.Engine, setting the timestamp of the header from
+// the engine's clock, after the period since the parent, and its difficulty.
+func (d *Dev) Prepare(chain consensus.ChainReader, header *types.Header) error {
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	d.lock.Lock()
+	now, warps := time.Now().Unix()+d.offset, d.warps
+	d.lock.Unlock()
+
+	header.Time = parent.Time + d.period
+	if header.Time < uint64(now) {
+		header.Time = uint64(now)
+	}
+	if header.Time <= parent.Time {
+		header.Time = parent.Time + 1
+	}
+	header.Difficulty = d.CalcDifficulty(chain, header.Time, parent)
+
+	// Mark the header with the clock it was prepared with, to not seal it after
+	// a warp
+	header.Nonce = types.EncodeNonce(warps)
+	return nil
+}
+
+// Finalize implements consensus.Engine, accumulating the block rewards of
+// proof-of-work chains and setting the final state on the header.
+func (d *Dev) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header) {
+	if d.pow {
+		d.ethash.Finalize(chain, header, state, txs, uncles)
+		return
+	}
+	header.Root = state.IntermediateRoot(chain.Config().IsEnabled(chain.Config().GetEIP161dTransition, header.Number))
+	header.UncleHash = types.CalcUncleHash(nil)
+}
+
+// FinalizeAndAssemble implements consensus.Engine, accumulating the block
+// rewards of proof-of-work chains, setting the final state and assembling the
+// block.
+func (d *Dev) FinalizeAndAssemble(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header, receipts []*types.Receipt) (*types.Block, error) {
+	d.Finalize(chain, header, state, txs, nil)
+	return types.NewBlock(header, txs, nil, receipts), nil
+}
+
+// Seal implements consensus.Engine, sealing the block once its timestamp is
+// reached if the engine has a period, as soon as possible if it has transactions
+// otherwise, or on a mining request.
+func (d *Dev) Seal(chain consensus.ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	header := block.Header()
+
+	go func() {
+		if !d.wait(header, len(block.Transactions()) > 0, stop) {
+			return
+		}
+		header.Nonce, header.MixDigest = types.BlockNonce{}, common.Hash{}
+
+		select {
+		case results <- block.WithSeal(header):
+		default:
+			log.Warn("Sealing result is not read by miner", "sealhash", d.SealHash(header))
+		}
+	}()
+	return nil
+}
+
+// wait blocks until the header is due or a mining request takes it, returning
+// false if sealing was stopped or the header was prepared before a clock warp.
+func (d *Dev) wait(header *types.Header, txs bool, stop <-chan struct{}) bool {
+	for {
+		// Don't let superseded sealing tasks take mining requests
+		select {
+		case <-stop:
+			return false
+		default:
+		}
+		d.lock.Lock()
+		if header.Nonce.Uint64() != d.warps {
+			d.lock.Unlock()
+			return false
+		}
+		if d.period == 0 && txs {
+			d.lock.Unlock()
+			return true
+		}
+		var (
+			wake  = d.wake
+			delay = time.Duration(int64(header.Time)-time.Now().Unix()-d.offset) * time.Second
+		)
+		if d.mining > 0 {
+			d.mining--
+			d.lock.Unlock()
+			return true
+		}
+		d.lock.Unlock()
+
+		var timer <-chan time.Time
+		if d.period > 0 {
+			timer = time.After(delay)
+		}
+		select {
+		case <-stop:
+			return false
+		case <-timer:
+			return true
+		case <-wake:
+		}
+	}
+}
+
+// SealHash returns the hash of a block prior to it being sealed.
+func (d *Dev) SealHash(header *types.Header) common.Hash {
+	return d.ethash.SealHash(header)
+}
+
+// CalcDifficulty implements consensus.Engine, returning the proof-of-work
+// difficulty for proof-of-work chains, and one otherwise.
+func (d *Dev) CalcDifficulty(chain consensus.ChainReader, time uint64, parent *types.Header) *big.Int {
+	if d.pow {
+		return d.ethash.CalcDifficulty(chain, time, parent)
+	}
+	return big.NewInt(1)
+}
+
+// APIs implements consensus.Engine, returning the user facing RPC API to mine
+// blocks and warp the clock.
+func (d *Dev) APIs(chain consensus.ChainReader) []rpc.API {
+	return []rpc.API{{
+		Namespace: "dev",
+		Version:   "1.0",
+		Service:   &API{chain: chain, dev: d},
+		Public:    false,
+	}}
+}
+
+// Close implements consensus.Engine, unsubscribing all clock warp subscribers.
+func (d *Dev) Close() error {
+	d.scope.Close()
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package dev
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// newTestChain creates a chain of the given configuration sealed by a developer
+// engine, with n blocks.
+func newTestChain(t *testing.T, config ctypes.ChainConfigurator, period uint64, n int) (*Dev, *core.BlockChain, []*types.Block) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		genesis = core.MustCommitGenesis(db, &genesisT.Genesis{Config: config})
+		engine  = New(config, period)
+	)
+	blocks, _ := core.GenerateChain(config, genesis, engine, db, n, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{0x01})
+	})
+	chain, err := core.NewBlockChain(db, nil, config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	return engine, chain, blocks
+}
+
+// Tests that proof-of-work chains keep their rewards and difficulty rules, and
+// other chains don't.
+func TestRules(t *testing.T) {
+	engine, chain, blocks := newTestChain(t, params.TestChainConfig, 0, 2)
+	defer chain.Stop()
+	defer engine.Close()
+
+	state, _ := chain.State()
+	if have, want := state.GetBalance(common.Address{0x01}), new(big.Int).Mul(big.NewInt(2), big.NewInt(2e18)); have.Cmp(want) != 0 {
+		t.Errorf("proof-of-work rewards mismatch: have %v, want %v", have, want)
+	}
+	if have, want := blocks[1].Difficulty(), engine.CalcDifficulty(chain, blocks[1].Time(), blocks[0].Header()); have.Cmp(want) != 0 || have.Cmp(common.Big1) <= 0 {
+		t.Errorf("proof-of-work difficulty mismatch: have %v, want %v", have, want)
+	}
+	engine, chain, blocks = newTestChain(t, params.AllCliqueProtocolChanges, 0, 2)
+	defer chain.Stop()
+	defer engine.Close()
+
+	state, _ = chain.State()
+	if have := state.GetBalance(common.Address{0x01}); have.Sign() != 0 {
+		t.Errorf("proof-of-authority rewards mismatch: have %v, want 0", have)
+	}
+	if have := blocks[1].Difficulty(); have.Cmp(common.Big1) != 0 {
+		t.Errorf("proof-of-authority difficulty mismatch: have %v, want 1", have)
+	}
+}
+
+// Tests that blocks are sealed as soon as they have transactions, or on mining
+// requests.
+func TestSealInstant(t *testing.T) {
+	engine, chain, _ := newTestChain(t, params.TestChainConfig, 0, 0)
+	defer chain.Stop()
+	defer engine.Close()
+
+	header := &types.Header{ParentHash: chain.Genesis().Hash(), Number: big.NewInt(1)}
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatalf("failed to prepare header: %v", err)
+	}
+	results, stop := make(chan *types.Block, 1), make(chan struct{})
+	defer close(stop)
+
+	// A block with transactions is sealed instantly
+	tx := types.NewTransaction(0, common.Address{}, common.Big0, 21000, common.Big1, nil)
+	engine.Seal(chain, types.NewBlock(header, []*types.Transaction{tx}, nil, nil), results, stop)
+	select {
+	case <-results:
+	case <-time.After(time.Second):
+		t.Fatal("block with transactions not sealed")
+	}
+	// An empty block waits for a mining request
+	engine.Seal(chain, types.NewBlockWithHeader(header), results, stop)
+	select {
+	case <-results:
+		t.Fatal("empty block sealed without request")
+	case <-time.After(100 * time.Millisecond):
+	}
+	engine.Mine(1)
+	select {
+	case block := <-results:
+		if block.Nonce() != 0 {
+			t.Errorf("sealed block nonce mismatch: have %d, want 0", block.Nonce())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("empty block not sealed on request")
+	}
+}
+
+// Tests that blocks are sealed once their timestamp is reached if the engine has
+// a period.
+func TestSealPeriod(t *testing.T) {
+	engine, chain, _ := newTestChain(t, params.TestChainConfig, 1, 0)
+	defer chain.Stop()
+	defer engine.Close()
+
+	header := &types.Header{ParentHash: chain.Genesis().Hash(), Number: big.NewInt(1)}
+	engine.Prepare(chain, header)
+
+	results, stop := make(chan *types.Block, 1), make(chan struct{})
+	defer close(stop)
+
+	engine.Seal(chain, types.NewBlockWithHeader(header), results, stop)
+	select {
+	case block := <-results:
+		if now := uint64(time.Now().Unix()); block.Time() > now {
+			t.Errorf("block sealed before its time: %d > %d", block.Time(), now)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("block not sealed after period")
+	}
+}
+
+// Tests that warping the clock changes the timestamps of the prepared headers,
+// and outdates the headers prepared before.
+func TestSetTime(t *testing.T) {
+	engine, chain, _ := newTestChain(t, params.TestChainConfig, 0, 1)
+	defer chain.Stop()
+	defer engine.Close()
+
+	head := chain.CurrentHeader()
+	if err := engine.SetTime(head, head.Time); err != errTimeNotAfterHead {
+		t.Fatalf("clock set to head time: have %v, want %v", err, errTimeNotAfterHead)
+	}
+	outdated := &types.Header{ParentHash: head.Hash(), Number: big.NewInt(2)}
+	engine.Prepare(chain, outdated)
+
+	warps := make(chan struct{}, 1)
+	sub := engine.SubscribeWarp(warps)
+	defer sub.Unsubscribe()
+
+	future := uint64(time.Now().Add(24 * time.Hour).Unix())
+	if err := engine.SetTime(head, future); err != nil {
+		t.Fatalf("failed to set clock: %v", err)
+	}
+	select {
+	case <-warps:
+	default:
+		t.Error("clock warp not notified")
+	}
+	header := &types.Header{ParentHash: head.Hash(), Number: big.NewInt(2)}
+	engine.Prepare(chain, header)
+	if header.Time < future || header.Time > future+1 {
+		t.Errorf("prepared time mismatch: have %d, want %d", header.Time, future)
+	}
+	if err := engine.VerifyHeader(chain, header, true); err != nil {
+		t.Errorf("future header rejected: %v", err)
+	}
+	// Headers prepared before the warp are not sealed
+	results, stop := make(chan *types.Block, 2), make(chan struct{})
+	defer close(stop)
+
+	engine.Seal(chain, types.NewBlockWithHeader(outdated), results, stop)
+	engine.Mine(1)
+	select {
+	case <-results:
+		t.Fatal("outdated header sealed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	engine.Seal(chain, types.NewBlockWithHeader(header), results, stop)
+	select {
+	case block := <-results:
+		if block.Time() != header.Time {
+			t.Errorf("sealed time mismatch: have %d, want %d", block.Time(), header.Time)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("header not sealed on request")
+	}
+}
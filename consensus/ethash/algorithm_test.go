@@ -56,6 +56,44 @@ func TestSizeCalculations(t *testing.T) {
 	}
 }
 
+// Tests that the epoch length, epoch and seed calculations are correct across
+// the ECIP-1099 transition.
+func TestCalcEpochECIP1099(t *testing.T) {
+	ecip1099FBlock := uint64(11_700_000)
+
+	tests := []struct {
+		block       uint64
+		epochLength uint64
+		epoch       uint64
+	}{
+		{0, epochLengthDefault, 0},
+		{29_999, epochLengthDefault, 0},
+		{30_000, epochLengthDefault, 1},
+		{11_699_999, epochLengthDefault, 389},
+		{11_700_000, epochLengthECIP1099, 195},
+		{11_759_999, epochLengthECIP1099, 195},
+		{11_760_000, epochLengthECIP1099, 196},
+	}
+	for i, tt := range tests {
+		epochLength := calcEpochLength(tt.block, &ecip1099FBlock)
+		if epochLength != tt.epochLength {
+			t.Errorf("test %d: epoch length mismatch: have %d, want %d", i, epochLength, tt.epochLength)
+		}
+		if epoch := calcEpoch(tt.block, epochLength); epoch != tt.epoch {
+			t.Errorf("test %d: epoch mismatch: have %d, want %d", i, epoch, tt.epoch)
+		}
+	}
+	if epochLength := calcEpochLength(11_700_000, nil); epochLength != epochLengthDefault {
+		t.Errorf("epoch length mismatch without ECIP-1099: have %d, want %d", epochLength, epochLengthDefault)
+	}
+	// Seeds progress in steps of the default epoch length, regardless of the epoch length.
+	for _, epoch := range []uint64{0, 1, 195, 196} {
+		if have, want := seedHash(epoch, epochLengthECIP1099), seedHash(epoch*2, epochLengthDefault); !bytes.Equal(have, want) {
+			t.Errorf("epoch %d: seed mismatch: have %x, want %x", epoch, have, want)
+		}
+	}
+}
+
 // Tests that verification caches can be correctly generated.
 func TestCacheGeneration(t *testing.T) {
 	tests := []struct {
@@ -108,7 +146,7 @@ func TestCacheGeneration(t *testing.T) {
 	}
 	for i, tt := range tests {
 		cache := make([]uint32, tt.size/4)
-		generateCache(cache, tt.epoch, seedHash(tt.epoch*epochLength+1))
+		generateCache(cache, tt.epoch, seedHash(tt.epoch, epochLengthDefault))
 
 		want := make([]uint32, tt.size/4)
 		prepare(want, tt.cache)
@@ -648,7 +686,7 @@ func TestDatasetGeneration(t *testing.T) {
 	}
 	for i, tt := range tests {
 		cache := make([]uint32, tt.cacheSize/4)
-		generateCache(cache, tt.epoch, seedHash(tt.epoch*epochLength+1))
+		generateCache(cache, tt.epoch, seedHash(tt.epoch, epochLengthDefault))
 
 		dataset := make([]uint32, tt.datasetSize/4)
 		generateDataset(dataset, tt.epoch, cache)
@@ -729,7 +767,7 @@ func TestConcurrentDiskCacheGeneration(t *testing.T) {
 
 		go func(idx int) {
 			defer pend.Done()
-			ethash := New(Config{cachedir, 0, 1, false, "", 0, 0, false, ModeNormal, nil}, nil, false)
+			ethash := New(Config{cachedir, 0, 1, false, "", 0, 0, false, ModeNormal, "", 0, nil, nil, nil}, nil, false)
 			defer ethash.Close()
 			if err := ethash.VerifySeal(nil, block.Header()); err != nil {
 				t.Errorf("proc %d: block verification failed: %v", idx, err)
@@ -742,7 +780,7 @@ func TestConcurrentDiskCacheGeneration(t *testing.T) {
 // Benchmarks the cache generation performance.
 func BenchmarkCacheGeneration(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		cache := make([]uint32, cacheSize(1)/4)
+		cache := make([]uint32, cacheSize(0)/4)
 		generateCache(cache, 0, make([]byte, 32))
 	}
 }
@@ -761,14 +799,14 @@ func BenchmarkSmallDatasetGeneration(b *testing.B) {
 
 // Benchmarks the light verification performance.
 func BenchmarkHashimotoLight(b *testing.B) {
-	cache := make([]uint32, cacheSize(1)/4)
+	cache := make([]uint32, cacheSize(0)/4)
 	generateCache(cache, 0, make([]byte, 32))
 
 	hash := hexutil.MustDecode("0xc9149cc0386e689d789a1c2f3d5d169a61a6218ed30e74414dc736e442ef3d1f")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		hashimotoLight(datasetSize(1), cache, hash, 0)
+		hashimotoLight(datasetSize(0), cache, hash, 0)
 	}
 }
 
@@ -478,49 +478,53 @@ func (ethash *Ethash) verifySeal(chain consensus.ChainReader, header *types.Head
 		return errInvalidDifficulty
 	}
 	// Recompute the digest and PoW values
-	number := header.Number.Uint64()
+	digest, result := ethash.computePoW(header.Number.Uint64(), ethash.SealHash(header).Bytes(), header.Nonce.Uint64(), fulldag)
 
-	var (
-		digest []byte
-		result []byte
-	)
+	// Verify the calculated values against the ones provided in the header
+	if !bytes.Equal(header.MixDigest[:], digest) {
+		return errInvalidMixDigest
+	}
+	target := new(big.Int).Div(two256, header.Difficulty)
+	if new(big.Int).SetBytes(result).Cmp(target) > 0 {
+		return errInvalidPoW
+	}
+	return nil
+}
+
+// computePoW calculates the mix digest and PoW value of a seal hash and nonce
+// at the given block number, using an ethash dataset if fulldag is requested
+// and generated, an ethash cache otherwise, or Keccak-256 past ECIP-1049.
+func (ethash *Ethash) computePoW(number uint64, hash []byte, nonce uint64, fulldag bool) (digest []byte, result []byte) {
+	// Keccak-256 PoW needs neither an ethash dataset nor a cache
+	if ethash.isECIP1049(number) {
+		return hashKeccak(hash, nonce)
+	}
 	// If fast-but-heavy PoW verification was requested, use an ethash dataset
 	if fulldag {
 		dataset := ethash.dataset(number, true)
 		if dataset.generated() {
-			digest, result = hashimotoFull(dataset.dataset, ethash.SealHash(header).Bytes(), header.Nonce.Uint64())
+			digest, result = hashimotoFull(dataset.dataset, hash, nonce)
 
 			// Datasets are unmapped in a finalizer. Ensure that the dataset stays alive
 			// until after the call to hashimotoFull so it's not unmapped while being used.
 			runtime.KeepAlive(dataset)
-		} else {
-			// Dataset not yet generated, don't hang, use a cache instead
-			fulldag = false
+			return digest, result
 		}
+		// Dataset not yet generated, don't hang, use a cache instead
 	}
 	// If slow-but-light PoW verification was requested (or DAG not yet ready), use an ethash cache
-	if !fulldag {
-		cache := ethash.cache(number)
-
-		size := datasetSize(number)
-		if ethash.config.PowMode == ModeTest {
-			size = 32 * 1024
-		}
-		digest, result = hashimotoLight(size, cache.cache, ethash.SealHash(header).Bytes(), header.Nonce.Uint64())
+	cache := ethash.cache(number)
 
-		// Caches are unmapped in a finalizer. Ensure that the cache stays alive
-		// until after the call to hashimotoLight so it's not unmapped while being used.
-		runtime.KeepAlive(cache)
-	}
-	// Verify the calculated values against the ones provided in the header
-	if !bytes.Equal(header.MixDigest[:], digest) {
-		return errInvalidMixDigest
-	}
-	target := new(big.Int).Div(two256, header.Difficulty)
-	if new(big.Int).SetBytes(result).Cmp(target) > 0 {
-		return errInvalidPoW
+	size := datasetSize(cache.epoch)
+	if ethash.config.PowMode == ModeTest {
+		size = 32 * 1024
 	}
-	return nil
+	digest, result = hashimotoLight(size, cache.cache, hash, nonce)
+
+	// Caches are unmapped in a finalizer. Ensure that the cache stays alive
+	// until after the call to hashimotoLight so it's not unmapped while being used.
+	runtime.KeepAlive(cache)
+	return digest, result
 }
 
 // Prepare implements consensus.Engine, initializing the difficulty field of a
@@ -586,27 +590,57 @@ var (
 // reward. The total reward consists of the static block reward and rewards for
 // included uncles. The coinbase of each uncle block is also rewarded.
 func accumulateRewards(config ctypes.ChainConfigurator, state *state.StateDB, header *types.Header, uncles []*types.Header) {
-	if config.IsEnabled(config.GetEthashECIP1017Transition, header.Number) {
-		ecip1017BlockReward(config, state, header, uncles)
-		return
+	reward, uncleRewards := GetRewards(config, header, uncles)
+	reward.Sub(reward, payTreasury(config, state, header, BlockWinnerReward(config, header.Number)))
+	for i, uncle := range uncles {
+		state.AddBalance(uncle.Coinbase, uncleRewards[i])
 	}
+	state.AddBalance(header.Coinbase, reward)
+}
 
+// GetRewards returns the rewards issued by the given block to its miner and to
+// the miners of each of its uncles. The reward of the miner includes the share
+// credited to the treasury, if a block reward split is in effect.
+func GetRewards(config ctypes.ChainConfigurator, header *types.Header, uncles []*types.Header) (*big.Int, []*big.Int) {
+	if config.IsEnabled(config.GetEthashECIP1017Transition, header.Number) {
+		return ecip1017BlockRewards(config, header, uncles)
+	}
 	blockReward := ctypes.EthashBlockReward(config, header.Number)
 
 	// Accumulate the rewards for the miner and any included uncles
 	reward := new(big.Int).Set(blockReward)
-	r := new(big.Int)
-	for _, uncle := range uncles {
-		r.Add(uncle.Number, big8)
+	uncleRewards := make([]*big.Int, len(uncles))
+	for i, uncle := range uncles {
+		r := new(big.Int).Add(uncle.Number, big8)
 		r.Sub(r, header.Number)
 		r.Mul(r, blockReward)
 		r.Div(r, big8)
-		state.AddBalance(uncle.Coinbase, r)
+		uncleRewards[i] = r
 
-		r.Div(blockReward, big32)
-		reward.Add(reward, r)
+		reward.Add(reward, new(big.Int).Div(blockReward, big32))
 	}
-	state.AddBalance(header.Coinbase, reward)
+	return reward, uncleRewards
+}
+
+// BlockWinnerReward returns the static reward of the miner of the block with the
+// given number, which excludes the rewards for included uncles.
+func BlockWinnerReward(config ctypes.ChainConfigurator, number *big.Int) *big.Int {
+	if config.IsEnabled(config.GetEthashECIP1017Transition, number) {
+		return ecip1017BlockWinnerReward(config, number)
+	}
+	return ctypes.EthashBlockReward(config, number)
+}
+
+// payTreasury credits the treasury with its share of the block winner's reward,
+// if a block reward split is in effect, and returns the share.
+func payTreasury(config ctypes.ChainConfigurator, state *state.StateDB, header *types.Header, reward *big.Int) *big.Int {
+	split, ok := ctypes.EthashBlockRewardSplit(config, header.Number)
+	if !ok {
+		return new(big.Int)
+	}
+	share := split.Share(reward)
+	state.AddBalance(split.Address, share)
+	return share
 }
 
 // As of "Era 2" (zero-index era 1), uncle miners and winners are rewarded equally for each included block.
@@ -18,13 +18,12 @@ package ethash
 import (
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/vars"
 )
 
-func ecip1017BlockReward(config ctypes.ChainConfigurator, state *state.StateDB, header *types.Header, uncles []*types.Header) {
+func ecip1017BlockRewards(config ctypes.ChainConfigurator, header *types.Header, uncles []*types.Header) (*big.Int, []*big.Int) {
 	blockReward := vars.FrontierBlockReward
 
 	// Ensure value 'era' is configured.
@@ -33,13 +32,20 @@ func ecip1017BlockReward(config ctypes.ChainConfigurator, state *state.StateDB,
 	wr := GetBlockWinnerRewardByEra(era, blockReward)                    // wr "winner reward". 5, 4, 3.2, 2.56, ...
 	wurs := GetBlockWinnerRewardForUnclesByEra(era, uncles, blockReward) // wurs "winner uncle rewards"
 	wr.Add(wr, wurs)
-	state.AddBalance(header.Coinbase, wr) // $$
 
 	// Reward uncle miners.
-	for _, uncle := range uncles {
-		ur := GetBlockUncleRewardByEra(era, header, uncle, blockReward)
-		state.AddBalance(uncle.Coinbase, ur) // $$
+	urs := make([]*big.Int, len(uncles))
+	for i, uncle := range uncles {
+		urs[i] = GetBlockUncleRewardByEra(era, header, uncle, blockReward)
 	}
+	return wr, urs
+}
+
+// ecip1017BlockWinnerReward returns the reward of the block winner by era, which
+// the treasury shares if a block reward split is in effect.
+func ecip1017BlockWinnerReward(config ctypes.ChainConfigurator, number *big.Int) *big.Int {
+	era := GetBlockEra(number, new(big.Int).SetUint64(*config.GetEthashECIP1017EraRounds()))
+	return GetBlockWinnerRewardByEra(era, vars.FrontierBlockReward)
 }
 
 func ecip1010Explosion(config ctypes.ChainConfigurator, next *big.Int, exPeriodRef *big.Int) {
@@ -23,9 +23,15 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/vars"
 )
 
 type diffTest struct {
@@ -84,3 +90,81 @@ func TestCalcDifficulty(t *testing.T) {
 		}
 	}
 }
+
+// Tests that block reward splits pay the exact treasury share of the winner's
+// reward, leaving the uncle rewards untouched.
+func TestAccumulateRewardsSplit(t *testing.T) {
+	var (
+		miner    = common.HexToAddress("0xaa")
+		uncler   = common.HexToAddress("0xbb")
+		treasury = common.HexToAddress("0xcc")
+		eraLen   = uint64(10)
+	)
+	split := ctypes.RewardSplitSchedule{
+		0: {Address: treasury, Percentage: 10},
+	}
+	cases := []struct {
+		name                    string
+		config                  *coregeth.CoreGethChainConfig
+		number                  int64
+		winner, uncle, treasury string
+	}{
+		{
+			name: "block reward schedule",
+			config: &coregeth.CoreGethChainConfig{
+				Ethash:                   new(ctypes.EthashConfig),
+				BlockRewardSchedule:      ctypes.Uint64BigMapEncodesHex{0: vars.FrontierBlockReward},
+				BlockRewardSplitSchedule: split,
+			},
+			number: 10,
+			// 5 - 0.5 treasury + 5/32 for the uncle
+			winner: "4656250000000000000",
+			// 5 * (9 + 8 - 10) / 8
+			uncle:    "4375000000000000000",
+			treasury: "500000000000000000",
+		},
+		{
+			name: "ecip1017",
+			config: &coregeth.CoreGethChainConfig{
+				Ethash:                   new(ctypes.EthashConfig),
+				ECIP1017FBlock:           big.NewInt(0),
+				ECIP1017EraRounds:        new(big.Int).SetUint64(eraLen),
+				BlockRewardSplitSchedule: split,
+			},
+			number: 15,
+			// Era 2: 4 - 0.4 treasury + 4/32 for the uncle
+			winner: "3725000000000000000",
+			// Era 2: 4/32
+			uncle:    "125000000000000000",
+			treasury: "400000000000000000",
+		},
+	}
+	for _, c := range cases {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		header := &types.Header{Number: big.NewInt(c.number), Coinbase: miner}
+		uncles := []*types.Header{{Number: big.NewInt(c.number - 1), Coinbase: uncler}}
+		accumulateRewards(c.config, statedb, header, uncles)
+
+		for _, want := range []struct {
+			addr    common.Address
+			balance string
+		}{{miner, c.winner}, {uncler, c.uncle}, {treasury, c.treasury}} {
+			if got := statedb.GetBalance(want.addr); got.String() != want.balance {
+				t.Errorf("%s: balance of %x: got %v, want %s", c.name, want.addr, got, want.balance)
+			}
+		}
+		// Without a split, the treasury share goes to the miner.
+		statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		c.config.BlockRewardSplitSchedule = nil
+		accumulateRewards(c.config, statedb, header, uncles)
+
+		winner, _ := new(big.Int).SetString(c.winner, 10)
+		share, _ := new(big.Int).SetString(c.treasury, 10)
+		if got, want := statedb.GetBalance(miner), winner.Add(winner, share); got.Cmp(want) != 0 {
+			t.Errorf("%s: balance of miner without split: got %v, want %v", c.name, got, want)
+		}
+		if got := statedb.GetBalance(treasury); got.Sign() != 0 {
+			t.Errorf("%s: balance of treasury without split: got %v, want 0", c.name, got)
+		}
+	}
+}
@@ -48,7 +48,7 @@ var (
 	two256 = new(big.Int).Exp(big.NewInt(2), big.NewInt(256), big.NewInt(0))
 
 	// sharedEthash is a full instance that can be shared between multiple users.
-	sharedEthash = New(Config{"", 3, 0, false, "", 1, 0, false, ModeNormal, nil}, nil, false)
+	sharedEthash = New(Config{"", 3, 0, false, "", 1, 0, false, ModeNormal, "", 0, nil, nil, nil}, nil, false)
 
 	// algorithmRevision is the data structure version used for file naming.
 	algorithmRevision = 23
@@ -152,21 +152,34 @@ func memoryMapAndGenerate(path string, size uint64, lock bool, generator func(bu
 	return memoryMap(path, lock)
 }
 
+// lruKey identifies a cache or dataset by its epoch and epoch length.
+// The epoch length is part of the key because epoch numbers are not unique
+// across the ECIP-1099 transition.
+type lruKey struct {
+	epoch       uint64
+	epochLength uint64
+}
+
+// block returns the first block number of the key's epoch.
+func (k lruKey) block() uint64 {
+	return k.epoch * k.epochLength
+}
+
 // lru tracks caches or datasets by their last use time, keeping at most N of them.
 type lru struct {
 	what string
-	new  func(epoch uint64) interface{}
+	new  func(epoch uint64, epochLength uint64) interface{}
 	mu   sync.Mutex
 	// Items are kept in a LRU cache, but there is a special case:
 	// We always keep an item for (highest seen epoch) + 1 as the 'future item'.
 	cache      *simplelru.LRU
-	future     uint64
+	future     lruKey
 	futureItem interface{}
 }
 
 // newlru create a new least-recently-used cache for either the verification caches
 // or the mining datasets.
-func newlru(what string, maxItems int, new func(epoch uint64) interface{}) *lru {
+func newlru(what string, maxItems int, new func(epoch uint64, epochLength uint64) interface{}) *lru {
 	if maxItems <= 0 {
 		maxItems = 1
 	}
@@ -179,51 +192,71 @@ func newlru(what string, maxItems int, new func(epoch uint64) interface{}) *lru
 // get retrieves or creates an item for the given epoch. The first return value is always
 // non-nil. The second return value is non-nil if lru thinks that an item will be useful in
 // the near future.
-func (lru *lru) get(epoch uint64) (item, future interface{}) {
+// The ECIP-1099 activation block, if any, is used to determine the epoch length
+// of the future item.
+func (lru *lru) get(epoch uint64, epochLength uint64, ecip1099FBlock *uint64) (item, future interface{}) {
 	lru.mu.Lock()
 	defer lru.mu.Unlock()
 
 	// Get or create the item for the requested epoch.
-	item, ok := lru.cache.Get(epoch)
+	key := lruKey{epoch: epoch, epochLength: epochLength}
+	item, ok := lru.cache.Get(key)
 	if !ok {
-		if lru.future > 0 && lru.future == epoch {
+		if lru.futureItem != nil && lru.future == key {
 			item = lru.futureItem
 		} else {
-			log.Trace("Requiring new ethash "+lru.what, "epoch", epoch)
-			item = lru.new(epoch)
+			log.Trace("Requiring new ethash "+lru.what, "epoch", epoch, "epochLength", epochLength)
+			item = lru.new(epoch, epochLength)
 		}
-		lru.cache.Add(epoch, item)
+		lru.cache.Add(key, item)
+	}
+	// Ensure the 'future item' is generated with the correct epoch length
+	// if the next epoch begins at or after the ECIP-1099 transition.
+	next := lruKey{epoch: epoch + 1, epochLength: epochLength}
+	if nextLength := calcEpochLength(next.block(), ecip1099FBlock); nextLength != epochLength {
+		next = lruKey{epoch: calcEpoch(next.block(), nextLength), epochLength: nextLength}
 	}
 	// Update the 'future item' if epoch is larger than previously seen.
-	if epoch < maxEpoch-1 && lru.future < epoch+1 {
-		log.Trace("Requiring new future ethash "+lru.what, "epoch", epoch+1)
-		future = lru.new(epoch + 1)
-		lru.future = epoch + 1
+	if next.epoch < maxEpoch && (lru.futureItem == nil || lru.future.block() < next.block()) {
+		log.Trace("Requiring new future ethash "+lru.what, "epoch", next.epoch, "epochLength", next.epochLength)
+		future = lru.new(next.epoch, next.epochLength)
+		lru.future = next
 		lru.futureItem = future
 	}
 	return item, future
 }
 
+// fileSuffix returns the file name suffix for caches and datasets stored on disk.
+// Items of ECIP-1099 epochs share their seeds with default length epochs,
+// but not their sizes, so they have to be named distinctly.
+func fileSuffix(epochLength uint64) string {
+	if epochLength == epochLengthDefault {
+		return ""
+	}
+	return fmt.Sprintf("-E%d", epochLength)
+}
+
 // cache wraps an ethash cache with some metadata to allow easier concurrent use.
 type cache struct {
-	epoch uint64    // Epoch for which this cache is relevant
-	dump  *os.File  // File descriptor of the memory mapped cache
-	mmap  mmap.MMap // Memory map itself to unmap before releasing
-	cache []uint32  // The actual cache data content (may be memory mapped)
-	once  sync.Once // Ensures the cache is generated only once
+	epoch       uint64    // Epoch for which this cache is relevant
+	epochLength uint64    // Epoch length (ECIP-1099)
+	dump        *os.File  // File descriptor of the memory mapped cache
+	mmap        mmap.MMap // Memory map itself to unmap before releasing
+	cache       []uint32  // The actual cache data content (may be memory mapped)
+	once        sync.Once // Ensures the cache is generated only once
 }
 
 // newCache creates a new ethash verification cache and returns it as a plain Go
 // interface to be usable in an LRU cache.
-func newCache(epoch uint64) interface{} {
-	return &cache{epoch: epoch}
+func newCache(epoch uint64, epochLength uint64) interface{} {
+	return &cache{epoch: epoch, epochLength: epochLength}
 }
 
 // generate ensures that the cache content is generated before use.
 func (c *cache) generate(dir string, limit int, lock bool, test bool) {
 	c.once.Do(func() {
-		size := cacheSize(c.epoch*epochLength + 1)
-		seed := seedHash(c.epoch*epochLength + 1)
+		size := cacheSize(c.epoch)
+		seed := seedHash(c.epoch, c.epochLength)
 		if test {
 			size = 1024
 		}
@@ -238,8 +271,8 @@ func (c *cache) generate(dir string, limit int, lock bool, test bool) {
 		if !isLittleEndian() {
 			endian = ".be"
 		}
-		path := filepath.Join(dir, fmt.Sprintf("cache-R%d-%x%s", algorithmRevision, seed[:8], endian))
-		logger := log.New("epoch", c.epoch)
+		path := filepath.Join(dir, fmt.Sprintf("cache-R%d-%x%s%s", algorithmRevision, seed[:8], fileSuffix(c.epochLength), endian))
+		logger := log.New("epoch", c.epoch, "epochLength", c.epochLength)
 
 		// We're about to mmap the file, ensure that the mapping is cleaned up when the
 		// cache becomes unused.
@@ -264,10 +297,18 @@ func (c *cache) generate(dir string, limit int, lock bool, test bool) {
 		}
 		// Iterate over all previous instances and delete old ones
 		for ep := int(c.epoch) - limit; ep >= 0; ep-- {
-			seed := seedHash(uint64(ep)*epochLength + 1)
-			path := filepath.Join(dir, fmt.Sprintf("cache-R%d-%x%s", algorithmRevision, seed[:8], endian))
+			seed := seedHash(uint64(ep), c.epochLength)
+			path := filepath.Join(dir, fmt.Sprintf("cache-R%d-%x%s%s", algorithmRevision, seed[:8], fileSuffix(c.epochLength), endian))
 			os.Remove(path)
 		}
+		// Also delete any default length epoch caches left behind by the ECIP-1099 transition
+		if c.epochLength != epochLengthDefault {
+			for ep := int(calcEpoch(c.epoch*c.epochLength, epochLengthDefault)) - limit; ep >= 0; ep-- {
+				seed := seedHash(uint64(ep), epochLengthDefault)
+				path := filepath.Join(dir, fmt.Sprintf("cache-R%d-%x%s", algorithmRevision, seed[:8], endian))
+				os.Remove(path)
+			}
+		}
 	})
 }
 
@@ -282,18 +323,19 @@ func (c *cache) finalizer() {
 
 // dataset wraps an ethash dataset with some metadata to allow easier concurrent use.
 type dataset struct {
-	epoch   uint64    // Epoch for which this cache is relevant
-	dump    *os.File  // File descriptor of the memory mapped cache
-	mmap    mmap.MMap // Memory map itself to unmap before releasing
-	dataset []uint32  // The actual cache data content
-	once    sync.Once // Ensures the cache is generated only once
-	done    uint32    // Atomic flag to determine generation status
+	epoch       uint64    // Epoch for which this cache is relevant
+	epochLength uint64    // Epoch length (ECIP-1099)
+	dump        *os.File  // File descriptor of the memory mapped cache
+	mmap        mmap.MMap // Memory map itself to unmap before releasing
+	dataset     []uint32  // The actual cache data content
+	once        sync.Once // Ensures the cache is generated only once
+	done        uint32    // Atomic flag to determine generation status
 }
 
 // newDataset creates a new ethash mining dataset and returns it as a plain Go
 // interface to be usable in an LRU cache.
-func newDataset(epoch uint64) interface{} {
-	return &dataset{epoch: epoch}
+func newDataset(epoch uint64, epochLength uint64) interface{} {
+	return &dataset{epoch: epoch, epochLength: epochLength}
 }
 
 // generate ensures that the dataset content is generated before use.
@@ -302,9 +344,9 @@ func (d *dataset) generate(dir string, limit int, lock bool, test bool) {
 		// Mark the dataset generated after we're done. This is needed for remote
 		defer atomic.StoreUint32(&d.done, 1)
 
-		csize := cacheSize(d.epoch*epochLength + 1)
-		dsize := datasetSize(d.epoch*epochLength + 1)
-		seed := seedHash(d.epoch*epochLength + 1)
+		csize := cacheSize(d.epoch)
+		dsize := datasetSize(d.epoch)
+		seed := seedHash(d.epoch, d.epochLength)
 		if test {
 			csize = 1024
 			dsize = 32 * 1024
@@ -324,8 +366,8 @@ func (d *dataset) generate(dir string, limit int, lock bool, test bool) {
 		if !isLittleEndian() {
 			endian = ".be"
 		}
-		path := filepath.Join(dir, fmt.Sprintf("full-R%d-%x%s", algorithmRevision, seed[:8], endian))
-		logger := log.New("epoch", d.epoch)
+		path := filepath.Join(dir, fmt.Sprintf("full-R%d-%x%s%s", algorithmRevision, seed[:8], fileSuffix(d.epochLength), endian))
+		logger := log.New("epoch", d.epoch, "epochLength", d.epochLength)
 
 		// We're about to mmap the file, ensure that the mapping is cleaned up when the
 		// cache becomes unused.
@@ -353,10 +395,18 @@ func (d *dataset) generate(dir string, limit int, lock bool, test bool) {
 		}
 		// Iterate over all previous instances and delete old ones
 		for ep := int(d.epoch) - limit; ep >= 0; ep-- {
-			seed := seedHash(uint64(ep)*epochLength + 1)
-			path := filepath.Join(dir, fmt.Sprintf("full-R%d-%x%s", algorithmRevision, seed[:8], endian))
+			seed := seedHash(uint64(ep), d.epochLength)
+			path := filepath.Join(dir, fmt.Sprintf("full-R%d-%x%s%s", algorithmRevision, seed[:8], fileSuffix(d.epochLength), endian))
 			os.Remove(path)
 		}
+		// Also delete any default length epoch datasets left behind by the ECIP-1099 transition
+		if d.epochLength != epochLengthDefault {
+			for ep := int(calcEpoch(d.epoch*d.epochLength, epochLengthDefault)) - limit; ep >= 0; ep-- {
+				seed := seedHash(uint64(ep), epochLengthDefault)
+				path := filepath.Join(dir, fmt.Sprintf("full-R%d-%x%s", algorithmRevision, seed[:8], endian))
+				os.Remove(path)
+			}
+		}
 	})
 }
 
@@ -377,14 +427,14 @@ func (d *dataset) finalizer() {
 }
 
 // MakeCache generates a new ethash cache and optionally stores it to disk.
-func MakeCache(block uint64, dir string) {
-	c := cache{epoch: block / epochLength}
+func MakeCache(block uint64, epochLength uint64, dir string) {
+	c := cache{epoch: calcEpoch(block, epochLength), epochLength: epochLength}
 	c.generate(dir, math.MaxInt32, false, false)
 }
 
 // MakeDataset generates a new ethash dataset and optionally stores it to disk.
-func MakeDataset(block uint64, dir string) {
-	d := dataset{epoch: block / epochLength}
+func MakeDataset(block uint64, epochLength uint64, dir string) {
+	d := dataset{epoch: calcEpoch(block, epochLength), epochLength: epochLength}
 	d.generate(dir, math.MaxInt32, false, false)
 }
 
@@ -411,6 +461,20 @@ type Config struct {
 	DatasetsLockMmap bool
 	PowMode          Mode
 
+	// StratumAddr is the listening address of the stratum mining server (empty = disabled).
+	StratumAddr string
+	// StratumDifficulty is the difficulty of the shares accepted by the stratum
+	// server (0 = block difficulty).
+	StratumDifficulty uint64
+
+	// ECIP1099Block is the block number of the ECIP-1099 epoch length doubling
+	// (nil = never), as configured by the chain configuration.
+	ECIP1099Block *uint64 `toml:"-"`
+
+	// ECIP1049Block is the block number from which blocks are sealed with
+	// Keccak-256 instead of ethash (nil = never), as configured by the chain configuration.
+	ECIP1049Block *uint64 `toml:"-"`
+
 	Log log.Logger `toml:"-"`
 }
 
@@ -543,6 +607,10 @@ func (ethash *Ethash) Close() error {
 		if ethash.remote == nil {
 			return
 		}
+		// Disconnect the stratum miners while the remote sealer still serves them.
+		if ethash.remote.stratum != nil {
+			ethash.remote.stratum.close()
+		}
 		close(ethash.remote.requestExit)
 		<-ethash.remote.exitCh
 	})
@@ -553,8 +621,9 @@ func (ethash *Ethash) Close() error {
 // by first checking against a list of in-memory caches, then against caches
 // stored on disk, and finally generating one if none can be found.
 func (ethash *Ethash) cache(block uint64) *cache {
-	epoch := block / epochLength
-	currentI, futureI := ethash.caches.get(epoch)
+	epochLength := calcEpochLength(block, ethash.config.ECIP1099Block)
+	epoch := calcEpoch(block, epochLength)
+	currentI, futureI := ethash.caches.get(epoch, epochLength, ethash.config.ECIP1099Block)
 	current := currentI.(*cache)
 
 	// Wait for generation finish.
@@ -576,8 +645,9 @@ func (ethash *Ethash) cache(block uint64) *cache {
 // generates on a background thread.
 func (ethash *Ethash) dataset(block uint64, async bool) *dataset {
 	// Retrieve the requested ethash dataset
-	epoch := block / epochLength
-	currentI, futureI := ethash.datasets.get(epoch)
+	epochLength := calcEpochLength(block, ethash.config.ECIP1099Block)
+	epoch := calcEpoch(block, epochLength)
+	currentI, futureI := ethash.datasets.get(epoch, epochLength, ethash.config.ECIP1099Block)
 	current := currentI.(*dataset)
 
 	// If async is specified, generate everything in a background thread
@@ -675,8 +745,18 @@ func (ethash *Ethash) APIs(chain consensus.ChainReader) []rpc.API {
 	}
 }
 
+// CalcEpochLength returns the epoch length for a given block number (ECIP-1099).
+func CalcEpochLength(block uint64, ecip1099FBlock *uint64) uint64 {
+	return calcEpochLength(block, ecip1099FBlock)
+}
+
+// CalcEpoch returns the epoch for a given block number and epoch length.
+func CalcEpoch(block uint64, epochLength uint64) uint64 {
+	return calcEpoch(block, epochLength)
+}
+
 // SeedHash is the seed to use for generating a verification cache and the mining
-// dataset.
-func SeedHash(block uint64) []byte {
-	return seedHash(block)
+// dataset for the given epoch and epoch length.
+func SeedHash(epoch uint64, epochLength uint64) []byte {
+	return seedHash(epoch, epochLength)
 }
@@ -54,6 +54,43 @@ func TestTestMode(t *testing.T) {
 	}
 }
 
+// Tests that blocks past the ECIP-1049 transition are sealed and verified with
+// Keccak-256, and that ethash seals are not accepted for them.
+func TestKeccakMode(t *testing.T) {
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(100)}
+
+	ethash := NewTester(nil, false)
+	defer ethash.Close()
+
+	ecip1049 := uint64(1)
+	ethash.config.ECIP1049Block = &ecip1049
+
+	results := make(chan *types.Block)
+	err := ethash.Seal(nil, types.NewBlockWithHeader(header), results, nil)
+	if err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	select {
+	case block := <-results:
+		header.Nonce = types.EncodeNonce(block.Nonce())
+		header.MixDigest = block.MixDigest()
+		digest, _ := hashKeccak(ethash.SealHash(header).Bytes(), block.Nonce())
+		if header.MixDigest != common.BytesToHash(digest) {
+			t.Fatalf("mix digest mismatch: have %x, want %x", header.MixDigest, digest)
+		}
+		if err := ethash.VerifySeal(nil, header); err != nil {
+			t.Fatalf("unexpected verification error: %v", err)
+		}
+		// The same seal is not a valid ethash seal.
+		ethash.config.ECIP1049Block = nil
+		if err := ethash.VerifySeal(nil, header); err == nil {
+			t.Fatal("keccak seal accepted as ethash seal")
+		}
+	case <-time.NewTimer(2 * time.Second).C:
+		t.Error("sealing result timeout")
+	}
+}
+
 // This test checks that cache lru logic doesn't crash under load.
 // It reproduces https://github.com/ethereum/go-ethereum/issues/14943
 func TestCacheFileEvict(t *testing.T) {
@@ -78,10 +115,10 @@ func TestCacheFileEvict(t *testing.T) {
 func verifyTest(wg *sync.WaitGroup, e *Ethash, workerIndex, epochs int) {
 	defer wg.Done()
 
-	const wiggle = 4 * epochLength
+	const wiggle = 4 * epochLengthDefault
 	r := rand.New(rand.NewSource(int64(workerIndex)))
 	for epoch := 0; epoch < epochs; epoch++ {
-		block := int64(epoch)*epochLength - wiggle/2 + r.Int63n(wiggle)
+		block := int64(epoch)*epochLengthDefault - wiggle/2 + r.Int63n(wiggle)
 		if block < 0 {
 			block = 0
 		}
@@ -90,6 +127,39 @@ func verifyTest(wg *sync.WaitGroup, e *Ethash, workerIndex, epochs int) {
 	}
 }
 
+// Tests that the lru pre-generates the correct future item across the ECIP-1099
+// transition, and that items of equal epoch but different epoch lengths are distinct.
+func TestLRUECIP1099(t *testing.T) {
+	ecip1099FBlock := uint64(120_000)
+	l := newlru("cache", 3, newCache)
+
+	// Epoch 3 is the last default length epoch, the future item must be 60k epoch 2.
+	_, futureI := l.get(3, epochLengthDefault, &ecip1099FBlock)
+	if futureI == nil {
+		t.Fatal("missing future item")
+	}
+	if future := futureI.(*cache); future.epoch != 2 || future.epochLength != epochLengthECIP1099 {
+		t.Errorf("future item mismatch: have %d/%d, want %d/%d", future.epoch, future.epochLength, 2, epochLengthECIP1099)
+	}
+	// The future item must be reused once requested.
+	prevFuture := futureI
+	itemI, futureI := l.get(2, epochLengthECIP1099, &ecip1099FBlock)
+	if itemI != prevFuture {
+		t.Error("future item not reused")
+	}
+	if futureI == nil {
+		t.Fatal("missing future item")
+	}
+	if future := futureI.(*cache); future.epoch != 3 || future.epochLength != epochLengthECIP1099 {
+		t.Errorf("future item mismatch: have %d/%d, want %d/%d", future.epoch, future.epochLength, 3, epochLengthECIP1099)
+	}
+	// Default length epoch 2 is a different item than 60k epoch 2.
+	itemI, _ = l.get(2, epochLengthDefault, &ecip1099FBlock)
+	if item := itemI.(*cache); item.epochLength != epochLengthDefault {
+		t.Errorf("item epoch length mismatch: have %d, want %d", item.epochLength, epochLengthDefault)
+	}
+}
+
 func TestRemoteSealer(t *testing.T) {
 	ethash := NewTester(nil, false)
 	defer ethash.Close()
@@ -128,6 +198,49 @@ func TestRemoteSealer(t *testing.T) {
 	}
 }
 
+func TestRemoteSealerKeccak(t *testing.T) {
+	ethash := NewTester(nil, false)
+	defer ethash.Close()
+
+	ecip1049 := uint64(0)
+	ethash.config.ECIP1049Block = &ecip1049
+
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(100)}
+	block := types.NewBlockWithHeader(header)
+	sealhash := ethash.SealHash(header)
+
+	results := make(chan *types.Block, 1)
+	ethash.Seal(nil, block, results, nil)
+
+	api := &API{ethash}
+	work, err := api.GetWork()
+	if err != nil || work[0] != sealhash.Hex() {
+		t.Fatal("expect to return a mining work has same hash")
+	}
+	if work[1] != (common.Hash{}).Hex() {
+		t.Errorf("seed hash mismatch: have %s, want zero hash", work[1])
+	}
+	// Search for a solution like an external miner would.
+	target := new(big.Int).Div(two256, header.Difficulty)
+	for nonce := uint64(0); ; nonce++ {
+		digest, result := hashKeccak(sealhash.Bytes(), nonce)
+		if new(big.Int).SetBytes(result).Cmp(target) <= 0 {
+			if !api.SubmitWork(types.EncodeNonce(nonce), sealhash, common.BytesToHash(digest)) {
+				t.Fatal("expect to accept a valid keccak solution")
+			}
+			break
+		}
+	}
+	select {
+	case sealed := <-results:
+		if sealed.Header().Number.Cmp(header.Number) != 0 {
+			t.Errorf("sealed block number mismatch: have %v, want %v", sealed.Number(), header.Number)
+		}
+	case <-time.NewTimer(2 * time.Second).C:
+		t.Error("sealing result timeout")
+	}
+}
+
 func TestHashRate(t *testing.T) {
 	var (
 		hashrate = []hexutil.Uint64{100, 200, 300}
@@ -0,0 +1,45 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethash
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// isECIP1049 tells whether a block is sealed with the ECIP-1049 Keccak-256
+// proof-of-work instead of ethash.
+func (ethash *Ethash) isECIP1049(number uint64) bool {
+	return ethash.config.ECIP1049Block != nil && number >= *ethash.config.ECIP1049Block
+}
+
+// hashKeccak computes the ECIP-1049 proof-of-work of a seal hash and nonce: the
+// Keccak-256 hash of the seal hash followed by the nonce. Unlike hashimoto, it
+// needs no cache nor dataset; the digest carried in the header's mix digest
+// field is the proof-of-work value itself.
+func hashKeccak(hash []byte, nonce uint64) ([]byte, []byte) {
+	seed := make([]byte, 40)
+	copy(seed, hash)
+	binary.LittleEndian.PutUint64(seed[32:], nonce)
+
+	result := make([]byte, 32)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(seed)
+	hasher.Sum(result[:0])
+	return result, result
+}
@@ -136,8 +136,12 @@ func (ethash *Ethash) mine(block *types.Block, id int, seed uint64, abort chan s
 		hash    = ethash.SealHash(header).Bytes()
 		target  = new(big.Int).Div(two256, header.Difficulty)
 		number  = header.Number.Uint64()
-		dataset = ethash.dataset(number, false)
+		keccak  = ethash.isECIP1049(number)
+		dataset *dataset
 	)
+	if !keccak {
+		dataset = ethash.dataset(number, false)
+	}
 	// Start generating random nonces until we abort or find a good one
 	var (
 		attempts = int64(0)
@@ -162,7 +166,12 @@ search:
 				attempts = 0
 			}
 			// Compute the PoW value of this nonce
-			digest, result := hashimotoFull(dataset.dataset, hash, nonce)
+			var digest, result []byte
+			if keccak {
+				digest, result = hashKeccak(hash, nonce)
+			} else {
+				digest, result = hashimotoFull(dataset.dataset, hash, nonce)
+			}
 			if new(big.Int).SetBytes(result).Cmp(target) <= 0 {
 				// Correct nonce found, create a new header with it
 				header = types.CopyHeader(header)
@@ -201,6 +210,7 @@ type remoteSealer struct {
 	ethash       *Ethash
 	noverify     bool
 	notifyURLs   []string
+	stratum      *stratumServer // Stratum server pushing work to miners, nil if disabled
 	results      chan<- *types.Block
 	workCh       chan *sealTask   // Notification channel to push new work and relative result channel to remote sealer
 	fetchWorkCh  chan *sealWork   // Channel used for remote sealer to fetch mining work
@@ -259,6 +269,14 @@ func startRemoteSealer(ethash *Ethash, urls []string, noverify bool) *remoteSeal
 		requestExit:  make(chan struct{}),
 		exitCh:       make(chan struct{}),
 	}
+	if addr := ethash.config.StratumAddr; addr != "" {
+		stratum, err := startStratumServer(s, addr, ethash.config.StratumDifficulty)
+		if err != nil {
+			ethash.config.Log.Error("Failed to start stratum server", "addr", addr, "err", err)
+		} else {
+			s.stratum = stratum
+		}
+	}
 	go s.loop()
 	return s
 }
@@ -339,13 +357,19 @@ func (s *remoteSealer) loop() {
 //
 // The work package consists of 3 strings:
 //   result[0], 32 bytes hex encoded current block header pow-hash
-//   result[1], 32 bytes hex encoded seed hash used for DAG
+//   result[1], 32 bytes hex encoded seed hash used for DAG (zero for ECIP-1049 Keccak-256 PoW)
 //   result[2], 32 bytes hex encoded boundary condition ("target"), 2^256/difficulty
 //   result[3], hex encoded block number
 func (s *remoteSealer) makeWork(block *types.Block) {
 	hash := s.ethash.SealHash(block.Header())
 	s.currentWork[0] = hash.Hex()
-	s.currentWork[1] = common.BytesToHash(SeedHash(block.NumberU64())).Hex()
+	if s.ethash.isECIP1049(block.NumberU64()) {
+		s.currentWork[1] = common.Hash{}.Hex()
+	} else {
+		epochLength := calcEpochLength(block.NumberU64(), s.ethash.config.ECIP1099Block)
+		epoch := calcEpoch(block.NumberU64(), epochLength)
+		s.currentWork[1] = common.BytesToHash(SeedHash(epoch, epochLength)).Hex()
+	}
 	s.currentWork[2] = common.BytesToHash(new(big.Int).Div(two256, block.Difficulty()).Bytes()).Hex()
 	s.currentWork[3] = hexutil.EncodeBig(block.Number())
 
@@ -363,6 +387,9 @@ func (s *remoteSealer) notifyWork() {
 	for _, url := range s.notifyURLs {
 		go s.sendNotification(s.notifyCtx, url, blob, work)
 	}
+	if s.stratum != nil {
+		s.stratum.notify(work)
+	}
 }
 
 func (s *remoteSealer) sendNotification(ctx context.Context, url string, json []byte, work [4]string) {
@@ -438,3 +465,27 @@ func (s *remoteSealer) submitWork(nonce types.BlockNonce, mixDigest common.Hash,
 	s.ethash.config.Log.Warn("Work submitted is too old", "number", solution.NumberU64(), "sealhash", sealhash, "hash", solution.Hash())
 	return false
 }
+
+// submitSolution passes a pow solution to the remote sealer thread, returning
+// whether it was accepted.
+func (s *remoteSealer) submitSolution(nonce types.BlockNonce, mixDigest common.Hash, sealhash common.Hash) bool {
+	errc := make(chan error, 1)
+	select {
+	case s.submitWorkCh <- &mineResult{nonce: nonce, mixDigest: mixDigest, hash: sealhash, errc: errc}:
+	case <-s.exitCh:
+		return false
+	}
+	return <-errc == nil
+}
+
+// submitRate passes the hash rate of a miner to the remote sealer thread.
+func (s *remoteSealer) submitRate(rate uint64, id common.Hash) bool {
+	done := make(chan struct{}, 1)
+	select {
+	case s.submitRateCh <- &hashrate{done: done, rate: rate, id: id}:
+	case <-s.exitCh:
+		return false
+	}
+	<-done
+	return true
+}
@@ -0,0 +1,766 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethash
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Stratum dialects spoken by the stratum server. The dialect of a connection is
+// chosen by the first method called by the miner.
+const (
+	stratumDialectNiceHash = "EthereumStratum/1.0.0" // mining.subscribe, mining.authorize, mining.submit
+	stratumDialectProxy    = "eth-proxy"             // eth_submitLogin, eth_getWork, eth_submitWork
+)
+
+const (
+	stratumReadTimeout    = 10 * time.Minute // Maximum time a miner may stay silent
+	stratumWriteTimeout   = 10 * time.Second // Maximum time to deliver a message to a miner
+	stratumMaxMessageSize = 16 * 1024        // Maximum size of a miner request
+	stratumOutboxSize     = 16               // Messages queued for a miner before it is dropped
+	stratumExtranonceSize = 2                // Bytes of the nonce assigned to a EthereumStratum session
+
+	stratumHashrateInterval = 5 * time.Second  // Interval of submitting worker hashrates, and ticking their share rates
+	stratumWorkerActive     = 30 * time.Second // Time since a worker's last share or report to count its hashrate
+	stratumWorkerExpiry     = 10 * time.Minute // Time since a worker's last activity to forget it
+)
+
+var (
+	stratumConnGauge      = metrics.NewRegisteredGauge("ethash/stratum/connections", nil)
+	stratumValidMeter     = metrics.NewRegisteredMeter("ethash/stratum/shares/valid", nil)
+	stratumInvalidMeter   = metrics.NewRegisteredMeter("ethash/stratum/shares/invalid", nil)
+	stratumStaleMeter     = metrics.NewRegisteredMeter("ethash/stratum/shares/stale", nil)
+	stratumDuplicateMeter = metrics.NewRegisteredMeter("ethash/stratum/shares/duplicate", nil)
+	stratumBlockMeter     = metrics.NewRegisteredMeter("ethash/stratum/blocks", nil)
+)
+
+var (
+	errStratumUnknownMethod = &stratumError{20, "unknown method"}
+	errStratumInvalidParams = &stratumError{20, "invalid params"}
+	errStratumNoWork        = &stratumError{20, "no mining work available yet"}
+	errStratumStaleShare    = &stratumError{21, "job not found"}
+	errStratumDuplicate     = &stratumError{22, "duplicate share"}
+	errStratumLowDifficulty = &stratumError{23, "low difficulty share"}
+	errStratumUnauthorized  = &stratumError{24, "unauthorized worker"}
+	errStratumNotSubscribed = &stratumError{25, "not subscribed"}
+	errStratumInvalidMix    = &stratumError{20, "invalid mix digest"}
+)
+
+// stratumError is an error returned to a miner.
+type stratumError struct {
+	code    int
+	message string
+}
+
+func (e *stratumError) Error() string { return e.message }
+
+// stratumRequest is a request of a miner.
+type stratumRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Worker string          `json:"worker"` // eth-proxy worker name
+}
+
+// stratumResponse is a response to a miner request, or an eth-proxy work push.
+type stratumResponse struct {
+	ID      json.RawMessage `json:"id"`
+	Version string          `json:"jsonrpc,omitempty"`
+	Result  interface{}     `json:"result"`
+	Error   interface{}     `json:"error"`
+}
+
+// stratumNotification is a EthereumStratum server to miner notification.
+type stratumNotification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// stratumJob is a work package handed out to the miners.
+type stratumJob struct {
+	hash        common.Hash
+	seed        common.Hash
+	number      uint64
+	target      *big.Int // block boundary, 2^256/difficulty
+	shareTarget *big.Int // share boundary, never below the block boundary
+	shares      map[uint64]struct{}
+}
+
+// id returns the identifier of the job in EthereumStratum notifications.
+func (job *stratumJob) id() string {
+	return job.hash.Hex()[2:]
+}
+
+// shareDifficulty returns the difficulty matching the share boundary.
+func (job *stratumJob) shareDifficulty() *big.Int {
+	return new(big.Int).Div(two256, job.shareTarget)
+}
+
+// stratumWorker tracks the hashrate of a mining rig.
+type stratumWorker struct {
+	id       common.Hash   // Hashrate identifier, as submitted by the rig or derived from its name
+	reported uint64        // Hashrate reported by the rig, if any
+	shares   metrics.EWMA  // Difficulty of the accepted shares, its rate is the effective hashrate
+	gauge    metrics.Gauge // Hashrate last submitted for the rig
+	reportAt time.Time     // Time of the last hashrate report
+	activeAt time.Time     // Time of the last share or hashrate report
+}
+
+// stratumServer serves work packages of the remote sealer to miners speaking
+// the EthereumStratum/1.0.0 or eth-proxy stratum dialects, validates their shares
+// against the share difficulty and submits the solutions to the remote sealer.
+type stratumServer struct {
+	ethash     *Ethash
+	sealer     *remoteSealer
+	listener   net.Listener
+	difficulty *big.Int // Share difficulty, nil to accept block solutions only
+
+	lock       sync.Mutex
+	conns      map[*stratumConn]struct{}
+	jobs       map[common.Hash]*stratumJob
+	current    *stratumJob
+	workers    map[string]*stratumWorker
+	extranonce uint16
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// startStratumServer starts listening for miners of a remote sealer on the given
+// address.
+func startStratumServer(sealer *remoteSealer, addr string, difficulty uint64) (*stratumServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &stratumServer{
+		ethash:   sealer.ethash,
+		sealer:   sealer,
+		listener: listener,
+		conns:    make(map[*stratumConn]struct{}),
+		jobs:     make(map[common.Hash]*stratumJob),
+		workers:  make(map[string]*stratumWorker),
+		quit:     make(chan struct{}),
+	}
+	if difficulty > 0 {
+		s.difficulty = new(big.Int).SetUint64(difficulty)
+	}
+	s.wg.Add(2)
+	go s.acceptLoop()
+	go s.hashrateLoop()
+
+	s.ethash.config.Log.Info("Stratum server started", "addr", listener.Addr(), "difficulty", difficulty)
+	return s, nil
+}
+
+// close disconnects all miners and stops the server.
+func (s *stratumServer) close() {
+	close(s.quit)
+	s.listener.Close()
+
+	s.lock.Lock()
+	for c := range s.conns {
+		c.close()
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+}
+
+// notify turns a new work package of the remote sealer into a job, and pushes
+// it to all miners.
+func (s *stratumServer) notify(work [4]string) {
+	number, err := hexutil.DecodeUint64(work[3])
+	if err != nil {
+		return
+	}
+	job := &stratumJob{
+		hash:   common.HexToHash(work[0]),
+		seed:   common.HexToHash(work[1]),
+		number: number,
+		target: new(big.Int).SetBytes(common.HexToHash(work[2]).Bytes()),
+		shares: make(map[uint64]struct{}),
+	}
+	job.shareTarget = job.target
+	if s.difficulty != nil {
+		if target := new(big.Int).Div(two256, s.difficulty); target.Cmp(job.target) > 0 {
+			job.shareTarget = target
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if old := s.jobs[job.hash]; old != nil {
+		// Same work pushed again, keep the submitted shares.
+		job.shares = old.shares
+	}
+	s.jobs[job.hash] = job
+	s.current = job
+
+	// Forget the jobs too old for their solutions to be accepted by the sealer.
+	for hash, old := range s.jobs {
+		if old.number+staleThreshold <= job.number {
+			delete(s.jobs, hash)
+		}
+	}
+	for c := range s.conns {
+		c.pushJob(job)
+	}
+}
+
+// currentJob returns the last job pushed to the miners.
+func (s *stratumServer) currentJob() *stratumJob {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.current
+}
+
+func (s *stratumServer) acceptLoop() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+			s.ethash.config.Log.Warn("Stratum server failed to accept connection", "err", err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		c := &stratumConn{
+			server: s,
+			conn:   conn,
+			out:    make(chan interface{}, stratumOutboxSize),
+			closed: make(chan struct{}),
+		}
+		s.lock.Lock()
+		select {
+		case <-s.quit:
+			s.lock.Unlock()
+			conn.Close()
+			return
+		default:
+		}
+		c.extranonce = fmt.Sprintf("%0*x", 2*stratumExtranonceSize, s.extranonce)
+		s.extranonce++
+		s.conns[c] = struct{}{}
+		stratumConnGauge.Update(int64(len(s.conns)))
+		s.lock.Unlock()
+
+		s.wg.Add(2)
+		go c.readLoop()
+		go c.writeLoop()
+	}
+}
+
+// hashrateLoop periodically submits the hashrate of the active workers to the
+// remote sealer.
+func (s *stratumServer) hashrateLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(stratumHashrateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.submitHashrates()
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// submitHashrates submits the hashrate of the active workers to the remote sealer,
+// either as reported by them, or as estimated from their shares. The share rates
+// are ticked on every call, which must happen every stratumHashrateInterval as
+// the rates are computed for 5 second ticks.
+func (s *stratumServer) submitHashrates() {
+	type submission struct {
+		id   common.Hash
+		rate uint64
+	}
+	var submissions []submission
+
+	s.lock.Lock()
+	for name, w := range s.workers {
+		if time.Since(w.activeAt) > stratumWorkerExpiry {
+			delete(s.workers, name)
+			metrics.DefaultRegistry.Unregister(stratumWorkerMetric(name, "hashrate"))
+			continue
+		}
+		w.shares.Tick()
+		if time.Since(w.activeAt) > stratumWorkerActive {
+			w.gauge.Update(0)
+			continue
+		}
+		rate := uint64(w.shares.Rate())
+		if time.Since(w.reportAt) <= stratumWorkerActive {
+			rate = w.reported
+		}
+		w.gauge.Update(int64(rate))
+		submissions = append(submissions, submission{w.id, rate})
+	}
+	s.lock.Unlock()
+
+	// Submit without holding the lock, the remote sealer pushes work through it.
+	for _, sub := range submissions {
+		s.sealer.submitRate(sub.rate, sub.id)
+	}
+}
+
+// worker returns the hashrate tracker of a mining rig, creating it if needed.
+// The caller must hold the lock.
+func (s *stratumServer) worker(name string) *stratumWorker {
+	w := s.workers[name]
+	if w == nil {
+		w = &stratumWorker{
+			id:     crypto.Keccak256Hash([]byte(name)),
+			shares: metrics.NewEWMA1(),
+			gauge:  metrics.DefaultRegistry.GetOrRegister(stratumWorkerMetric(name, "hashrate"), new(metrics.StandardGauge)).(metrics.Gauge),
+		}
+		s.workers[name] = w
+	}
+	w.activeAt = time.Now()
+	return w
+}
+
+// submitHashrate records the hashrate reported by a mining rig and forwards it
+// to the remote sealer.
+func (s *stratumServer) submitHashrate(name string, rate uint64, id common.Hash) {
+	s.lock.Lock()
+	w := s.worker(name)
+	if id != (common.Hash{}) {
+		w.id = id
+	}
+	w.reported, w.reportAt = rate, time.Now()
+	w.gauge.Update(int64(rate))
+	id = w.id
+	s.lock.Unlock()
+
+	s.sealer.submitRate(rate, id)
+}
+
+// submitShare validates a share of a mining rig against the share boundary of
+// its job, and submits it to the remote sealer if it also solves the block. The
+// mix digest is verified if the rig provided it.
+func (s *stratumServer) submitShare(name string, hash common.Hash, nonce uint64, mixDigest *common.Hash) error {
+	s.lock.Lock()
+	job := s.jobs[hash]
+	if job == nil {
+		s.lock.Unlock()
+		stratumStaleMeter.Mark(1)
+		return errStratumStaleShare
+	}
+	if _, ok := job.shares[nonce]; ok {
+		s.lock.Unlock()
+		stratumDuplicateMeter.Mark(1)
+		return errStratumDuplicate
+	}
+	s.lock.Unlock()
+
+	pow := s.ethash
+	if pow.shared != nil {
+		pow = pow.shared
+	}
+	digest, result := pow.computePoW(job.number, hash.Bytes(), nonce, true)
+	if mixDigest != nil && *mixDigest != common.BytesToHash(digest) {
+		stratumInvalidMeter.Mark(1)
+		return errStratumInvalidMix
+	}
+	value := new(big.Int).SetBytes(result)
+	if value.Cmp(job.shareTarget) > 0 {
+		stratumInvalidMeter.Mark(1)
+		return errStratumLowDifficulty
+	}
+	// Only count valid shares as submitted, checking again for duplicates
+	// submitted while computing.
+	s.lock.Lock()
+	if _, ok := job.shares[nonce]; ok {
+		s.lock.Unlock()
+		stratumDuplicateMeter.Mark(1)
+		return errStratumDuplicate
+	}
+	job.shares[nonce] = struct{}{}
+	s.worker(name).shares.Update(job.shareDifficulty().Int64())
+	s.lock.Unlock()
+	stratumValidMeter.Mark(1)
+
+	if value.Cmp(job.target) <= 0 {
+		if !s.sealer.submitSolution(types.EncodeNonce(nonce), common.BytesToHash(digest), hash) {
+			s.ethash.config.Log.Warn("Stratum block solution rejected", "worker", name, "number", job.number, "sealhash", hash)
+			return errStratumStaleShare
+		}
+		s.ethash.config.Log.Info("Stratum block solution found", "worker", name, "number", job.number, "sealhash", hash)
+		stratumBlockMeter.Mark(1)
+	}
+	return nil
+}
+
+// stratumWorkerMetric returns the name of a metric of a mining rig.
+func stratumWorkerMetric(name, metric string) string {
+	return "ethash/stratum/workers/" + name + "/" + metric
+}
+
+// stratumWorkerName sanitizes the worker name given by a mining rig, so it can
+// be used in metric names and logs.
+func stratumWorkerName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	if name == "" {
+		name = "default"
+	}
+	return name
+}
+
+// stratumConn is the connection of a mining rig to the stratum server.
+type stratumConn struct {
+	server     *stratumServer
+	conn       net.Conn
+	dialect    string
+	extranonce string   // Hex encoded nonce prefix of a EthereumStratum session
+	worker     string   // Worker name, empty until authorized
+	difficulty *big.Int // Share difficulty last notified to a EthereumStratum rig
+
+	lock      sync.Mutex // Protects the fields above, accessed by the work pushes
+	out       chan interface{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// close disconnects the mining rig.
+func (c *stratumConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.conn.Close()
+	})
+}
+
+// send queues a message to the mining rig, dropping the rig if it can't keep up.
+func (c *stratumConn) send(msg interface{}) {
+	select {
+	case c.out <- msg:
+	case <-c.closed:
+	default:
+		c.server.ethash.config.Log.Debug("Dropping slow stratum miner", "addr", c.conn.RemoteAddr())
+		c.close()
+	}
+}
+
+// pushJob sends a job to the mining rig, if it is ready to receive work.
+func (c *stratumConn) pushJob(job *stratumJob) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.worker == "" {
+		return
+	}
+	switch c.dialect {
+	case stratumDialectNiceHash:
+		if diff := job.shareDifficulty(); c.difficulty == nil || c.difficulty.Cmp(diff) != 0 {
+			c.difficulty = diff
+			c.send(&stratumNotification{Method: "mining.set_difficulty", Params: []interface{}{stratumDifficulty(diff)}})
+		}
+		c.send(&stratumNotification{Method: "mining.notify", Params: []interface{}{job.id(), job.seed.Hex()[2:], job.hash.Hex()[2:], true}})
+	case stratumDialectProxy:
+		c.send(&stratumResponse{ID: json.RawMessage("0"), Version: "2.0", Result: stratumProxyWork(job)})
+	}
+}
+
+// stratumDifficulty converts a share difficulty to a EthereumStratum difficulty,
+// where difficulty 1 stands for 2^32 hashes.
+func stratumDifficulty(diff *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(diff), big.NewFloat(1<<32)).Float64()
+	return f
+}
+
+// stratumProxyWork returns the eth-proxy work package of a job, with the share
+// boundary as target.
+func stratumProxyWork(job *stratumJob) []string {
+	return []string{
+		job.hash.Hex(),
+		job.seed.Hex(),
+		common.BytesToHash(job.shareTarget.Bytes()).Hex(),
+		hexutil.EncodeUint64(job.number),
+	}
+}
+
+func (c *stratumConn) readLoop() {
+	defer func() {
+		c.close()
+
+		c.server.lock.Lock()
+		delete(c.server.conns, c)
+		stratumConnGauge.Update(int64(len(c.server.conns)))
+		c.server.lock.Unlock()
+
+		c.server.wg.Done()
+	}()
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 1024), stratumMaxMessageSize)
+	for {
+		c.conn.SetReadDeadline(time.Now().Add(stratumReadTimeout))
+		if !scanner.Scan() {
+			return
+		}
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var req stratumRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			c.server.ethash.config.Log.Debug("Invalid stratum request", "addr", c.conn.RemoteAddr(), "err", err)
+			return
+		}
+		result, err := c.handle(&req)
+
+		c.lock.Lock()
+		res := &stratumResponse{ID: req.ID, Result: result}
+		if c.dialect == stratumDialectProxy {
+			res.Version = "2.0"
+		}
+		if err != nil {
+			res.Result = nil
+			res.Error = c.encodeError(err)
+		}
+		c.send(res)
+		c.lock.Unlock()
+
+		// Hand out work right after the rig is ready to receive it.
+		if err == nil && (req.Method == "mining.authorize" || req.Method == "eth_submitLogin") {
+			if job := c.server.currentJob(); job != nil {
+				c.pushJob(job)
+			}
+		}
+	}
+}
+
+func (c *stratumConn) writeLoop() {
+	defer c.server.wg.Done()
+
+	for {
+		select {
+		case msg := <-c.out:
+			blob, err := json.Marshal(msg)
+			if err != nil {
+				c.server.ethash.config.Log.Error("Failed to encode stratum message", "err", err)
+				continue
+			}
+			c.conn.SetWriteDeadline(time.Now().Add(stratumWriteTimeout))
+			if _, err := c.conn.Write(append(blob, '\n')); err != nil {
+				c.close()
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// encodeError formats an error as expected by the dialect of the mining rig.
+func (c *stratumConn) encodeError(err error) interface{} {
+	serr, ok := err.(*stratumError)
+	if !ok {
+		serr = &stratumError{20, err.Error()}
+	}
+	if c.dialect == stratumDialectProxy {
+		return map[string]interface{}{"code": serr.code, "message": serr.message}
+	}
+	return []interface{}{serr.code, serr.message, nil}
+}
+
+// handle executes a request of the mining rig.
+func (c *stratumConn) handle(req *stratumRequest) (interface{}, error) {
+	var params []interface{}
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, errStratumInvalidParams
+		}
+	}
+	c.lock.Lock()
+	if c.dialect == "" {
+		switch {
+		case strings.HasPrefix(req.Method, "mining."):
+			c.dialect = stratumDialectNiceHash
+		case strings.HasPrefix(req.Method, "eth_"):
+			c.dialect = stratumDialectProxy
+		}
+	}
+	dialect, worker := c.dialect, c.worker
+	c.lock.Unlock()
+
+	switch req.Method {
+	// EthereumStratum/1.0.0
+	case "mining.subscribe":
+		if dialect != stratumDialectNiceHash {
+			return nil, errStratumUnknownMethod
+		}
+		return []interface{}{
+			[]interface{}{"mining.notify", c.extranonce, stratumDialectNiceHash},
+			c.extranonce,
+		}, nil
+
+	case "mining.extranonce.subscribe":
+		return true, nil
+
+	case "mining.authorize":
+		if dialect != stratumDialectNiceHash {
+			return nil, errStratumUnknownMethod
+		}
+		name, ok := stringParam(params, 0)
+		if !ok {
+			return nil, errStratumInvalidParams
+		}
+		c.lock.Lock()
+		c.worker = stratumWorkerName(name)
+		c.lock.Unlock()
+		return true, nil
+
+	case "mining.submit":
+		if dialect != stratumDialectNiceHash {
+			return nil, errStratumNotSubscribed
+		}
+		if worker == "" {
+			return nil, errStratumUnauthorized
+		}
+		jobID, ok1 := stringParam(params, 1)
+		suffix, ok2 := stringParam(params, 2)
+		if !ok1 || !ok2 {
+			return nil, errStratumInvalidParams
+		}
+		nonce, err := strconv.ParseUint(c.extranonce+strings.TrimPrefix(suffix, "0x"), 16, 64)
+		if err != nil || len(c.extranonce)+len(strings.TrimPrefix(suffix, "0x")) != 16 {
+			return nil, errStratumInvalidParams
+		}
+		if err := c.server.submitShare(worker, common.HexToHash(jobID), nonce, nil); err != nil {
+			return nil, err
+		}
+		return true, nil
+
+	// eth-proxy
+	case "eth_submitLogin":
+		name, ok := stringParam(params, 0)
+		if !ok {
+			return nil, errStratumInvalidParams
+		}
+		if req.Worker != "" {
+			name += "." + req.Worker
+		}
+		c.lock.Lock()
+		c.worker = stratumWorkerName(name)
+		c.lock.Unlock()
+		return true, nil
+
+	case "eth_getWork":
+		if worker == "" {
+			return nil, errStratumUnauthorized
+		}
+		job := c.server.currentJob()
+		if job == nil {
+			return nil, errStratumNoWork
+		}
+		return stratumProxyWork(job), nil
+
+	case "eth_submitWork":
+		if worker == "" {
+			return nil, errStratumUnauthorized
+		}
+		var (
+			nonce types.BlockNonce
+			hash  common.Hash
+			mix   common.Hash
+		)
+		if err := hexParams(params, &nonce, &hash, &mix); err != nil {
+			return nil, errStratumInvalidParams
+		}
+		if err := c.server.submitShare(worker, hash, nonce.Uint64(), &mix); err != nil {
+			return false, err
+		}
+		return true, nil
+
+	// Both dialects
+	case "eth_submitHashrate":
+		if worker == "" {
+			return nil, errStratumUnauthorized
+		}
+		var (
+			rate hexutil.Uint64
+			id   common.Hash
+		)
+		if err := hexParams(params, &rate, &id); err != nil {
+			return nil, errStratumInvalidParams
+		}
+		c.server.submitHashrate(worker, uint64(rate), id)
+		return true, nil
+	}
+	return nil, errStratumUnknownMethod
+}
+
+// stringParam returns the string parameter at the given position.
+func stringParam(params []interface{}, i int) (string, bool) {
+	if i >= len(params) {
+		return "", false
+	}
+	s, ok := params[i].(string)
+	return s, ok
+}
+
+// hexParams decodes hex encoded string parameters into the given values.
+func hexParams(params []interface{}, values ...interface{}) error {
+	if len(params) < len(values) {
+		return errors.New("missing params")
+	}
+	for i, v := range values {
+		s, ok := stringParam(params, i)
+		if !ok {
+			return errors.New("non-string param")
+		}
+		if err := json.Unmarshal([]byte(strconv.Quote(s)), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
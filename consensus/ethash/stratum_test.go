@@ -0,0 +1,281 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethash
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// stratumTestMiner is a stratum client for testing.
+type stratumTestMiner struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+	id     int
+}
+
+// stratumTestMessage is a message received by the test miner.
+type stratumTestMessage struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	Result json.RawMessage   `json:"result"`
+	Error  json.RawMessage   `json:"error"`
+}
+
+func newStratumTestServer(t *testing.T, difficulty uint64) (*Ethash, *stratumServer) {
+	ethash := NewTester(nil, false)
+	ethash.SetThreads(-1) // Disable CPU mining, the test miners seal the blocks
+	server, err := startStratumServer(ethash.remote, "127.0.0.1:0", difficulty)
+	if err != nil {
+		ethash.Close()
+		t.Fatalf("failed to start stratum server: %v", err)
+	}
+	ethash.remote.stratum = server
+	return ethash, server
+}
+
+func dialStratum(t *testing.T, server *stratumServer) *stratumTestMiner {
+	conn, err := net.Dial("tcp", server.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial stratum server: %v", err)
+	}
+	return &stratumTestMiner{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+// call sends a request and returns its response, skipping any notifications.
+func (m *stratumTestMiner) call(method string, params ...interface{}) stratumTestMessage {
+	m.id++
+	req := map[string]interface{}{"id": m.id, "method": method, "params": params}
+	blob, _ := json.Marshal(req)
+	if _, err := m.conn.Write(append(blob, '\n')); err != nil {
+		m.t.Fatalf("failed to send %s: %v", method, err)
+	}
+	for {
+		msg := m.read()
+		if string(msg.ID) == strconv.Itoa(m.id) {
+			return msg
+		}
+	}
+}
+
+// read returns the next message of the server.
+func (m *stratumTestMiner) read() stratumTestMessage {
+	m.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	line, err := m.reader.ReadBytes('\n')
+	if err != nil {
+		m.t.Fatalf("failed to read message: %v", err)
+	}
+	var msg stratumTestMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		m.t.Fatalf("invalid message %s: %v", line, err)
+	}
+	return msg
+}
+
+// searchNonce finds a nonce with the given prefix whose PoW value is within the
+// share target but, if block is false, not within the block target.
+func searchNonce(ethash *Ethash, job *stratumJob, prefix uint64, block bool) (uint64, common.Hash) {
+	for suffix := uint64(0); ; suffix++ {
+		nonce := prefix<<48 | suffix
+		digest, result := ethash.computePoW(job.number, job.hash.Bytes(), nonce, false)
+		value := new(big.Int).SetBytes(result)
+		if value.Cmp(job.shareTarget) > 0 {
+			continue
+		}
+		if (value.Cmp(job.target) <= 0) == block {
+			return nonce, common.BytesToHash(digest)
+		}
+	}
+}
+
+func TestStratumNiceHash(t *testing.T) {
+	ethash, server := newStratumTestServer(t, 10)
+	defer ethash.Close()
+
+	miner := dialStratum(t, server)
+	defer miner.conn.Close()
+
+	var subscription []json.RawMessage
+	if res := miner.call("mining.subscribe", "test/1.0", stratumDialectNiceHash); json.Unmarshal(res.Result, &subscription) != nil || len(subscription) != 2 {
+		t.Fatalf("invalid subscription: %s", res.Result)
+	}
+	var extranonce string
+	json.Unmarshal(subscription[1], &extranonce)
+	if len(extranonce) != 2*stratumExtranonceSize {
+		t.Fatalf("invalid extranonce %q", extranonce)
+	}
+	if res := miner.call("mining.authorize", "0xdeadbeef.rig1", "x"); string(res.Result) != "true" {
+		t.Fatalf("authorization failed: %s", res.Error)
+	}
+	// Push new work, expect the share difficulty and the job.
+	results := make(chan *types.Block, 1)
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1000)}
+	ethash.Seal(nil, types.NewBlockWithHeader(header), results, nil)
+
+	if msg := miner.read(); msg.Method != "mining.set_difficulty" {
+		t.Fatalf("expected difficulty notification, got %+v", msg)
+	}
+	msg := miner.read()
+	if msg.Method != "mining.notify" || len(msg.Params) != 4 {
+		t.Fatalf("expected job notification, got %+v", msg)
+	}
+	var jobID string
+	json.Unmarshal(msg.Params[0], &jobID)
+	if want := ethash.SealHash(header).Hex()[2:]; jobID != want {
+		t.Fatalf("job id mismatch: have %s, want %s", jobID, want)
+	}
+	job := server.currentJob()
+	prefix, _ := strconv.ParseUint(extranonce, 16, 64)
+
+	// Submit a share that doesn't solve the block.
+	nonce, _ := searchNonce(ethash, job, prefix, false)
+	suffix := fmt.Sprintf("%016x", nonce)[len(extranonce):]
+	if res := miner.call("mining.submit", "0xdeadbeef.rig1", jobID, suffix); string(res.Result) != "true" {
+		t.Fatalf("valid share rejected: %s", res.Error)
+	}
+	if res := miner.call("mining.submit", "0xdeadbeef.rig1", jobID, suffix); string(res.Result) == "true" {
+		t.Fatal("duplicate share accepted")
+	}
+	if res := miner.call("mining.submit", "0xdeadbeef.rig1", "00"+jobID[2:], suffix); string(res.Result) == "true" {
+		t.Fatal("share of unknown job accepted")
+	}
+	select {
+	case <-results:
+		t.Fatal("share sealed a block")
+	default:
+	}
+	// Submit a share solving the block.
+	nonce, _ = searchNonce(ethash, job, prefix, true)
+	suffix = fmt.Sprintf("%016x", nonce)[len(extranonce):]
+	if res := miner.call("mining.submit", "0xdeadbeef.rig1", jobID, suffix); string(res.Result) != "true" {
+		t.Fatalf("block solution rejected: %s", res.Error)
+	}
+	select {
+	case block := <-results:
+		if block.Nonce() != nonce {
+			t.Errorf("sealed nonce mismatch: have %x, want %x", block.Nonce(), nonce)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("sealing result timeout")
+	}
+}
+
+func TestStratumProxy(t *testing.T) {
+	ethash, server := newStratumTestServer(t, 0)
+	defer ethash.Close()
+
+	miner := dialStratum(t, server)
+	defer miner.conn.Close()
+
+	if res := miner.call("eth_getWork"); string(res.Result) == "true" || len(res.Error) == 0 || string(res.Error) == "null" {
+		t.Fatalf("expected unauthorized error, got %s", res.Result)
+	}
+	if res := miner.call("eth_submitLogin", "0xdeadbeef"); string(res.Result) != "true" {
+		t.Fatalf("login failed: %s", res.Error)
+	}
+	results := make(chan *types.Block, 1)
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(100)}
+	ethash.Seal(nil, types.NewBlockWithHeader(header), results, nil)
+
+	// Expect the work push, with the block target as share target.
+	msg := miner.read()
+	var work []string
+	if string(msg.ID) != "0" || json.Unmarshal(msg.Result, &work) != nil || len(work) != 4 {
+		t.Fatalf("expected work push, got %+v", msg)
+	}
+	if want := ethash.SealHash(header).Hex(); work[0] != want {
+		t.Fatalf("work hash mismatch: have %s, want %s", work[0], want)
+	}
+	if want := common.BytesToHash(new(big.Int).Div(two256, header.Difficulty).Bytes()).Hex(); work[2] != want {
+		t.Fatalf("work target mismatch: have %s, want %s", work[2], want)
+	}
+	// Submit a solution with an invalid, then a valid mix digest.
+	nonce, digest := searchNonce(ethash, server.currentJob(), 0, true)
+	if res := miner.call("eth_submitWork", types.EncodeNonce(nonce), work[0], common.Hash{}); string(res.Result) == "true" {
+		t.Fatal("solution with invalid mix digest accepted")
+	}
+	if res := miner.call("eth_submitWork", types.EncodeNonce(nonce+1<<32), work[0], digest); string(res.Result) == "true" {
+		t.Fatal("solution with mismatching nonce accepted")
+	}
+	if res := miner.call("eth_submitWork", types.EncodeNonce(nonce), work[0], digest); string(res.Result) != "true" {
+		t.Fatalf("valid solution rejected: %s", res.Error)
+	}
+	select {
+	case <-results:
+	case <-time.After(2 * time.Second):
+		t.Fatal("sealing result timeout")
+	}
+	// Report the hashrate of the rig.
+	if res := miner.call("eth_submitHashrate", "0x100", common.HexToHash("0x1")); string(res.Result) != "true" {
+		t.Fatalf("hashrate report rejected: %s", res.Error)
+	}
+	if rate := ethash.Hashrate(); rate != 0x100 {
+		t.Errorf("hashrate mismatch: have %v, want %v", rate, 0x100)
+	}
+}
+
+// Tests that the hashrate of rigs not reporting it is estimated from their shares.
+func TestStratumShareHashrate(t *testing.T) {
+	ethash, server := newStratumTestServer(t, 10)
+	defer ethash.Close()
+
+	miner := dialStratum(t, server)
+	defer miner.conn.Close()
+
+	var subscription []json.RawMessage
+	if res := miner.call("mining.subscribe", "test/1.0", stratumDialectNiceHash); json.Unmarshal(res.Result, &subscription) != nil || len(subscription) != 2 {
+		t.Fatalf("invalid subscription: %s", res.Result)
+	}
+	var extranonce string
+	json.Unmarshal(subscription[1], &extranonce)
+	if res := miner.call("mining.authorize", "0xdeadbeef.rig1", "x"); string(res.Result) != "true" {
+		t.Fatalf("authorization failed: %s", res.Error)
+	}
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1000)}
+	ethash.Seal(nil, types.NewBlockWithHeader(header), make(chan *types.Block, 1), nil)
+
+	miner.read() // mining.set_difficulty
+	msg := miner.read()
+	if msg.Method != "mining.notify" {
+		t.Fatalf("expected job notification, got %+v", msg)
+	}
+	var jobID string
+	json.Unmarshal(msg.Params[0], &jobID)
+
+	// Submit a share without reporting the hashrate.
+	prefix, _ := strconv.ParseUint(extranonce, 16, 64)
+	nonce, _ := searchNonce(ethash, server.currentJob(), prefix, false)
+	suffix := fmt.Sprintf("%016x", nonce)[len(extranonce):]
+	if res := miner.call("mining.submit", "0xdeadbeef.rig1", jobID, suffix); string(res.Result) != "true" {
+		t.Fatalf("valid share rejected: %s", res.Error)
+	}
+	server.submitHashrates()
+	if rate := ethash.Hashrate(); rate == 0 {
+		t.Error("no hashrate estimated from the shares")
+	}
+}
@@ -18,6 +18,7 @@
 package core
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -37,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -72,6 +74,7 @@ var (
 	blockWriteTimer      = metrics.NewRegisteredTimer("chain/write", nil)
 	blockReorgAddMeter   = metrics.NewRegisteredMeter("chain/reorg/drop", nil)
 	blockReorgDropMeter  = metrics.NewRegisteredMeter("chain/reorg/add", nil)
+	blockReorgDepthHist  = metrics.NewRegisteredHistogram("chain/reorg/depth", nil, metrics.NewExpDecaySample(1028, 0.015))
 
 	blockPrefetchExecuteTimer   = metrics.NewRegisteredTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
@@ -86,7 +89,6 @@ const (
 	txLookupCacheLimit  = 1024
 	maxFutureBlocks     = 256
 	maxTimeFutureBlocks = 30
-	badBlockLimit       = 10
 	TriesInMemory       = 128
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
@@ -155,17 +157,19 @@ type BlockChain struct {
 	//  * nil: disable tx reindexer/deleter, but still index new blocks
 	txLookupLimit uint64
 
-	hc            *HeaderChain
-	rmLogsFeed    event.Feed
-	chainFeed     event.Feed
-	chainSideFeed event.Feed
-	chainHeadFeed event.Feed
-	logsFeed      event.Feed
-	blockProcFeed event.Feed
-	scope         event.SubscriptionScope
-	genesisBlock  *types.Block
+	hc             *HeaderChain
+	rmLogsFeed     event.Feed
+	chainFeed      event.Feed
+	chainSideFeed  event.Feed
+	chainHeadFeed  event.Feed
+	chainReorgFeed event.Feed
+	logsFeed       event.Feed
+	blockProcFeed  event.Feed
+	scope          event.SubscriptionScope
+	genesisBlock   *types.Block
 
-	chainmu sync.RWMutex // blockchain insertion lock
+	chainmu    sync.RWMutex // blockchain insertion lock
+	insertPeer string       // Peer delivering the blocks being inserted, protected by chainmu
 
 	currentBlock     atomic.Value // Current head of the block chain
 	currentFastBlock atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
@@ -184,13 +188,15 @@ type BlockChain struct {
 	procInterrupt int32          // interrupt signaler for block processing
 	wg            sync.WaitGroup // chain processing wait group for shutting down
 
+	// artificialFinalityEnabled toggles artificial finality features (eg. ECBP1100), must be called atomically
+	artificialFinalityEnabled int32
+
 	engine     consensus.Engine
 	validator  Validator  // Block and state validator interface
 	prefetcher Prefetcher // Block state prefetcher interface
 	processor  Processor  // Block transaction processor interface
 	vmConfig   vm.Config
 
-	badBlocks       *lru.Cache                     // Bad block cache
 	shouldPreserve  func(*types.Block) bool        // Function used to determine whether should preserve the given block.
 	terminateInsert func(common.Hash, uint64) bool // Testing hook used to terminate ancient receipt chain insertion.
 }
@@ -199,6 +205,9 @@ type BlockChain struct {
 // available in the database. It initialises the default Ethereum Validator and
 // Processor.
 func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig ctypes.ChainConfigurator, engine consensus.Engine, vmConfig vm.Config, shouldPreserve func(block *types.Block) bool, txLookupLimit *uint64) (*BlockChain, error) {
+	if err := vm.ValidateCustomPrecompiles(chainConfig); err != nil {
+		return nil, err
+	}
 	if cacheConfig == nil {
 		cacheConfig = &CacheConfig{
 			TrieCleanLimit: 256,
@@ -214,7 +223,6 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig ctyp
 	blockCache, _ := lru.New(blockCacheLimit)
 	txLookupCache, _ := lru.New(txLookupCacheLimit)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
-	badBlocks, _ := lru.New(badBlockLimit)
 
 	bc := &BlockChain{
 		chainConfig:    chainConfig,
@@ -232,7 +240,6 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig ctyp
 		futureBlocks:   futureBlocks,
 		engine:         engine,
 		vmConfig:       vmConfig,
-		badBlocks:      badBlocks,
 	}
 	bc.validator = NewBlockValidator(chainConfig, bc, engine)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc, engine)
@@ -1508,9 +1515,17 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 		// Reorganise the chain if the parent is not the head block
 		if block.ParentHash() != currentBlock.Hash() {
 			if err := bc.reorg(currentBlock, block); err != nil {
-				return NonStatTy, err
+				if !errors.Is(err, errReorgFinality) {
+					return NonStatTy, err
+				}
+				// The proposed chain segment was rejected by artificial finality,
+				// so the block is kept as a side chain block.
+				log.Warn("Reorg disallowed", "error", err)
+				reorg = false
 			}
 		}
+	}
+	if reorg {
 		status = CanonStatTy
 	} else {
 		status = SideStatTy
@@ -1559,6 +1574,12 @@ func (bc *BlockChain) addFutureBlock(block *types.Block) error {
 //
 // After insertion is done, all accumulated events will be fired.
 func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
+	return bc.InsertChainFrom("", chain)
+}
+
+// InsertChainFrom inserts blocks delivered by the given peer like InsertChain,
+// recording the peer along with the blocks rejected.
+func (bc *BlockChain) InsertChainFrom(peer string, chain types.Blocks) (int, error) {
 	// Sanity check that we have something meaningful to import
 	if len(chain) == 0 {
 		return 0, nil
@@ -1587,7 +1608,9 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 	// Pre-checks passed, start the full block imports
 	bc.wg.Add(1)
 	bc.chainmu.Lock()
+	bc.insertPeer = peer
 	n, err := bc.insertChain(chain, true)
+	bc.insertPeer = ""
 	bc.chainmu.Unlock()
 	bc.wg.Done()
 
@@ -1702,7 +1725,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 	case err != nil:
 		bc.futureBlocks.Remove(block.Hash())
 		stats.ignored += len(it.chain)
-		bc.reportBlock(block, nil, err)
+		bc.reportBlock(block, nil, nil, err)
 		return it.index, err
 	}
 	// No validation errors for the first block (or chain prefix skipped)
@@ -1714,7 +1737,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 		}
 		// If the header is a banned one, straight out abort
 		if BadHashes[block.Hash()] {
-			bc.reportBlock(block, nil, ErrBlacklistedHash)
+			bc.reportBlock(block, nil, nil, ErrBlacklistedHash)
 			return it.index, ErrBlacklistedHash
 		}
 		// If the block is known (in the middle of the chain), it's a special case for
@@ -1786,7 +1809,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 		substart := time.Now()
 		receipts, logs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig)
 		if err != nil {
-			bc.reportBlock(block, receipts, err)
+			bc.reportBlock(block, nil, receipts, err)
 			atomic.StoreUint32(&followupInterrupt, 1)
 			return it.index, err
 		}
@@ -1807,7 +1830,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 		// Validate the state using the default validator
 		substart = time.Now()
 		if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
-			bc.reportBlock(block, receipts, err)
+			bc.reportBlock(block, statedb, receipts, err)
 			atomic.StoreUint32(&followupInterrupt, 1)
 			return it.index, err
 		}
@@ -2018,6 +2041,9 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 		oldChain    types.Blocks
 		commonBlock *types.Block
 
+		currentHead  = oldBlock
+		proposedHead = newBlock
+
 		deletedTxs types.Transactions
 		addedTxs   types.Transactions
 
@@ -2113,6 +2139,14 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 			return fmt.Errorf("invalid new chain")
 		}
 	}
+	// Ensure artificial finality rules allow the reorganization.
+	if len(oldChain) > 0 && len(newChain) > 0 &&
+		bc.IsArtificialFinalityEnabled() &&
+		bc.chainConfig.IsEnabled(bc.chainConfig.GetECBP1100Transition, currentHead.Number()) {
+		if err := bc.ecbp1100(commonBlock.Header(), currentHead.Header(), proposedHead.Header()); err != nil {
+			return err
+		}
+	}
 	// Ensure the user sees large reorgs
 	if len(oldChain) > 0 && len(newChain) > 0 {
 		logFn := log.Info
@@ -2125,6 +2159,7 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 			"drop", len(oldChain), "dropfrom", oldChain[0].Hash(), "add", len(newChain), "addfrom", newChain[0].Hash())
 		blockReorgAddMeter.Mark(int64(len(newChain)))
 		blockReorgDropMeter.Mark(int64(len(oldChain)))
+		blockReorgDepthHist.Update(int64(len(oldChain)))
 	} else {
 		log.Error("Impossible reorg, please file an issue", "oldnum", oldBlock.Number(), "oldhash", oldBlock.Hash(), "newnum", newBlock.Number(), "newhash", newBlock.Hash())
 		return fmt.Errorf("impossible reorg")
@@ -2174,6 +2209,20 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 			bc.chainSideFeed.Send(ChainSideEvent{Block: oldChain[i]})
 		}
 	}
+	// Announce the reorg as a whole, including the new head written by the caller
+	ev := ChainReorgEvent{
+		CommonAncestor: commonBlock.Header(),
+		Dropped:        make([]*types.Block, len(oldChain)),
+		Added:          make([]*types.Block, len(newChain)),
+		RemovedTxs:     types.TxDifference(deletedTxs, append(addedTxs, newChain[0].Transactions()...)),
+	}
+	for i := range oldChain {
+		ev.Dropped[len(oldChain)-1-i] = oldChain[i]
+	}
+	for i := range newChain {
+		ev.Added[len(newChain)-1-i] = newChain[i]
+	}
+	bc.chainReorgFeed.Send(ev)
 	return nil
 }
 
@@ -2272,24 +2321,47 @@ func (bc *BlockChain) maintainTxIndex(ancients uint64) {
 
 // BadBlocks returns a list of the last 'bad blocks' that the client has seen on the network
 func (bc *BlockChain) BadBlocks() []*types.Block {
-	blocks := make([]*types.Block, 0, bc.badBlocks.Len())
-	for _, hash := range bc.badBlocks.Keys() {
-		if blk, exist := bc.badBlocks.Peek(hash); exist {
-			block := blk.(*types.Block)
-			blocks = append(blocks, block)
-		}
+	bad := rawdb.ReadAllBadBlocks(bc.db)
+	blocks := make([]*types.Block, 0, len(bad))
+	for _, b := range bad {
+		blocks = append(blocks, b.Block())
 	}
 	return blocks
 }
 
-// addBadBlock adds a bad block to the bad-block LRU cache
-func (bc *BlockChain) addBadBlock(block *types.Block) {
-	bc.badBlocks.Add(block.Hash(), block)
+// addBadBlock persists a bad block along with the diagnostics of its rejection.
+// The results of its execution are recorded if the state is given, which must
+// be the state after its full execution.
+func (bc *BlockChain) addBadBlock(block *types.Block, statedb *state.StateDB, receipts types.Receipts, err error) {
+	// A missing parent says nothing about the validity of the block itself
+	if err == consensus.ErrUnknownAncestor {
+		return
+	}
+	bad := &rawdb.BadBlock{
+		Header: block.Header(),
+		Body:   block.Body(),
+		Time:   uint64(time.Now().Unix()),
+		Reason: err.Error(),
+		Peer:   bc.insertPeer,
+	}
+	if config, err := json.Marshal(bc.chainConfig); err == nil {
+		bad.ConfigHash = crypto.Keccak256Hash(config)
+	}
+	if statedb != nil {
+		bad.Root = statedb.IntermediateRoot(bc.chainConfig.IsEnabled(bc.chainConfig.GetEIP161dTransition, block.Number()))
+		bad.ReceiptHash = types.DeriveSha(receipts)
+		bad.Bloom = types.CreateBloom(receipts)
+		if len(receipts) > 0 {
+			bad.GasUsed = receipts[len(receipts)-1].CumulativeGasUsed
+		}
+	}
+	rawdb.WriteBadBlock(bc.db, bad)
 }
 
-// reportBlock logs a bad block error.
-func (bc *BlockChain) reportBlock(block *types.Block, receipts types.Receipts, err error) {
-	bc.addBadBlock(block)
+// reportBlock logs a bad block error, and persists the block. The state is only
+// given if the block was fully executed.
+func (bc *BlockChain) reportBlock(block *types.Block, statedb *state.StateDB, receipts types.Receipts, err error) {
+	bc.addBadBlock(block, statedb, receipts, err)
 
 	var receiptString string
 	for i, receipt := range receipts {
@@ -2442,6 +2514,11 @@ func (bc *BlockChain) SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Su
 	return bc.scope.Track(bc.chainSideFeed.Subscribe(ch))
 }
 
+// SubscribeChainReorgEvent registers a subscription of ChainReorgEvent.
+func (bc *BlockChain) SubscribeChainReorgEvent(ch chan<- ChainReorgEvent) event.Subscription {
+	return bc.scope.Track(bc.chainReorgFeed.Subscribe(ch))
+}
+
 // SubscribeLogsEvent registers a subscription of []*types.Log.
 func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
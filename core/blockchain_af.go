@@ -0,0 +1,128 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errReorgFinality represents an error caused by artificial finality mechanisms.
+var errReorgFinality = errors.New("finality-enforced invalid new chain")
+
+const (
+	// ecbp1100ExpBase is the base of the exponential antigravity function,
+	// with the exponent being the age, in seconds, of the common ancestor.
+	ecbp1100ExpBase = 1.0001
+
+	// ecbp1100MaxAntigravity is the ceiling of the antigravity function.
+	// It is reached when the common ancestor is about 9.5 hours old.
+	ecbp1100MaxAntigravity = 31.0
+)
+
+// EnableArtificialFinality enables and disables artificial finality features for the blockchain.
+// Currently toggled features include:
+// - ECBP1100-MESS: modified exponential subjective scoring
+//
+// This level of activation works BELOW the chain configuration for any of the
+// potential features. eg. If ECBP1100 is not activated at the chain config x block number,
+// then calling bc.EnableArtificialFinality(true) will be a noop.
+// The method is idempotent.
+func (bc *BlockChain) EnableArtificialFinality(enable bool, logValues ...interface{}) {
+	// Store enable/disable value regardless of config activation.
+	var statusLog string
+	if enable {
+		statusLog = "Enabled"
+		atomic.StoreInt32(&bc.artificialFinalityEnabled, 1)
+	} else {
+		statusLog = "Disabled"
+		atomic.StoreInt32(&bc.artificialFinalityEnabled, 0)
+	}
+	if !bc.chainConfig.IsEnabled(bc.chainConfig.GetECBP1100Transition, bc.CurrentHeader().Number) {
+		// Don't log anything if the config hasn't enabled it yet.
+		return
+	}
+	logFn := log.Warn // Activated and disabled
+	if enable {
+		logFn = log.Info // Activated and enabled
+	}
+	logFn(fmt.Sprintf("%s artificial finality features", statusLog), logValues...)
+}
+
+// IsArtificialFinalityEnabled returns the status of the blockchain's artificial
+// finality feature setting.
+// This status is agnostic of feature activation by chain configuration.
+func (bc *BlockChain) IsArtificialFinalityEnabled() bool {
+	return atomic.LoadInt32(&bc.artificialFinalityEnabled) == 1
+}
+
+// ecbp1100 implements the "MESS" artificial finality mechanism
+// "Modified Exponential Subjective Scoring" used to prefer known chain segments
+// over later-to-come counterparts, especially proposed segments stretching far into the past.
+//
+// The proposed chain segment must have a total difficulty (measured from the common ancestor)
+// which exceeds that of the current segment by a factor given by the antigravity function,
+// which grows exponentially with the age of the common ancestor relative to the current head.
+func (bc *BlockChain) ecbp1100(commonAncestor, current, proposed *types.Header) error {
+	// Get the total difficulty ratio of the proposed chain segment over the existing one.
+	commonAncestorTD := bc.GetTd(commonAncestor.Hash(), commonAncestor.Number.Uint64())
+
+	proposedParentTD := bc.GetTd(proposed.ParentHash, proposed.Number.Uint64()-1)
+	if commonAncestorTD == nil || proposedParentTD == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	proposedTD := new(big.Int).Add(proposed.Difficulty, proposedParentTD)
+
+	localTD := bc.GetTd(current.Hash(), current.Number.Uint64())
+	if localTD == nil {
+		return consensus.ErrUnknownAncestor
+	}
+
+	tdRatio, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Sub(proposedTD, commonAncestorTD)),
+		new(big.Float).SetInt(new(big.Int).Sub(localTD, commonAncestorTD)),
+	).Float64()
+
+	// Time span in seconds of the current (local) segment.
+	x := float64(0)
+	if current.Time > commonAncestor.Time {
+		x = float64(current.Time - commonAncestor.Time)
+	}
+	antiGravity := ecbp1100AGExp(x)
+
+	if tdRatio < antiGravity {
+		// Using "b/a" here as "'B' chain vs. 'A' chain", where A is original (current), and B is proposed (new).
+		underpoweredBy := tdRatio / antiGravity
+		return fmt.Errorf("%w: ECBP1100-MESS: td.B/A%0.6f < antigravity%0.6f (under=%0.6f)",
+			errReorgFinality, tdRatio, antiGravity, underpoweredBy)
+	}
+	return nil
+}
+
+// ecbp1100AGExp is the antigravity function used by ECBP1100.
+// It yields the minimum required ratio of proposed:current segment total difficulties
+// for a chain segment with a common ancestor x seconds older than the current head.
+func ecbp1100AGExp(x float64) (antiGravity float64) {
+	return math.Min(math.Pow(ecbp1100ExpBase, x), ecbp1100MaxAntigravity)
+}
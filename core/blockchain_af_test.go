@@ -0,0 +1,127 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+func TestECBP1100AGExp(t *testing.T) {
+	cases := []struct {
+		x    float64
+		want float64
+	}{
+		{0, 1},
+		{1, 1.0001},
+		{10000, math.Pow(1.0001, 10000)},
+		{100000, ecbp1100MaxAntigravity},
+	}
+	for i, c := range cases {
+		if got := ecbp1100AGExp(c.x); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("case %d: x=%v, want=%v, got=%v", i, c.x, c.want, got)
+		}
+	}
+}
+
+// TestBlockChain_AF_ECBP1100 tests that competing chains of various depths
+// are accepted or rejected by the ECBP1100 (MESS) chain reorganization rule.
+//
+// Easy (local) blocks are spaced 100 seconds apart, and hard (proposed) blocks 10 seconds apart,
+// but all blocks are at the minimum difficulty, so total difficulty is proportional
+// to the length of the segment.
+func TestBlockChain_AF_ECBP1100(t *testing.T) {
+	cases := []struct {
+		easyLen, hardLen int
+		ecbp1100         *big.Int // activation block
+		afEnabled        bool
+		accepted         bool
+	}{
+		// Shallow reorgs are accepted with modest difficulty advantage.
+		// antigravity = 1.0001^(100*2) ~= 1.02
+		{2, 3, big.NewInt(0), true, true},
+		// antigravity = 1.0001^(100*10) ~= 1.105
+		{10, 12, big.NewInt(0), true, true},
+		{10, 11, big.NewInt(0), true, false},
+		// antigravity = 1.0001^(100*100) ~= 2.718
+		{100, 101, big.NewInt(0), true, false},
+		{100, 250, big.NewInt(0), true, false},
+		{100, 300, big.NewInt(0), true, true},
+		// antigravity = 31 (ceiling)
+		{500, 600, big.NewInt(0), true, false},
+		// Artificial finality disabled.
+		{100, 101, big.NewInt(0), false, true},
+		// Not activated by chain configuration.
+		{100, 101, nil, true, true},
+		{100, 101, big.NewInt(1000), true, true},
+	}
+
+	for i, c := range cases {
+		config := &coregeth.CoreGethChainConfig{
+			NetworkID:      1,
+			ChainID:        big.NewInt(1),
+			Ethash:         new(ctypes.EthashConfig),
+			EIP2FBlock:     big.NewInt(0),
+			EIP7FBlock:     big.NewInt(0),
+			ECBP1100FBlock: c.ecbp1100,
+		}
+		db := rawdb.NewMemoryDatabase()
+		genesis := MustCommitGenesis(db, &genesisT.Genesis{Config: config})
+
+		chain, err := NewBlockChain(db, nil, config, ethash.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("case %d: failed to create chain: %v", i, err)
+		}
+		chain.EnableArtificialFinality(c.afEnabled)
+
+		easy, _ := GenerateChain(config, genesis, ethash.NewFaker(), db, c.easyLen, func(i int, b *BlockGen) {
+			b.SetCoinbase([20]byte{0x01})
+			b.OffsetTime(90)
+		})
+		hard, _ := GenerateChain(config, genesis, ethash.NewFaker(), db, c.hardLen, func(i int, b *BlockGen) {
+			b.SetCoinbase([20]byte{0x02})
+		})
+		if _, err := chain.InsertChain(easy); err != nil {
+			t.Fatalf("case %d: failed to insert easy chain: %v", i, err)
+		}
+		if _, err := chain.InsertChain(hard); err != nil {
+			t.Fatalf("case %d: failed to insert hard chain: %v", i, err)
+		}
+
+		want := easy[len(easy)-1]
+		if c.accepted {
+			want = hard[len(hard)-1]
+		}
+		if head := chain.CurrentBlock(); head.Hash() != want.Hash() {
+			t.Errorf("case %d: easy=%d hard=%d, accepted=%v: head mismatch, want=%d/%x, got=%d/%x",
+				i, c.easyLen, c.hardLen, c.accepted, want.NumberU64(), want.Hash().Bytes()[:4], head.NumberU64(), head.Hash().Bytes()[:4])
+		}
+		// Rejected blocks must still be available as side chain blocks.
+		if last := hard[len(hard)-1]; chain.GetBlockByHash(last.Hash()) == nil {
+			t.Errorf("case %d: missing proposed block %d", i, last.NumberU64())
+		}
+		chain.Stop()
+	}
+}
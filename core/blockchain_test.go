@@ -22,6 +22,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -152,12 +153,12 @@ func testBlockChainImport(chain types.Blocks, blockchain *BlockChain) error {
 		}
 		receipts, _, usedGas, err := blockchain.processor.Process(block, statedb, vm.Config{})
 		if err != nil {
-			blockchain.reportBlock(block, receipts, err)
+			blockchain.reportBlock(block, nil, receipts, err)
 			return err
 		}
 		err = blockchain.validator.ValidateState(block, statedb, receipts, usedGas)
 		if err != nil {
-			blockchain.reportBlock(block, receipts, err)
+			blockchain.reportBlock(block, statedb, receipts, err)
 			return err
 		}
 		blockchain.chainmu.Lock()
@@ -983,6 +984,114 @@ func TestLogReorgs(t *testing.T) {
 	}
 }
 
+// Tests that a ChainReorgEvent describing the whole reorg is sent when the chain
+// reorganizes, with the transactions not included by the new chain.
+func TestChainReorgEvent(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &genesisT.Genesis{Config: params.TestChainConfig, Alloc: genesisT.GenesisAlloc{addr1: {Balance: big.NewInt(10000000000000)}}}
+		genesis = MustCommitGenesis(db, gspec)
+		signer  = types.NewEIP155Signer(gspec.Config.GetChainID())
+	)
+	blockchain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer blockchain.Stop()
+
+	reorgCh := make(chan ChainReorgEvent, 1)
+	sub := blockchain.SubscribeChainReorgEvent(reorgCh)
+	defer sub.Unsubscribe()
+
+	kept, _ := types.SignTx(types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), vars.TxGas, nil, nil), signer, key1)
+	dropped, _ := types.SignTx(types.NewTransaction(1, common.Address{0x02}, big.NewInt(1), vars.TxGas, nil, nil), signer, key1)
+
+	// Import a chain with both transactions, and a heavier fork with only one
+	chain, _ := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 3, func(i int, gen *BlockGen) {
+		if i == 1 {
+			gen.AddTx(kept)
+			gen.AddTx(dropped)
+		}
+	})
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	fork, _ := GenerateChain(params.TestChainConfig, chain[0], ethash.NewFaker(), db, 2, func(i int, gen *BlockGen) {
+		gen.OffsetTime(-9) // higher block difficulty, to take over at the same height
+		if i == 1 {
+			gen.AddTx(kept)
+		}
+	})
+	if _, err := blockchain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert forked chain: %v", err)
+	}
+	select {
+	case ev := <-reorgCh:
+		if ev.CommonAncestor.Hash() != chain[0].Hash() {
+			t.Errorf("common ancestor mismatch: have #%d, want #1", ev.CommonAncestor.Number)
+		}
+		if len(ev.Dropped) != 2 || ev.Dropped[0].Hash() != chain[1].Hash() || ev.Dropped[1].Hash() != chain[2].Hash() {
+			t.Errorf("dropped blocks mismatch: have %d blocks", len(ev.Dropped))
+		}
+		if len(ev.Added) != 2 || ev.Added[0].Hash() != fork[0].Hash() || ev.Added[1].Hash() != fork[1].Hash() {
+			t.Errorf("added blocks mismatch: have %d blocks", len(ev.Added))
+		}
+		if len(ev.RemovedTxs) != 1 || ev.RemovedTxs[0].Hash() != dropped.Hash() {
+			t.Errorf("removed transactions mismatch: have %d", len(ev.RemovedTxs))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no ChainReorgEvent sent")
+	}
+}
+
+// Tests that rejected blocks are persisted along with the diagnostics of their
+// rejection, and survive restarts.
+func TestBadBlockPersistence(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &genesisT.Genesis{Config: params.TestChainConfig}
+		genesis = MustCommitGenesis(db, gspec)
+	)
+	blocks, _ := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 2, nil)
+
+	blockchain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if _, err := blockchain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	// Import a block with an invalid state root from a peer
+	header := blocks[1].Header()
+	header.Root = common.Hash{0x01}
+	bad := types.NewBlockWithHeader(header)
+	if _, err := blockchain.InsertChainFrom("peer", types.Blocks{bad}); err == nil {
+		t.Fatal("inserted bad block")
+	}
+	blockchain.Stop()
+
+	blockchain, _ = NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer blockchain.Stop()
+
+	if blocks := blockchain.BadBlocks(); len(blocks) != 1 || blocks[0].Hash() != bad.Hash() {
+		t.Fatalf("bad blocks mismatch: have %d, want [%x]", len(blocks), bad.Hash())
+	}
+	record := rawdb.ReadBadBlock(db, bad.Hash())
+	if record.Peer != "peer" || !strings.Contains(record.Reason, "invalid merkle root") {
+		t.Errorf("rejection mismatch: have peer %q, reason %q", record.Peer, record.Reason)
+	}
+	if record.Root != blocks[1].Root() || record.ReceiptHash != blocks[1].ReceiptHash() || record.Bloom != blocks[1].Bloom() {
+		t.Errorf("execution results mismatch: have root %x, want %x", record.Root, blocks[1].Root())
+	}
+	if record.ConfigHash == (common.Hash{}) {
+		t.Error("chain configuration hash missing")
+	}
+	// Blocks with unknown ancestors aren't persisted
+	orphan := types.NewBlockWithHeader(&types.Header{ParentHash: common.Hash{0x02}, Number: big.NewInt(5), Difficulty: big.NewInt(1)})
+	if _, err := blockchain.InsertChainFrom("peer", types.Blocks{orphan}); err != consensus.ErrUnknownAncestor {
+		t.Fatalf("orphan insertion error mismatch: have %v, want %v", err, consensus.ErrUnknownAncestor)
+	}
+	if blocks := blockchain.BadBlocks(); len(blocks) != 1 {
+		t.Errorf("bad block count mismatch: have %d, want 1", len(blocks))
+	}
+}
+
 // This EVM code generates a log when the contract is created.
 var logCode = common.Hex2Bytes("60606040525b7f24ec1d3ff24c2f6ff210738839dbc339cd45a5294d85c79361016243157aae7b60405180905060405180910390a15b600a8060416000396000f360606040526008565b00")
 
@@ -3035,3 +3144,84 @@ func TestInitThenFailCreateContract(t *testing.T) {
 		}
 	}
 }
+
+// TestEIP2718Transition tests that an EIP-2718 typed (access list) transaction
+// is rejected before the fork, and is processed, charged intrinsic gas for its
+// access list, and yields a typed receipt after the fork.
+func TestEIP2718Transition(t *testing.T) {
+	var (
+		aa = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+
+		engine = ethash.NewFaker()
+
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000)
+	)
+	for _, activated := range []bool{false, true} {
+		config := *params.TestChainConfig
+		if activated {
+			if err := config.SetEIP2718Transition(new(uint64)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		db := rawdb.NewMemoryDatabase()
+		gspec := &genesisT.Genesis{
+			Config: &config,
+			Alloc: genesisT.GenesisAlloc{
+				address: {Balance: funds},
+			},
+		}
+		genesis := MustCommitGenesis(db, gspec)
+
+		txdata := &types.AccessListTx{
+			ChainID:  config.GetChainID(),
+			Nonce:    0,
+			To:       &aa,
+			Gas:      30000,
+			GasPrice: big.NewInt(1),
+			AccessList: types.AccessList{{
+				Address:     aa,
+				StorageKeys: []common.Hash{{0}},
+			}},
+		}
+		tx, _ := types.SignTx(types.NewTx(txdata), types.NewEIP2930Signer(config.GetChainID()), key)
+		if !activated {
+			// Block processing derives the sender with the fork-appropriate signer.
+			if _, err := types.Sender(types.MakeSigner(&config, common.Big1), tx); err != types.ErrTxTypeNotSupported {
+				t.Fatalf("expected %v before EIP-2718, got %v", types.ErrTxTypeNotSupported, err)
+			}
+			continue
+		}
+		blocks, _ := GenerateChain(&config, genesis, engine, db, 1, func(i int, b *BlockGen) {
+			b.SetCoinbase(common.Address{1})
+			b.AddTx(tx)
+		})
+		block := blocks[0]
+
+		diskdb := rawdb.NewMemoryDatabase()
+		MustCommitGenesis(diskdb, gspec)
+		chain, err := NewBlockChain(diskdb, nil, &config, engine, vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create tester chain: %v", err)
+		}
+		if n, err := chain.InsertChain([]*types.Block{block}); err != nil {
+			t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+		}
+		receipts := chain.GetReceiptsByHash(block.Hash())
+		if len(receipts) != 1 {
+			t.Fatalf("receipt count mismatch: have %d, want 1", len(receipts))
+		}
+		if receipts[0].Type != types.AccessListTxType {
+			t.Errorf("receipt type mismatch: have %d, want %d", receipts[0].Type, types.AccessListTxType)
+		}
+		expected := vars.TxGas + vars.TxAccessListAddressGas + vars.TxAccessListStorageKeyGas
+		if receipts[0].GasUsed != expected {
+			t.Errorf("gas used mismatch: have %d, want %d", receipts[0].GasUsed, expected)
+		}
+		if have := chain.GetBlockByHash(block.Hash()).Transactions()[0].Hash(); have != tx.Hash() {
+			t.Errorf("transaction hash mismatch: have %x, want %x", have, tx.Hash())
+		}
+		chain.Stop()
+	}
+}
@@ -41,3 +41,16 @@ type ChainSideEvent struct {
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
+
+// ChainReorgEvent is posted when the canonical chain is reorganised. The dropped
+// and added blocks are ordered by ascending number, the added ones ending with
+// the new head.
+type ChainReorgEvent struct {
+	CommonAncestor *types.Header
+	Dropped        []*types.Block
+	Added          []*types.Block
+
+	// RemovedTxs are the transactions of the dropped blocks not included in
+	// the added ones.
+	RemovedTxs types.Transactions
+}
@@ -60,6 +60,12 @@ func NewID(chain *core.BlockChain) ID {
 	)
 }
 
+// NewIDFromConfig calculates the Ethereum fork ID from the chain config, the
+// genesis hash and the head block number.
+func NewIDFromConfig(config ctypes.ChainConfigurator, genesis common.Hash, head uint64) ID {
+	return newID(config, genesis, head)
+}
+
 // newID is the internal version of NewID, which takes extracted values as its
 // arguments instead of a chain. The reason is to allow testing the IDs without
 // having to simulate an entire blockchain.
@@ -99,6 +105,11 @@ func NewStaticFilter(config ctypes.ChainConfigurator, genesis common.Hash) Filte
 	return newFilter(config, genesis, head)
 }
 
+// NewFilterFromConfig creates a filter at the given head block number.
+func NewFilterFromConfig(config ctypes.ChainConfigurator, genesis common.Hash, head uint64) Filter {
+	return newFilter(config, genesis, func() uint64 { return head })
+}
+
 // newFilter is the internal version of NewFilter, taking closures as its arguments
 // instead of a chain. The reason is to allow testing it without having to simulate
 // an entire blockchain.
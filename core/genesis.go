@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
 	"github.com/ethereum/go-ethereum/params/vars"
@@ -47,6 +48,14 @@ import (
 //
 // The returned chain configuration is never nil.
 func SetupGenesisBlock(db ethdb.Database, genesis *genesisT.Genesis) (ctypes.ChainConfigurator, common.Hash, error) {
+	return SetupGenesisBlockWithOverride(db, genesis, nil)
+}
+
+// SetupGenesisBlockWithOverride is SetupGenesisBlock, additionally overriding the
+// named transitions (see confp.TransitionNames) of the chain configuration with the
+// given block numbers. The overridden configuration must be valid and compatible
+// with the stored one.
+func SetupGenesisBlockWithOverride(db ethdb.Database, genesis *genesisT.Genesis, overrides map[string]uint64) (ctypes.ChainConfigurator, common.Hash, error) {
 	if genesis != nil && confp.IsEmpty(genesis.Config) {
 		return params.AllEthashProtocolChanges, common.Hash{}, genesisT.ErrGenesisNoConfig
 	}
@@ -59,6 +68,15 @@ func SetupGenesisBlock(db ethdb.Database, genesis *genesisT.Genesis) (ctypes.Cha
 		} else {
 			log.Info("Writing custom genesis block")
 		}
+		if len(overrides) > 0 {
+			config, err := overrideTransitions(genesis.Config, overrides, new(uint64))
+			if err != nil {
+				return genesis.Config, common.Hash{}, err
+			}
+			overridden := *genesis
+			overridden.Config = config
+			genesis = &overridden
+		}
 		block, err := CommitGenesis(genesis, db)
 		if err != nil {
 			return genesis.Config, common.Hash{}, err
@@ -114,8 +132,12 @@ func SetupGenesisBlock(db ethdb.Database, genesis *genesisT.Genesis) (ctypes.Cha
 	// New logic (below) checks _inequality_ between a defaulty config and a stored config. If different,
 	// the stored config is used. This breaks auto-upgrade magic for defaulty chains.
 	if genesis == nil && !confp.Identical(storedcfg, newcfg, []string{"NetworkID", "ChainID"}) {
-		log.Info("Found non-defaulty stored config, using it.")
-		return storedcfg, stored, nil
+		if len(overrides) == 0 {
+			log.Info("Found non-defaulty stored config, using it.")
+			return storedcfg, stored, nil
+		}
+		log.Info("Found non-defaulty stored config, overriding it.")
+		newcfg = storedcfg
 	}
 
 	// Check config compatibility and write the config. Compatibility errors
@@ -124,6 +146,13 @@ func SetupGenesisBlock(db ethdb.Database, genesis *genesisT.Genesis) (ctypes.Cha
 	if height == nil {
 		return newcfg, stored, fmt.Errorf("missing block number for head header hash")
 	}
+	if len(overrides) > 0 {
+		overridden, err := overrideTransitions(newcfg, overrides, height)
+		if err != nil {
+			return newcfg, stored, err
+		}
+		newcfg = overridden
+	}
 	compatErr := confp.Compatible(height, storedcfg, newcfg)
 	if compatErr != nil && *height != 0 && compatErr.RewindTo != 0 {
 		return newcfg, stored, compatErr
@@ -132,6 +161,27 @@ func SetupGenesisBlock(db ethdb.Database, genesis *genesisT.Genesis) (ctypes.Cha
 	return newcfg, stored, nil
 }
 
+// overrideTransitions returns a copy of the configuration with the named transitions
+// set to the given block numbers. The resulting configuration is validated at head.
+func overrideTransitions(config ctypes.ChainConfigurator, overrides map[string]uint64, head *uint64) (ctypes.ChainConfigurator, error) {
+	// Copy the configuration; it may be one of the shared defaults.
+	overridden := &coregeth.CoreGethChainConfig{}
+	if err := confp.Convert(config, overridden); err != nil {
+		return nil, fmt.Errorf("copy config for overrides: %v", err)
+	}
+	for name, n := range overrides {
+		n := n
+		if err := confp.SetTransition(overridden, name, &n); err != nil {
+			return nil, fmt.Errorf("override %s: %v", name, err)
+		}
+		log.Info("Overriding chain config transition", "name", name, "block", n)
+	}
+	if err := confp.IsValid(overridden, head); err != nil {
+		return nil, fmt.Errorf("invalid overridden config: %v", err)
+	}
+	return overridden, nil
+}
+
 func configOrDefault(g *genesisT.Genesis, ghash common.Hash) ctypes.ChainConfigurator {
 	switch {
 	case g != nil:
@@ -308,3 +308,50 @@ func TestSetupGenesisBlock2(t *testing.T) {
 		t.Fatal("different config")
 	}
 }
+
+func TestSetupGenesisBlockWithOverride(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	// Override a transition while writing the genesis block.
+	config, hash, err := SetupGenesisBlockWithOverride(db, params.DefaultClassicGenesisBlock(), map[string]uint64{"EIP2929": 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantHash := GenesisToBlock(params.DefaultClassicGenesisBlock(), nil).Hash(); wantHash != hash {
+		t.Errorf("mismatch block hash, want: %x, got: %x", wantHash, hash)
+	}
+	if got := config.GetEIP2929Transition(); got == nil || *got != 100 {
+		t.Errorf("override not applied, got: %v", got)
+	}
+	if got := rawdb.ReadChainConfig(db, hash).GetEIP2929Transition(); got == nil || *got != 100 {
+		t.Errorf("override not stored, got: %v", got)
+	}
+	if got := params.ClassicChainConfig.GetEIP2929Transition(); got != nil {
+		t.Errorf("default config modified, got: %v", *got)
+	}
+
+	// Move the head beyond the overridden transition.
+	head := common.Hash{0x1}
+	rawdb.WriteHeaderNumber(db, head, 200)
+	rawdb.WriteHeadHeaderHash(db, head)
+
+	// A compatible override of a future transition is stored.
+	config, _, err = SetupGenesisBlockWithOverride(db, params.DefaultClassicGenesisBlock(), map[string]uint64{"EIP2929": 100, "EIP2537": 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := config.GetEIP2537Transition(); got == nil || *got != 300 {
+		t.Errorf("override not applied, got: %v", got)
+	}
+
+	// An override changing the past is rejected.
+	_, _, err = SetupGenesisBlockWithOverride(db, params.DefaultClassicGenesisBlock(), map[string]uint64{"EIP2929": 150, "EIP2537": 300})
+	if _, ok := err.(*confp.ConfigCompatError); !ok {
+		t.Errorf("want compat error, got: %v", err)
+	}
+
+	// Unknown transitions are rejected.
+	if _, _, err = SetupGenesisBlockWithOverride(db, nil, map[string]uint64{"Foo": 1}); err == nil {
+		t.Error("want error for unknown transition")
+	}
+}
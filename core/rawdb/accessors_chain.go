@@ -669,3 +669,148 @@ func FindCommonAncestor(db ethdb.Reader, a, b *types.Header) *types.Header {
 	}
 	return a
 }
+
+const (
+	// badBlocksLimit is the maximum number of bad blocks kept.
+	badBlocksLimit = 10
+
+	// badBlocksSizeLimit is the maximum total encoded size of the bad blocks kept.
+	badBlocksSizeLimit = 4 * 1024 * 1024
+)
+
+// badBlockIndexEntry is an entry of the bad block index, tracking the encoded
+// size of the block so the size limit is enforced without reading the blocks.
+type badBlockIndexEntry struct {
+	Hash common.Hash
+	Size uint64
+}
+
+// BadBlock is a block rejected by the chain, along with the diagnostics of its
+// rejection.
+type BadBlock struct {
+	Header     *types.Header
+	Body       *types.Body
+	Time       uint64      // Unix time of the rejection
+	Reason     string      // Error rejecting the block
+	Peer       string      // Identifier of the peer delivering the block, empty if local
+	ConfigHash common.Hash // Keccak256 hash of the JSON encoded chain configuration
+
+	// Results of the execution of the block, zero if it wasn't fully executed
+	Root        common.Hash
+	ReceiptHash common.Hash
+	Bloom       types.Bloom
+	GasUsed     uint64
+}
+
+// Block returns the rejected block.
+func (b *BadBlock) Block() *types.Block {
+	return types.NewBlockWithHeader(b.Header).WithBody(b.Body.Transactions, b.Body.Uncles)
+}
+
+// Executed returns whether the block was fully executed before its rejection.
+func (b *BadBlock) Executed() bool {
+	return b.Root != (common.Hash{})
+}
+
+// readBadBlockIndex retrieves the index of the bad blocks kept, the most
+// recently rejected first.
+func readBadBlockIndex(db ethdb.KeyValueReader) []badBlockIndexEntry {
+	blob, err := db.Get(badBlockIndexKey)
+	if err != nil {
+		return nil
+	}
+	var index []badBlockIndexEntry
+	if err := rlp.DecodeBytes(blob, &index); err != nil {
+		log.Error("Invalid bad block index RLP", "err", err)
+		return nil
+	}
+	return index
+}
+
+// ReadAllBadBlocks retrieves the bad blocks kept, the most recently rejected
+// first.
+func ReadAllBadBlocks(db ethdb.KeyValueReader) []*BadBlock {
+	var bad []*BadBlock
+	for _, entry := range readBadBlockIndex(db) {
+		if b := ReadBadBlock(db, entry.Hash); b != nil {
+			bad = append(bad, b)
+		}
+	}
+	return bad
+}
+
+// ReadBadBlock retrieves the bad block with the given hash, if kept.
+func ReadBadBlock(db ethdb.KeyValueReader, hash common.Hash) *BadBlock {
+	blob, err := db.Get(badBlockKey(hash))
+	if err != nil {
+		return nil
+	}
+	bad := new(BadBlock)
+	if err := rlp.DecodeBytes(blob, bad); err != nil {
+		log.Error("Invalid bad block RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return bad
+}
+
+// WriteBadBlock stores a bad block, replacing any previous rejection of it. The
+// least recently rejected blocks are dropped beyond the count and size limits,
+// though the latest rejection is always kept.
+func WriteBadBlock(db ethdb.KeyValueStore, bad *BadBlock) {
+	blob, err := rlp.EncodeToBytes(bad)
+	if err != nil {
+		log.Crit("Failed to RLP encode bad block", "err", err)
+	}
+	var (
+		hash  = bad.Header.Hash()
+		index = []badBlockIndexEntry{{Hash: hash, Size: uint64(len(blob))}}
+		size  = uint64(len(blob))
+		batch = db.NewBatch()
+		full  bool
+	)
+	for _, old := range readBadBlockIndex(db) {
+		if len(index) >= badBlocksLimit || size+old.Size > badBlocksSizeLimit {
+			full = true
+		}
+		switch {
+		case old.Hash == hash:
+			// Replaced by the new rejection
+		case !full:
+			index = append(index, old)
+			size += old.Size
+		default:
+			if err := batch.Delete(badBlockKey(old.Hash)); err != nil {
+				log.Crit("Failed to delete bad block", "err", err)
+			}
+		}
+	}
+	if err := batch.Put(badBlockKey(hash), blob); err != nil {
+		log.Crit("Failed to store bad block", "err", err)
+	}
+	enc, err := rlp.EncodeToBytes(index)
+	if err != nil {
+		log.Crit("Failed to RLP encode bad block index", "err", err)
+	}
+	if err := batch.Put(badBlockIndexKey, enc); err != nil {
+		log.Crit("Failed to store bad block index", "err", err)
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to write bad block", "err", err)
+	}
+}
+
+// DeleteBadBlocks removes all the bad blocks kept.
+func DeleteBadBlocks(db ethdb.KeyValueStore) {
+	batch := db.NewBatch()
+	for _, entry := range readBadBlockIndex(db) {
+		if err := batch.Delete(badBlockKey(entry.Hash)); err != nil {
+			log.Crit("Failed to delete bad block", "err", err)
+		}
+	}
+	if err := batch.Delete(badBlockIndexKey); err != nil {
+		log.Crit("Failed to delete bad block index", "err", err)
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete bad blocks", "err", err)
+	}
+}
@@ -187,6 +187,76 @@ func TestPartialBlockStorage(t *testing.T) {
 	}
 }
 
+// Tests bad block storage and retrieval operations, and the limits of the bad
+// blocks kept.
+func TestBadBlockStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	newBadBlock := func(number int64, reason string) *BadBlock {
+		block := types.NewBlockWithHeader(&types.Header{
+			Number:      big.NewInt(number),
+			Extra:       []byte("bad block"),
+			UncleHash:   types.EmptyUncleHash,
+			TxHash:      types.EmptyRootHash,
+			ReceiptHash: types.EmptyRootHash,
+		})
+		return &BadBlock{
+			Header: block.Header(),
+			Body:   block.Body(),
+			Reason: reason,
+			Peer:   "peer",
+			Root:   common.Hash{0x01},
+		}
+	}
+	first := newBadBlock(1, "first")
+	if entry := ReadBadBlock(db, first.Header.Hash()); entry != nil {
+		t.Fatalf("Non existent bad block returned: %v", entry)
+	}
+	WriteBadBlock(db, first)
+	if entry := ReadBadBlock(db, first.Header.Hash()); entry == nil {
+		t.Fatalf("Stored bad block not found")
+	} else if entry.Block().Hash() != first.Header.Hash() || entry.Reason != "first" || entry.Peer != "peer" || !entry.Executed() {
+		t.Fatalf("Retrieved bad block mismatch: have %+v, want %+v", entry, first)
+	}
+	// Rejecting a block again replaces its previous rejection
+	WriteBadBlock(db, newBadBlock(2, "second"))
+	WriteBadBlock(db, newBadBlock(1, "again"))
+	if entries := ReadAllBadBlocks(db); len(entries) != 2 || entries[0].Reason != "again" || entries[1].Reason != "second" {
+		t.Fatalf("Bad blocks mismatch: have %d, want [again second]", len(entries))
+	}
+	// Only the most recent rejections are kept
+	for i := 0; i < badBlocksLimit; i++ {
+		WriteBadBlock(db, newBadBlock(int64(10+i), fmt.Sprintf("block %d", i)))
+	}
+	entries := ReadAllBadBlocks(db)
+	if len(entries) != badBlocksLimit {
+		t.Fatalf("Bad block count mismatch: have %d, want %d", len(entries), badBlocksLimit)
+	}
+	if want := fmt.Sprintf("block %d", badBlocksLimit-1); entries[0].Reason != want {
+		t.Fatalf("Latest bad block mismatch: have %s, want %s", entries[0].Reason, want)
+	}
+	// Dropped rejections are removed from the database
+	if entry := ReadBadBlock(db, first.Header.Hash()); entry != nil {
+		t.Fatalf("Dropped bad block returned: %v", entry)
+	}
+	// Large rejections push out the older ones beyond the size limit
+	for i := 0; i < 2; i++ {
+		large := newBadBlock(int64(100+i), fmt.Sprintf("large %d", i))
+		large.Header.Extra = make([]byte, badBlocksSizeLimit/2)
+		WriteBadBlock(db, large)
+	}
+	if entries := ReadAllBadBlocks(db); len(entries) != 1 || entries[0].Reason != "large 1" {
+		t.Fatalf("Bad block count mismatch: have %d, want 1", len(entries))
+	}
+	DeleteBadBlocks(db)
+	if entries := ReadAllBadBlocks(db); len(entries) != 0 {
+		t.Fatalf("Deleted bad blocks returned: %d", len(entries))
+	}
+	if entry := ReadBadBlock(db, entries[0].Header.Hash()); entry != nil {
+		t.Fatalf("Deleted bad block returned: %v", entry)
+	}
+}
+
 // Tests block total difficulty storage and retrieval operations.
 func TestTdStorage(t *testing.T) {
 	db := NewMemoryDatabase()
@@ -17,6 +17,7 @@
 package rawdb
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -151,3 +152,59 @@ func WriteBloomBits(db ethdb.KeyValueWriter, bit uint, section uint64, head comm
 		log.Crit("Failed to store bloom bits", "err", err)
 	}
 }
+
+// Issuance is the ether issued and destroyed by a block, along with the total
+// supply after it.
+type Issuance struct {
+	Reward *big.Int // Reward of the block's miner, including any treasury share
+	Uncles *big.Int // Rewards of the block's uncle miners
+	Alloc  *big.Int // Ether allocated in the genesis block
+	Burnt  *big.Int // Ether destroyed by self-destructs or sent to unrecoverable addresses
+	Supply *big.Int // Total supply after the block
+}
+
+// ReadIssuance retrieves the issuance of the block with the given number and
+// hash, or nil if the block wasn't indexed.
+func ReadIssuance(db ethdb.KeyValueReader, number uint64, hash common.Hash) *Issuance {
+	data, _ := db.Get(issuanceKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	issuance := new(Issuance)
+	if err := rlp.DecodeBytes(data, issuance); err != nil {
+		log.Error("Invalid block issuance RLP", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return issuance
+}
+
+// WriteIssuance stores the issuance of the block with the given number and hash.
+func WriteIssuance(db ethdb.KeyValueWriter, number uint64, hash common.Hash, issuance *Issuance) {
+	data, err := rlp.EncodeToBytes(issuance)
+	if err != nil {
+		log.Crit("Failed to RLP encode block issuance", "err", err)
+	}
+	if err := db.Put(issuanceKey(number, hash), data); err != nil {
+		log.Crit("Failed to store block issuance", "err", err)
+	}
+}
+
+// ReadSupplyStateGeneration retrieves the generation of the state data of the
+// supply indexer in use.
+func ReadSupplyStateGeneration(db ethdb.KeyValueReader) uint64 {
+	data, _ := db.Get(supplyStateGenerationKey)
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// WriteSupplyStateGeneration stores the generation of the state data of the
+// supply indexer in use.
+func WriteSupplyStateGeneration(db ethdb.KeyValueWriter, generation uint64) {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], generation)
+	if err := db.Put(supplyStateGenerationKey, enc[:]); err != nil {
+		log.Crit("Failed to store supply state generation", "err", err)
+	}
+}
@@ -234,7 +234,10 @@ func InspectDatabase(db ethdb.Database) error {
 		storageSnapSize common.StorageSize
 		preimageSize    common.StorageSize
 		bloomBitsSize   common.StorageSize
+		issuanceSize    common.StorageSize
+		supplyStateSize common.StorageSize
 		cliqueSnapsSize common.StorageSize
+		badBlockSize    common.StorageSize
 
 		// Ancient store statistics
 		ancientHeaders  common.StorageSize
@@ -281,6 +284,12 @@ func InspectDatabase(db ethdb.Database) error {
 			preimageSize += size
 		case bytes.HasPrefix(key, bloomBitsPrefix) && len(key) == (len(bloomBitsPrefix)+10+common.HashLength):
 			bloomBitsSize += size
+		case bytes.HasPrefix(key, issuancePrefix) && len(key) == (len(issuancePrefix)+8+common.HashLength):
+			issuanceSize += size
+		case bytes.HasPrefix(key, SupplyStatePrefix) && len(key) == (len(SupplyStatePrefix)+1+common.HashLength):
+			supplyStateSize += size
+		case bytes.HasPrefix(key, badBlockPrefix) && len(key) == (len(badBlockPrefix)+common.HashLength):
+			badBlockSize += size
 		case bytes.HasPrefix(key, []byte("clique-")) && len(key) == 7+common.HashLength:
 			cliqueSnapsSize += size
 		case bytes.HasPrefix(key, []byte("cht-")) && len(key) == 4+common.HashLength:
@@ -291,7 +300,7 @@ func InspectDatabase(db ethdb.Database) error {
 			trieSize += size
 		default:
 			var accounted bool
-			for _, meta := range [][]byte{databaseVerisionKey, headHeaderKey, headBlockKey, headFastBlockKey, fastTrieProgressKey} {
+			for _, meta := range [][]byte{databaseVerisionKey, headHeaderKey, headBlockKey, headFastBlockKey, fastTrieProgressKey, supplyStateGenerationKey, badBlockIndexKey} {
 				if bytes.Equal(key, meta) {
 					metadata += size
 					accounted = true
@@ -326,11 +335,14 @@ func InspectDatabase(db ethdb.Database) error {
 		{"Key-Value store", "Block hash->number", hashNumPairing.String()},
 		{"Key-Value store", "Transaction index", txlookupSize.String()},
 		{"Key-Value store", "Bloombit index", bloomBitsSize.String()},
+		{"Key-Value store", "Supply index", issuanceSize.String()},
+		{"Key-Value store", "Supply index state", supplyStateSize.String()},
 		{"Key-Value store", "Trie nodes", trieSize.String()},
 		{"Key-Value store", "Trie preimages", preimageSize.String()},
 		{"Key-Value store", "Account snapshot", accountSnapSize.String()},
 		{"Key-Value store", "Storage snapshot", storageSnapSize.String()},
 		{"Key-Value store", "Clique snapshots", cliqueSnapsSize.String()},
+		{"Key-Value store", "Bad blocks", badBlockSize.String()},
 		{"Key-Value store", "Singleton metadata", metadata.String()},
 		{"Ancient store", "Headers", ancientHeaders.String()},
 		{"Ancient store", "Bodies", ancientBodies.String()},
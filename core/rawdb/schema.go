@@ -53,6 +53,12 @@ var (
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	fastTxLookupLimitKey = []byte("FastTransactionLookupLimit")
 
+	// supplyStateGenerationKey tracks the generation of the supply indexer's state data in use.
+	supplyStateGenerationKey = []byte("supply-generation")
+
+	// badBlockIndexKey tracks the hashes of the bad blocks kept, the most recently rejected first.
+	badBlockIndexKey = []byte("BadBlockIndex")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -69,9 +75,15 @@ var (
 
 	preimagePrefix = []byte("secure-key-")      // preimagePrefix + hash -> preimage
 	ConfigPrefix   = []byte("ethereum-config-") // config prefix for the db
+	issuancePrefix = []byte("supply-issuance-") // issuancePrefix + num (uint64 big endian) + hash -> block issuance
+	badBlockPrefix = []byte("bad-block-")       // badBlockPrefix + hash -> bad block
+
+	// SupplyStatePrefix is the prefix of the state tables of the supply indexer.
+	SupplyStatePrefix = []byte("supply-state-") // SupplyStatePrefix + generation (byte) + hash -> trie node or contract code
 
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
+	SupplyIndexPrefix    = []byte("iS") // SupplyIndexPrefix is the data table of the supply indexer to track its progress
 
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
@@ -184,6 +196,16 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// issuanceKey = issuancePrefix + num (uint64 big endian) + hash
+func issuanceKey(number uint64, hash common.Hash) []byte {
+	return append(append(issuancePrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// badBlockKey = badBlockPrefix + hash
+func badBlockKey(hash common.Hash) []byte {
+	return append(badBlockPrefix, hash.Bytes()...)
+}
+
 // preimageKey = preimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(preimagePrefix, hash.Bytes()...)
@@ -0,0 +1,108 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/steakknife/bloomfilter"
+)
+
+// stateBloomHasher is a wrapper around a byte blob to satisfy the interface API
+// requirements of the bloom library used. It's used to convert a trie hash or
+// contract code hash into a 64 bit mini hash.
+type stateBloomHasher []byte
+
+func (f stateBloomHasher) Write(p []byte) (n int, err error) { panic("not implemented") }
+func (f stateBloomHasher) Sum(b []byte) []byte               { panic("not implemented") }
+func (f stateBloomHasher) Reset()                            { panic("not implemented") }
+func (f stateBloomHasher) BlockSize() int                    { panic("not implemented") }
+func (f stateBloomHasher) Size() int                         { return 8 }
+func (f stateBloomHasher) Sum64() uint64                     { return binary.BigEndian.Uint64(f) }
+
+// stateBloom is a bloom filter of the hashes of all the trie nodes and contract
+// codes of the state to keep. It implements ethdb.KeyValueWriter, so the state
+// can be regenerated into it.
+//
+// The false positives of the filter are entries kept though they should have
+// been pruned, so with a low enough rate only a tiny part of the stale state is
+// left dangling in the database.
+type stateBloom struct {
+	bloom *bloomfilter.Filter
+}
+
+// newStateBloomWithSize creates a state bloom of the given size in megabytes.
+func newStateBloomWithSize(size uint64) (*stateBloom, error) {
+	bloom, err := bloomfilter.New(size*1024*1024*8, 4)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Initialized state bloom", "size", common.StorageSize(float64(bloom.M()/8)))
+	return &stateBloom{bloom: bloom}, nil
+}
+
+// newStateBloomFromDisk loads the state bloom committed into the given file.
+func newStateBloomFromDisk(filename string) (*stateBloom, error) {
+	bloom, _, err := bloomfilter.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &stateBloom{bloom: bloom}, nil
+}
+
+// Commit flushes the bloom filter into the given file, going through a temporary
+// file so a partially written filter is never picked up.
+func (bloom *stateBloom) Commit(filename, tempname string) error {
+	if _, err := bloom.bloom.WriteFile(tempname); err != nil {
+		return err
+	}
+	// Ensure the file is synced to disk before moving it into place
+	f, err := os.OpenFile(tempname, os.O_RDWR, 0666)
+	if err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	f.Close()
+
+	return os.Rename(tempname, filename)
+}
+
+// Put implements ethdb.KeyValueWriter, adding the hash of a trie node or contract
+// code into the filter.
+func (bloom *stateBloom) Put(key []byte, value []byte) error {
+	if len(key) != common.HashLength {
+		return errors.New("invalid state entry")
+	}
+	bloom.bloom.Add(stateBloomHasher(key))
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter, but removals aren't supported.
+func (bloom *stateBloom) Delete(key []byte) error { panic("not supported") }
+
+// Contain returns whether the given trie node or contract code hash might be in
+// the filter. False positives are possible, false negatives are not.
+func (bloom *stateBloom) Contain(key []byte) bool {
+	return bloom.bloom.Contains(stateBloomHasher(key))
+}
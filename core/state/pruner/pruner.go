@@ -0,0 +1,465 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package pruner implements the offline pruning of the stale state from the
+// database, keeping only the state of a recent block and of the genesis.
+package pruner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const (
+	// stateBloomFilePrefix is the filename prefix of the state bloom filter.
+	stateBloomFilePrefix = "statebloom"
+
+	// stateBloomFileSuffix is the filename suffix of the state bloom filter.
+	stateBloomFileSuffix = "bf.gz"
+
+	// stateBloomFileTempSuffix is the filename suffix of the state bloom filter
+	// while it's being written out to detect write aborts.
+	stateBloomFileTempSuffix = ".tmp"
+
+	// rangeCompactionThreshold is the minimal number of deleted entries to
+	// trigger a range compaction of the database. It's a quite arbitrary number
+	// but just to avoid triggering range compaction because of small deletions.
+	rangeCompactionThreshold = 100000
+
+	// snapshotLayers is the number of diff layers kept on top of the disk layer
+	// of the snapshot, the bottom-most one being the default pruning target.
+	snapshotLayers = 128
+)
+
+var (
+	// emptyRoot is the known root hash of an empty trie.
+	emptyRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+	// emptyCode is the known hash of the empty EVM bytecode.
+	emptyCode = crypto.Keccak256(nil)
+)
+
+// Pruner is an offline tool to prune the stale state with the help of the state
+// snapshot. The workflow of the pruner is very simple:
+//
+//   - iterate the snapshot, reconstruct the relevant state
+//   - iterate the database, delete all other state entries which don't belong to
+//     the target state and the genesis state
+//
+// It can take several hours (around 2 hours for mainnet) to finish the whole
+// pruning work. It's recommended to run it on a machine with decent resources.
+//
+// The state bloom is persisted before the deletions start, so an interrupted
+// pruning is resumed with the same filter, either by running the pruner again or
+// on the next startup of the node.
+type Pruner struct {
+	db         ethdb.Database
+	stateBloom *stateBloom
+	datadir    string
+	headHeader *types.Header
+	snaptree   *snapshot.Tree
+}
+
+// NewPruner creates a pruner of the given database, with a state bloom of the
+// given size in megabytes persisted into the given directory.
+func NewPruner(db ethdb.Database, datadir string, bloomSize uint64) (*Pruner, error) {
+	headHeader, err := readHeadHeader(db)
+	if err != nil {
+		return nil, err
+	}
+	// Sanitize the bloom filter size if it's too small.
+	if bloomSize < 256 {
+		log.Warn("Sanitizing bloomfilter size", "provided(MB)", bloomSize, "updated(MB)", 256)
+		bloomSize = 256
+	}
+	snaptree, err := snapshot.Load(db, trie.NewDatabase(db), 256, headHeader.Root)
+	if err != nil {
+		return nil, err // The relevant snapshot(s) might not exist
+	}
+	stateBloom, err := newStateBloomWithSize(bloomSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Pruner{
+		db:         db,
+		stateBloom: stateBloom,
+		datadir:    datadir,
+		headHeader: headHeader,
+		snaptree:   snaptree,
+	}, nil
+}
+
+// Prune deletes all the stale state entries from the database, keeping the state
+// with the given root and the genesis state. If the root is empty, the state of
+// the bottom-most diff layer of the snapshot, HEAD-127, is kept.
+func (p *Pruner) Prune(root common.Hash) error {
+	// If a state bloom was committed before, a previous pruning was interrupted
+	// and a part of the state may be deleted already, finish that one first.
+	_, bloomRoot, err := findBloomFilter(p.datadir)
+	if err != nil {
+		return err
+	}
+	if bloomRoot != (common.Hash{}) {
+		return RecoverPruning(p.datadir, p.db)
+	}
+	// If the target state root is not specified, use HEAD-127, the bottom-most
+	// diff layer of the snapshot: its state is persisted on shutdown, and it's
+	// unlikely to be reorged.
+	layers := p.snaptree.Snapshots(p.headHeader.Root, snapshotLayers, true)
+	if root == (common.Hash{}) {
+		if len(layers) != snapshotLayers {
+			return fmt.Errorf("snapshot not old enough yet: need %d more blocks", snapshotLayers-len(layers))
+		}
+		// On clique networks consecutive blocks may have the same root, so HEAD-127
+		// may not be paired with the bottom-most diff layer. Find the lowest layer
+		// with its state available, ignoring HEAD and HEAD-1.
+		for i := len(layers) - 1; i >= 2; i-- {
+			if blob, _ := p.db.Get(layers[i].Root().Bytes()); len(blob) != 0 {
+				root = layers[i].Root()
+				log.Info("Selecting snapshot layer as the pruning target", "root", root, "depth", i)
+				break
+			}
+		}
+		if root == (common.Hash{}) {
+			return errors.New("no snapshot layer paired with a persisted state")
+		}
+	} else {
+		var found bool
+		for _, layer := range layers {
+			if layer.Root() == root {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("snapshot layer [%x] not found", root)
+		}
+		// Ensure the root is really present, its presence indicating the presence
+		// of the entire trie.
+		if blob, _ := p.db.Get(root.Bytes()); len(blob) == 0 {
+			return fmt.Errorf("associated state [%x] is not present", root)
+		}
+		log.Info("Selecting user-specified state as the pruning target", "root", root)
+	}
+	middleRoots, err := collectMiddleRoots(p.db, p.headHeader, root)
+	if err != nil {
+		return err
+	}
+	// Regenerate the target state from the snapshot, and add the genesis state,
+	// into the state bloom.
+	start := time.Now()
+	if err := snapshot.GenerateTrie(p.snaptree, root, p.db, p.stateBloom); err != nil {
+		return err
+	}
+	if err := extractGenesis(p.db, p.stateBloom); err != nil {
+		return err
+	}
+	bloomPath := bloomFilterName(p.datadir, root)
+
+	log.Info("Writing state bloom to disk", "name", bloomPath)
+	if err := p.stateBloom.Commit(bloomPath, bloomPath+stateBloomFileTempSuffix); err != nil {
+		return err
+	}
+	log.Info("State bloom filter committed", "name", bloomPath)
+
+	return prune(p.snaptree, root, p.db, p.stateBloom, bloomPath, middleRoots, start)
+}
+
+// RecoverPruning resumes an interrupted pruning, if the state bloom of one was
+// committed into the given directory. The pruning target is not available to
+// the node anymore, so this must be done before the blockchain is loaded.
+func RecoverPruning(datadir string, db ethdb.Database) error {
+	bloomPath, root, err := findBloomFilter(datadir)
+	if err != nil {
+		return err
+	}
+	if bloomPath == "" {
+		return nil // nothing to recover
+	}
+	headHeader, err := readHeadHeader(db)
+	if err != nil {
+		return err
+	}
+	stateBloom, err := newStateBloomFromDisk(bloomPath)
+	if err != nil {
+		return err
+	}
+	log.Info("Loaded state bloom filter", "path", bloomPath)
+
+	middleRoots, err := collectMiddleRoots(db, headHeader, root)
+	if err != nil {
+		return err
+	}
+	// The snapshot wasn't touched by the interrupted pruning, unless it was
+	// interrupted right after flattening it onto the target.
+	snaptree, err := snapshot.Load(db, trie.NewDatabase(db), 256, headHeader.Root)
+	if err != nil {
+		if snaptree, err = snapshot.Load(db, trie.NewDatabase(db), 256, root); err != nil {
+			return err
+		}
+	}
+
+	return prune(snaptree, root, db, stateBloom, bloomPath, middleRoots, time.Now())
+}
+
+// collectMiddleRoots returns the state roots of the blocks above the pruning
+// target. Their state must be forcibly pruned, including the false positives of
+// the state bloom, otherwise the node may pick one as a dangling, incomplete
+// state to resume from.
+func collectMiddleRoots(db ethdb.Reader, head *types.Header, root common.Hash) (map[common.Hash]struct{}, error) {
+	roots := make(map[common.Hash]struct{})
+	for header := head; header.Root != root; {
+		roots[header.Root] = struct{}{}
+		if header.Number.Sign() == 0 {
+			return nil, fmt.Errorf("pruning target [%x] not in the canonical chain", root)
+		}
+		number := header.Number.Uint64() - 1
+		if header = rawdb.ReadHeader(db, header.ParentHash, number); header == nil {
+			return nil, fmt.Errorf("header #%d missing", number)
+		}
+	}
+	return roots, nil
+}
+
+// prune deletes all the trie nodes and contract codes of the database but the
+// ones in the state bloom, then flattens the snapshot onto the pruning target.
+func prune(snaptree *snapshot.Tree, root common.Hash, db ethdb.Database, stateBloom *stateBloom, bloomPath string, middleRoots map[common.Hash]struct{}, start time.Time) error {
+	// Delete all the stale trie nodes and contract codes, keyed by their plain
+	// hash. A tiny part of them is kept because of the false positives of the
+	// bloom, but they will never be visited again.
+	var (
+		count  int
+		size   common.StorageSize
+		pstart = time.Now()
+		logged = time.Now()
+		batch  = db.NewBatch()
+		iter   = db.NewIterator(nil, nil)
+	)
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != common.HashLength {
+			continue
+		}
+		if _, ok := middleRoots[common.BytesToHash(key)]; ok {
+			log.Debug("Forcibly delete the middle state roots", "hash", common.BytesToHash(key))
+		} else if stateBloom.Contain(key) {
+			continue
+		}
+		count++
+		size += common.StorageSize(len(key) + len(iter.Value()))
+		batch.Delete(key)
+
+		if time.Since(logged) > 8*time.Second {
+			var eta time.Duration
+			if done := binary.BigEndian.Uint64(key[:8]); done > 0 {
+				var (
+					left  = math.MaxUint64 - done
+					speed = done/uint64(time.Since(pstart)/time.Millisecond+1) + 1
+				)
+				eta = time.Duration(left/speed) * time.Millisecond
+			}
+			log.Info("Pruning state data", "nodes", count, "size", size,
+				"elapsed", common.PrettyDuration(time.Since(pstart)), "eta", common.PrettyDuration(eta))
+			logged = time.Now()
+		}
+		// Recreate the iterator after every batch commit to allow the underlying
+		// compactor to delete the entries.
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				iter.Release()
+				return err
+			}
+			batch.Reset()
+
+			iter.Release()
+			iter = db.NewIterator(nil, key)
+		}
+	}
+	iter.Release()
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Pruned state data", "nodes", count, "size", size, "elapsed", common.PrettyDuration(time.Since(pstart)))
+
+	// Flatten the layers below the target into the disk layer, and journal the
+	// snapshot with the target as its head. The layers above are dropped.
+	if len(snaptree.Snapshots(root, 1, true)) > 0 {
+		if err := snaptree.Cap(root, 0); err != nil {
+			return err
+		}
+	}
+	if _, err := snaptree.Journal(root); err != nil {
+		return err
+	}
+	// Delete the state bloom, marking the end of the pruning. If interrupted
+	// before, the pruning is resumed from the beginning of the deletions.
+	os.RemoveAll(bloomPath)
+
+	// Compact the database to release the deleted data from the disk right away,
+	// unless only a few entries were deleted.
+	if count >= rangeCompactionThreshold {
+		cstart := time.Now()
+		for b := 0x00; b <= 0xf0; b += 0x10 {
+			var (
+				start = []byte{byte(b)}
+				end   = []byte{byte(b + 0x10)}
+			)
+			if b == 0xf0 {
+				end = nil
+			}
+			log.Info("Compacting database", "range", fmt.Sprintf("%#x-%#x", start, end), "elapsed", common.PrettyDuration(time.Since(cstart)))
+			if err := db.Compact(start, end); err != nil {
+				log.Error("Database compaction failed", "err", err)
+				return err
+			}
+		}
+		log.Info("Database compaction finished", "elapsed", common.PrettyDuration(time.Since(cstart)))
+	}
+	log.Info("State pruning successful", "pruned", size, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// extractGenesis adds all the trie nodes and contract codes of the genesis state
+// into the state bloom, the genesis state being always kept.
+func extractGenesis(db ethdb.Database, stateBloom *stateBloom) error {
+	genesisHash := rawdb.ReadCanonicalHash(db, 0)
+	if genesisHash == (common.Hash{}) {
+		return errors.New("missing genesis hash")
+	}
+	genesis := rawdb.ReadBlock(db, genesisHash, 0)
+	if genesis == nil {
+		return errors.New("missing genesis block")
+	}
+	triedb := trie.NewDatabase(db)
+	t, err := trie.NewSecure(genesis.Root(), triedb)
+	if err != nil {
+		return err
+	}
+	accIter := t.NodeIterator(nil)
+	for accIter.Next(true) {
+		// Embedded nodes don't have a hash
+		if hash := accIter.Hash(); hash != (common.Hash{}) {
+			stateBloom.Put(hash.Bytes(), nil)
+		}
+		if !accIter.Leaf() {
+			continue
+		}
+		var acc state.Account
+		if err := rlp.DecodeBytes(accIter.LeafBlob(), &acc); err != nil {
+			return err
+		}
+		if acc.Root != emptyRoot {
+			storageTrie, err := trie.NewSecure(acc.Root, triedb)
+			if err != nil {
+				return err
+			}
+			storageIter := storageTrie.NodeIterator(nil)
+			for storageIter.Next(true) {
+				if hash := storageIter.Hash(); hash != (common.Hash{}) {
+					stateBloom.Put(hash.Bytes(), nil)
+				}
+			}
+			if storageIter.Error() != nil {
+				return storageIter.Error()
+			}
+		}
+		if !bytes.Equal(acc.CodeHash, emptyCode) {
+			stateBloom.Put(acc.CodeHash, nil)
+		}
+	}
+	return accIter.Error()
+}
+
+// readHeadHeader retrieves the header of the head block of the database.
+func readHeadHeader(db ethdb.Reader) (*types.Header, error) {
+	hash := rawdb.ReadHeadBlockHash(db)
+	if hash == (common.Hash{}) {
+		return nil, errors.New("empty database")
+	}
+	number := rawdb.ReadHeaderNumber(db, hash)
+	if number == nil {
+		return nil, fmt.Errorf("head block %x missing", hash)
+	}
+	header := rawdb.ReadHeader(db, hash, *number)
+	if header == nil {
+		return nil, fmt.Errorf("head block %x missing", hash)
+	}
+	return header, nil
+}
+
+// bloomFilterName returns the path of the state bloom filter of the given state
+// root in the given directory.
+func bloomFilterName(datadir string, hash common.Hash) string {
+	return filepath.Join(datadir, fmt.Sprintf("%s.%s.%s", stateBloomFilePrefix, hash.Hex(), stateBloomFileSuffix))
+}
+
+// isBloomFilter returns whether the given filename is the one of a state bloom
+// filter, and the state root it was generated for.
+func isBloomFilter(filename string) (bool, common.Hash) {
+	filename = filepath.Base(filename)
+	if strings.HasPrefix(filename, stateBloomFilePrefix) && strings.HasSuffix(filename, stateBloomFileSuffix) {
+		return true, common.HexToHash(filename[len(stateBloomFilePrefix)+1 : len(filename)-len(stateBloomFileSuffix)-1])
+	}
+	return false, common.Hash{}
+}
+
+// findBloomFilter returns the path of the state bloom filter committed into the
+// given directory and its state root, if any.
+func findBloomFilter(datadir string) (string, common.Hash, error) {
+	if datadir == "" {
+		return "", common.Hash{}, nil // ephemeral node
+	}
+	var (
+		stateBloomPath string
+		stateBloomRoot common.Hash
+	)
+	if err := filepath.Walk(datadir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != datadir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ok, root := isBloomFilter(path); ok {
+			stateBloomPath, stateBloomRoot = path, root
+		}
+		return nil
+	}); err != nil {
+		return "", common.Hash{}, err
+	}
+	return stateBloomPath, stateBloomRoot, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// newTestChain creates an archive database with a chain of the given length and
+// a state snapshot, each block updating the storage of a contract.
+func newTestChain(t *testing.T, n int) (ethdb.Database, common.Address) {
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		counter = common.Address{0xc0}
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &genesisT.Genesis{
+			Config: params.AllEthashProtocolChanges,
+			Alloc: genesisT.GenesisAlloc{
+				address: {Balance: big.NewInt(vars.Ether)},
+				// NUMBER NUMBER SSTORE NUMBER PUSH1 0 SSTORE
+				counter: {Code: []byte{0x43, 0x43, 0x55, 0x43, 0x60, 0x00, 0x55}, Balance: new(big.Int)},
+			},
+		}
+		genesis = core.MustCommitGenesis(db, gspec)
+		signer  = types.NewEIP155Signer(gspec.Config.GetChainID())
+		engine  = ethash.NewFaker()
+	)
+	blocks, _ := core.GenerateChain(gspec.Config, genesis, engine, db, n, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(address), counter, nil, 100000, nil, nil), signer, key)
+		gen.AddTx(tx)
+		tx, _ = types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{byte(i)}, big.NewInt(1), vars.TxGas, nil, nil), signer, key)
+		gen.AddTx(tx)
+
+		if i == 10 {
+			// Deploy NUMBER NUMBER SSTORE
+			code := []byte{0x62, 0x43, 0x43, 0x55, 0x60, 0x00, 0x52, 0x60, 0x03, 0x60, 0x1d, 0xf3}
+			tx, _ = types.SignTx(types.NewContractCreation(gen.TxNonce(address), nil, 100000, nil, code), signer, key)
+			gen.AddTx(tx)
+		}
+	})
+	cacheConfig := &core.CacheConfig{
+		TrieCleanLimit:    16,
+		TrieDirtyLimit:    16,
+		TrieDirtyDisabled: true,
+		TrieTimeLimit:     5 * time.Minute,
+		SnapshotLimit:     16,
+		SnapshotWait:      true,
+	}
+	chain, err := core.NewBlockChain(db, cacheConfig, gspec.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.Stop()
+
+	return db, crypto.CreateAddress(address, 22)
+}
+
+// checkState iterates over all the trie nodes and codes of the given state, to
+// ensure it's complete.
+func checkState(t *testing.T, db ethdb.Database, root common.Hash) {
+	statedb, err := state.New(root, state.NewDatabase(db), nil)
+	if err != nil {
+		t.Fatalf("state %x missing: %v", root, err)
+	}
+	it := state.NewNodeIterator(statedb)
+	for it.Next() {
+	}
+	if it.Error != nil {
+		t.Fatalf("state %x incomplete: %v", root, it.Error)
+	}
+}
+
+// Tests that the pruner deletes all the state but the one of the HEAD-127 block
+// and the genesis, and that the chain resumes from the pruning target.
+func TestPrune(t *testing.T) {
+	for _, interrupt := range []bool{false, true} {
+		db, deployed := newTestChain(t, 200)
+
+		datadir, err := ioutil.TempDir("", "pruner")
+		if err != nil {
+			t.Fatalf("failed to create datadir: %v", err)
+		}
+		defer os.RemoveAll(datadir)
+
+		head, _ := readHeadHeader(db)
+		stateBloom, _ := newStateBloomWithSize(1)
+		pruner := &Pruner{
+			db:         db,
+			stateBloom: stateBloom,
+			datadir:    datadir,
+			headHeader: head,
+			snaptree:   loadSnapshot(t, db, head.Root),
+		}
+		target := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, 73), 73)
+		if interrupt {
+			// Commit the state bloom, as an interrupted pruning would do before
+			// deleting anything, and prune on recovery
+			if err := snapshot.GenerateTrie(pruner.snaptree, target.Root, db, stateBloom); err != nil {
+				t.Fatalf("failed to generate state: %v", err)
+			}
+			if err := extractGenesis(db, stateBloom); err != nil {
+				t.Fatalf("failed to extract genesis: %v", err)
+			}
+			path := bloomFilterName(datadir, target.Root)
+			if err := stateBloom.Commit(path, path+stateBloomFileTempSuffix); err != nil {
+				t.Fatalf("failed to commit state bloom: %v", err)
+			}
+			if err := RecoverPruning(datadir, db); err != nil {
+				t.Fatalf("failed to recover pruning: %v", err)
+			}
+		} else {
+			if err := pruner.Prune(common.Hash{}); err != nil {
+				t.Fatalf("failed to prune: %v", err)
+			}
+		}
+		if path, _, _ := findBloomFilter(datadir); path != "" {
+			t.Errorf("interrupt %v: state bloom not deleted: %s", interrupt, path)
+		}
+		// The target and genesis states are complete, the others pruned
+		checkState(t, db, target.Root)
+		checkState(t, db, rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, 0), 0).Root)
+
+		for number := uint64(1); number <= head.Number.Uint64(); number++ {
+			header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number), number)
+			if header.Root == target.Root {
+				continue
+			}
+			if blob, _ := db.Get(header.Root.Bytes()); len(blob) != 0 {
+				t.Errorf("interrupt %v: state of block %d not pruned", interrupt, number)
+			}
+		}
+		// The chain is rewound to the pruning target, with the snapshot
+		chain, err := core.NewBlockChain(db, &core.CacheConfig{TrieCleanLimit: 16, TrieDirtyLimit: 16, TrieTimeLimit: 5 * time.Minute, SnapshotLimit: 16, SnapshotWait: true}, params.AllEthashProtocolChanges, ethash.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create chain: %v", err)
+		}
+		if have, want := chain.CurrentBlock().NumberU64(), target.Number.Uint64(); have != want {
+			t.Errorf("interrupt %v: head mismatch: have %d, want %d", interrupt, have, want)
+		}
+		statedb, _ := chain.State()
+		if code := statedb.GetCode(deployed); len(code) != 3 {
+			t.Errorf("interrupt %v: deployed code mismatch: have %x", interrupt, code)
+		}
+		if have, want := statedb.GetState(common.Address{0xc0}, common.Hash{}), common.BigToHash(target.Number); have != want {
+			t.Errorf("interrupt %v: storage mismatch: have %x, want %x", interrupt, have, want)
+		}
+		if snap := chain.Snapshot().Snapshot(target.Root); snap == nil {
+			t.Errorf("interrupt %v: snapshot not resumed from the pruning target", interrupt)
+		}
+		chain.Stop()
+	}
+}
+
+// Tests that the pruner refuses to prune without HEAD-127 in the snapshot.
+func TestPruneShortSnapshot(t *testing.T) {
+	db, _ := newTestChain(t, 100)
+
+	head, _ := readHeadHeader(db)
+	stateBloom, _ := newStateBloomWithSize(1)
+	pruner := &Pruner{
+		db:         db,
+		stateBloom: stateBloom,
+		headHeader: head,
+		snaptree:   loadSnapshot(t, db, head.Root),
+	}
+	if err := pruner.Prune(common.Hash{}); err == nil {
+		t.Fatal("pruned with a short snapshot")
+	}
+}
+
+// loadSnapshot loads the snapshot journalled with the given head.
+func loadSnapshot(t *testing.T, db ethdb.Database, root common.Hash) *snapshot.Tree {
+	snaptree, err := snapshot.Load(db, trie.NewDatabase(db), 16, root)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	return snaptree
+}
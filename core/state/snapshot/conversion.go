@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -42,7 +43,7 @@ type (
 
 	// leafCallbackFn is the callback invoked at the leaves of the trie,
 	// returns the subtrie root with the specified subtrie identifier.
-	leafCallbackFn func(hash common.Hash, stat *generateStats) common.Hash
+	leafCallbackFn func(hash common.Hash, codeHash common.Hash, stat *generateStats) (common.Hash, error)
 )
 
 // GenerateAccountTrieRoot takes an account iterator and reproduces the root hash.
@@ -65,18 +66,54 @@ func VerifyState(snaptree *Tree, root common.Hash) error {
 	}
 	defer acctIt.Release()
 
-	got, err := generateTrieRoot(acctIt, common.Hash{}, stdGenerate, func(account common.Hash, stat *generateStats) common.Hash {
+	got, err := generateTrieRoot(acctIt, common.Hash{}, stdGenerate, func(account common.Hash, codeHash common.Hash, stat *generateStats) (common.Hash, error) {
 		storageIt, err := snaptree.StorageIterator(root, account, common.Hash{})
 		if err != nil {
-			return common.Hash{}
+			return common.Hash{}, err
 		}
 		defer storageIt.Release()
 
-		hash, err := generateTrieRoot(storageIt, account, stdGenerate, nil, stat, false)
+		return generateTrieRoot(storageIt, account, stdGenerate, nil, stat, false)
+	}, &generateStats{start: time.Now()}, true)
+
+	if err != nil {
+		return err
+	}
+	if got != root {
+		return fmt.Errorf("state root hash mismatch: got %x, want %x", got, root)
+	}
+	return nil
+}
+
+// GenerateTrie takes the whole snapshot tree as the input, traverses all the
+// accounts as well as the corresponding storages, and regenerates the entire
+// state (account trie, storage tries and contract codes) into the destination.
+func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.KeyValueReader, dst ethdb.KeyValueWriter) error {
+	acctIt, err := snaptree.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return err
+	}
+	defer acctIt.Release()
+
+	got, err := generateTrieRoot(acctIt, common.Hash{}, stackGenerate(dst), func(account common.Hash, codeHash common.Hash, stat *generateStats) (common.Hash, error) {
+		// Migrate the contract code first
+		if codeHash != emptyCode {
+			code, _ := src.Get(codeHash.Bytes())
+			if len(code) == 0 {
+				return common.Hash{}, fmt.Errorf("contract code %x missing", codeHash)
+			}
+			if err := dst.Put(codeHash.Bytes(), code); err != nil {
+				return common.Hash{}, err
+			}
+		}
+		// Then regenerate all the storage trie nodes
+		storageIt, err := snaptree.StorageIterator(root, account, common.Hash{})
 		if err != nil {
-			return common.Hash{}
+			return common.Hash{}, err
 		}
-		return hash
+		defer storageIt.Release()
+
+		return generateTrieRoot(storageIt, account, stackGenerate(dst), nil, stat, false)
 	}, &generateStats{start: time.Now()}, true)
 
 	if err != nil {
@@ -223,7 +260,11 @@ func generateTrieRoot(it Iterator, account common.Hash, generatorFn trieGenerato
 				}
 				// Apply the leaf callback. Normally the callback is used to traverse
 				// the storage trie and re-generate the subtrie root.
-				subroot := leafCallback(it.Hash(), stats)
+				subroot, err := leafCallback(it.Hash(), common.BytesToHash(account.CodeHash), stats)
+				if err != nil {
+					stop(false)
+					return common.Hash{}, err
+				}
 				if !bytes.Equal(account.Root, subroot.Bytes()) {
 					stop(false)
 					return common.Hash{}, fmt.Errorf("invalid subroot(%x), want %x, got %x", it.Hash(), account.Root, subroot)
@@ -273,3 +314,16 @@ func stdGenerate(in chan (trieKV), out chan (common.Hash)) {
 	}
 	out <- t.Hash()
 }
+
+// stackGenerate returns a hexary trie builder which writes all the trie nodes it
+// generates into the given database, with a memory usage bounded by the depth of
+// the trie.
+func stackGenerate(db ethdb.KeyValueWriter) trieGeneratorFn {
+	return func(in chan (trieKV), out chan (common.Hash)) {
+		t := trie.NewStackTrie(db)
+		for leaf := range in {
+			t.TryUpdate(leaf.key[:], leaf.value)
+		}
+		out <- t.Hash()
+	}
+}
@@ -193,6 +193,30 @@ func New(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root comm
 	return snap
 }
 
+// Load loads an already existing snapshot from a persistent key-value store, with
+// a number of memory layers from a journal, ensuring that the head of the
+// snapshot matches the expected one. Unlike New, a missing or inconsistent
+// snapshot is not rebuilt, but reported. An interrupted generation is resumed,
+// and waited for.
+func Load(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash) (*Tree, error) {
+	head, err := loadSnapshot(diskdb, triedb, cache, root)
+	if err != nil {
+		return nil, err
+	}
+	snap := &Tree{
+		diskdb: diskdb,
+		triedb: triedb,
+		cache:  cache,
+		layers: make(map[common.Hash]snapshot),
+	}
+	for head != nil {
+		snap.layers[head.Root()] = head
+		head = head.Parent()
+	}
+	snap.waitBuild()
+	return snap, nil
+}
+
 // waitBuild blocks until the snapshot finishes rebuilding. This method is meant
 // to  be used by tests to ensure we're testing what we believe we are.
 func (t *Tree) waitBuild() {
@@ -223,6 +247,23 @@ func (t *Tree) Snapshot(blockRoot common.Hash) Snapshot {
 	return t.layers[blockRoot]
 }
 
+// Snapshots returns the layers of the tree from the one with the given root down
+// to the disk layer, at most limit of them. If nodisk is set, the disk layer is
+// excluded.
+func (t *Tree) Snapshots(root common.Hash, limit int, nodisk bool) []Snapshot {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var layers []Snapshot
+	for layer := t.layers[root]; layer != nil && len(layers) < limit; layer = layer.Parent() {
+		if _, ok := layer.(*diskLayer); ok && nodisk {
+			break
+		}
+		layers = append(layers, layer)
+	}
+	return layers
+}
+
 // Update adds a new snapshot into the tree, if that can be linked to an existing
 // old parent. It is disallowed to insert a disk layer (the origin of all).
 func (t *Tree) Update(blockRoot common.Hash, parentRoot common.Hash, destructs map[common.Hash]struct{}, accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte) error {
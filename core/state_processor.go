@@ -116,6 +116,7 @@ func ApplyTransaction(config ctypes.ChainConfigurator, bc ChainContext, author *
 	// Create a new receipt for the transaction, storing the intermediate root and gas used by the tx
 	// based on the eip phase, we're passing whether the root touch-delete accounts.
 	receipt := types.NewReceipt(root, result.Failed(), *usedGas)
+	receipt.Type = tx.Type()
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
 	// if the transaction created a contract, store the creation address in the receipt.
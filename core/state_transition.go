@@ -21,6 +21,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params/vars"
 )
@@ -66,6 +67,7 @@ type Message interface {
 	Nonce() uint64
 	CheckNonce() bool
 	Data() []byte
+	AccessList() types.AccessList
 }
 
 // ExecutionResult includes all output after executing given evm
@@ -104,7 +106,7 @@ func (result *ExecutionResult) Revert() []byte {
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, contractCreation, isEIP2 bool, isEIP2028 bool) (uint64, error) {
+func IntrinsicGas(data []byte, accessList types.AccessList, contractCreation, isEIP2 bool, isEIP2028 bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if contractCreation && isEIP2 {
@@ -137,6 +139,12 @@ func IntrinsicGas(data []byte, contractCreation, isEIP2 bool, isEIP2028 bool) (u
 		}
 		gas += z * vars.TxDataZeroGas
 	}
+	// Access lists are only carried by EIP-2718 typed transactions,
+	// and so are only priced when present.
+	if accessList != nil {
+		gas += uint64(len(accessList)) * vars.TxAccessListAddressGas
+		gas += uint64(accessList.StorageKeys()) * vars.TxAccessListStorageKeyGas
+	}
 	return gas, nil
 }
 
@@ -235,7 +243,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	contractCreation := msg.To() == nil
 
 	// Pay intrinsic gas
-	gas, err := IntrinsicGas(st.data, contractCreation, eip2f, eip2028f)
+	gas, err := IntrinsicGas(st.data, msg.AccessList(), contractCreation, eip2f, eip2028f)
 	if err != nil {
 		return nil, err
 	}
@@ -248,6 +256,10 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if msg.Value().Sign() > 0 && !st.evm.CanTransfer(st.state, msg.From(), msg.Value()) {
 		return nil, ErrInsufficientFundsForTransfer
 	}
+	// Warm the sender, destination and precompiles, and the optional EIP-2930 access list.
+	if st.evm.ChainConfig().IsEnabled(st.evm.ChainConfig().GetEIP2929Transition, st.evm.BlockNumber) {
+		st.state.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(st.evm.ChainConfig(), st.evm.BlockNumber), msg.AccessList())
+	}
 	var (
 		ret   []byte
 		vmerr error // vm errors do not effect consensus and are therefore not assigned to err
@@ -246,6 +246,7 @@ type TxPool struct {
 
 	eip2f    bool
 	eip2028f bool
+	eip2718  bool // Fork indicator whether we are using EIP-2718 typed transactions.
 }
 
 type txpoolResetRequest struct {
@@ -263,7 +264,7 @@ func NewTxPool(config TxPoolConfig, chainconfig ctypes.ChainConfigurator, chain
 		config:          config,
 		chainconfig:     chainconfig,
 		chain:           chain,
-		signer:          types.NewEIP155Signer(chainconfig.GetChainID()),
+		signer:          types.LatestSigner(chainconfig),
 		pending:         make(map[common.Address]*txList),
 		queue:           make(map[common.Address]*txList),
 		beats:           make(map[common.Address]time.Time),
@@ -521,6 +522,10 @@ func (pool *TxPool) local() map[common.Address]types.Transactions {
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
+	// Reject typed transactions before the EIP-2718 fork activates.
+	if !pool.eip2718 && tx.Type() != types.LegacyTxType {
+		return ErrTxTypeNotSupported
+	}
 	// Reject transactions over defined size to prevent DOS attacks
 	if uint64(tx.Size()) > txMaxSize {
 		return ErrOversizedData
@@ -554,7 +559,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return ErrInsufficientFunds
 	}
 	// Ensure the transaction has more gas than the basic tx fee.
-	intrGas, err := IntrinsicGas(tx.Data(), tx.To() == nil, pool.eip2f, pool.eip2028f)
+	intrGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, pool.eip2f, pool.eip2028f)
 	if err != nil {
 		return err
 	}
@@ -1167,6 +1172,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	// Update all fork indicator by next pending block number.
 	next := new(big.Int).Add(newHead.Number, big.NewInt(1))
 	pool.eip2028f = pool.chainconfig.IsEnabled(pool.chainconfig.GetEIP2028Transition, next)
+	pool.eip2718 = pool.chainconfig.IsEnabled(pool.chainconfig.GetEIP2718Transition, next)
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -267,6 +267,56 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that EIP-2718 typed transactions are only accepted once the fork activates.
+func TestTypedTransactionsGating(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	txdata := &types.AccessListTx{
+		ChainID:    params.TestChainConfig.GetChainID(),
+		Gas:        100000,
+		GasPrice:   big.NewInt(1),
+		AccessList: types.AccessList{{Address: common.Address{1}, StorageKeys: []common.Hash{{1}}}},
+	}
+	tx, err := types.SignTx(types.NewTx(txdata), types.NewEIP2930Signer(params.TestChainConfig.GetChainID()), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The test configuration does not schedule EIP-2718.
+	pool, _ := setupTxPool()
+	defer pool.Stop()
+
+	pool.currentState.AddBalance(from, big.NewInt(0xffffffffffffff))
+	if err := pool.AddRemote(tx); err != ErrTxTypeNotSupported {
+		t.Error("expected", ErrTxTypeNotSupported, "got", err)
+	}
+
+	// Activate EIP-2718 at genesis.
+	config := *params.TestChainConfig
+	if err := config.SetEIP2718Transition(new(uint64)); err != nil {
+		t.Fatal(err)
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{statedb, 10000000, new(event.Feed)}
+	pool2 := NewTxPool(testTxPoolConfig, &config, blockchain)
+	defer pool2.Stop()
+
+	pool2.currentState.AddBalance(from, big.NewInt(0xffffffffffffff))
+	if err := pool2.AddRemote(tx); err != nil {
+		t.Error("expected", nil, "got", err)
+	}
+
+	// The access list is charged as intrinsic gas.
+	txdata.Nonce, txdata.Gas = 1, vars.TxGas+vars.TxAccessListAddressGas
+	tx, _ = types.SignTx(types.NewTx(txdata), types.NewEIP2930Signer(params.TestChainConfig.GetChainID()), key)
+	if err := pool2.AddRemote(tx); err != ErrIntrinsicGas {
+		t.Error("expected", ErrIntrinsicGas, "got", err)
+	}
+}
+
 func TestTransactionQueue(t *testing.T) {
 	t.Parallel()
 
@@ -24,18 +24,28 @@ import (
 	"github.com/ethereum/go-ethereum/trie"
 )
 
+// DerivableList is the input to DeriveSha.
+// It is implemented by the 'Transactions' and 'Receipts' types.
+// This is internal, do not use these methods.
 type DerivableList interface {
 	Len() int
-	GetRlp(i int) []byte
+	EncodeIndex(int, *bytes.Buffer)
 }
 
+// DeriveSha creates the tree hashes of transactions and receipts in a block header.
+// List items are encoded with their canonical encoding, ie. EIP-2718 typed items
+// are inserted into the trie as their type byte followed by the payload.
 func DeriveSha(list DerivableList) common.Hash {
 	keybuf := new(bytes.Buffer)
+	valuebuf := new(bytes.Buffer)
 	trie := new(trie.Trie)
 	for i := 0; i < list.Len(); i++ {
 		keybuf.Reset()
 		rlp.Encode(keybuf, uint(i))
-		trie.Update(keybuf.Bytes(), list.GetRlp(i))
+		valuebuf.Reset()
+		list.EncodeIndex(i, valuebuf)
+		// The trie retains the value slice, so it must not alias the reused buffer.
+		trie.Update(keybuf.Bytes(), common.CopyBytes(valuebuf.Bytes()))
 	}
 	return trie.Hash()
 }
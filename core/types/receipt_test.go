@@ -151,6 +151,53 @@ func encodeAsV3StoredReceiptRLP(want *Receipt) ([]byte, error) {
 	return rlp.EncodeToBytes(stored)
 }
 
+// Tests that typed receipts are encoded as EIP-2718 envelopes and survive a
+// round trip through the consensus encoding.
+func TestTypedReceiptEncoding(t *testing.T) {
+	receipt := &Receipt{
+		Type:              AccessListTxType,
+		Status:            ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		Logs: []*Log{
+			{
+				Address: common.BytesToAddress([]byte{0x11}),
+				Topics:  []common.Hash{common.HexToHash("dead")},
+				Data:    []byte{0x01},
+			},
+		},
+	}
+	receipt.Bloom = CreateBloom(Receipts{receipt})
+
+	enc, err := rlp.EncodeToBytes(receipt)
+	if err != nil {
+		t.Fatalf("Error encoding receipt: %v", err)
+	}
+	var content []byte
+	if err := rlp.DecodeBytes(enc, &content); err != nil {
+		t.Fatalf("Typed receipt not encoded as RLP string: %v", err)
+	}
+	if content[0] != AccessListTxType {
+		t.Fatalf("Wrong type prefix: have %d, want %d", content[0], AccessListTxType)
+	}
+	var dec Receipt
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatalf("Error decoding receipt: %v", err)
+	}
+	if dec.Type != receipt.Type || dec.Status != receipt.Status || dec.CumulativeGasUsed != receipt.CumulativeGasUsed || dec.Bloom != receipt.Bloom {
+		t.Fatalf("Receipt mismatch: have %+v, want %+v", dec, receipt)
+	}
+	if len(dec.Logs) != 1 || !reflect.DeepEqual(dec.Logs[0].Topics, receipt.Logs[0].Topics) {
+		t.Fatalf("Receipt logs mismatch: have %v, want %v", dec.Logs, receipt.Logs)
+	}
+
+	// Unknown receipt types must be rejected.
+	bad := append([]byte{0x7f}, content[1:]...)
+	badEnc, _ := rlp.EncodeToBytes(bad)
+	if err := rlp.DecodeBytes(badEnc, &dec); err != ErrTxTypeNotSupported {
+		t.Fatalf("Expected %v for unknown receipt type, got %v", ErrTxTypeNotSupported, err)
+	}
+}
+
 // Tests that receipt data can be correctly derived from the contextual infos
 func TestDeriveFields(t *testing.T) {
 	// Create a few transactions to have receipts for
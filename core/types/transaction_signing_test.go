@@ -76,6 +76,47 @@ func TestEIP155ChainId(t *testing.T) {
 	}
 }
 
+func TestEIP2930Signing(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	signer := NewEIP2930Signer(big.NewInt(18))
+	txdata := &AccessListTx{
+		ChainID:    big.NewInt(18),
+		To:         &addr,
+		GasPrice:   new(big.Int),
+		AccessList: AccessList{{Address: addr, StorageKeys: []common.Hash{{1}}}},
+	}
+	tx, err := SignTx(NewTx(txdata), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+
+	// Signers predating EIP-2718 must reject typed transactions.
+	if _, err := Sender(NewEIP155Signer(big.NewInt(18)), tx); err != ErrTxTypeNotSupported {
+		t.Errorf("expected %v, got %v", ErrTxTypeNotSupported, err)
+	}
+	// The chain ID of the transaction must match the signer.
+	if _, err := Sender(NewEIP2930Signer(big.NewInt(1)), tx); err == nil {
+		t.Error("expected error for mismatching chain id")
+	}
+	// Legacy transactions are still accepted.
+	legacy, err := SignTx(NewTransaction(0, addr, new(big.Int), 0, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from, err := Sender(NewEIP155Signer(big.NewInt(18)), legacy); err != nil || from != addr {
+		t.Errorf("legacy sender mismatch: have %x (%v), want %x", from, err, addr)
+	}
+}
+
 func TestEIP155SigningVitalik(t *testing.T) {
 	// Test vectors come from http://vitalik.ca/files/eip155_testvec.txt
 	for i, test := range []struct {
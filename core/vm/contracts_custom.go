@@ -0,0 +1,161 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"golang.org/x/crypto/sha3"
+)
+
+// BuiltinPrecompile is a named precompiled contract implementation which chain
+// configurations may install at arbitrary addresses as custom precompiles.
+// Builtins are not priced; gas is charged according to the pricing formula
+// declared alongside them in the configuration.
+type BuiltinPrecompile func(input []byte) ([]byte, error)
+
+var (
+	builtinPrecompilesMu sync.RWMutex
+	builtinPrecompiles   = map[string]BuiltinPrecompile{
+		"identity":       (&dataCopy{}).Run,
+		"sha256":         (&sha256hash{}).Run,
+		"ripemd160":      (&ripemd160hash{}).Run,
+		"keccak256":      runKeccak256,
+		"sha3_512":       runSha3512,
+		"ed25519_verify": runEd25519Verify,
+	}
+)
+
+// RegisterBuiltinPrecompile adds a named implementation to the registry of builtins
+// available to custom precompiles. It returns an error if the name is already taken.
+func RegisterBuiltinPrecompile(name string, run BuiltinPrecompile) error {
+	builtinPrecompilesMu.Lock()
+	defer builtinPrecompilesMu.Unlock()
+
+	if _, ok := builtinPrecompiles[name]; ok {
+		return fmt.Errorf("builtin precompile %q already registered", name)
+	}
+	builtinPrecompiles[name] = run
+	return nil
+}
+
+// BuiltinPrecompileNames returns the sorted names of all registered builtins.
+func BuiltinPrecompileNames() []string {
+	builtinPrecompilesMu.RLock()
+	defer builtinPrecompilesMu.RUnlock()
+
+	names := make([]string, 0, len(builtinPrecompiles))
+	for name := range builtinPrecompiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupBuiltinPrecompile(name string) (BuiltinPrecompile, bool) {
+	builtinPrecompilesMu.RLock()
+	defer builtinPrecompilesMu.RUnlock()
+
+	run, ok := builtinPrecompiles[name]
+	return run, ok
+}
+
+// ValidateCustomPrecompiles checks that every custom precompile declared by the
+// configuration refers to a registered builtin, has a pricing formula, and does
+// not shadow a protocol precompiled contract.
+func ValidateCustomPrecompiles(config ctypes.ChainConfigurator) error {
+	for addr, p := range config.GetCustomPrecompiles() {
+		if _, ok := lookupBuiltinPrecompile(p.Builtin); !ok {
+			return fmt.Errorf("custom precompile %s: unknown builtin %q (available: %v)", addr.Hex(), p.Builtin, BuiltinPrecompileNames())
+		}
+		if p.Pricing.Linear == nil {
+			return fmt.Errorf("custom precompile %s: missing pricing", addr.Hex())
+		}
+		if isProtocolPrecompileAddress(addr) {
+			return fmt.Errorf("custom precompile %s: address reserved for protocol precompiles", addr.Hex())
+		}
+	}
+	return nil
+}
+
+// isProtocolPrecompileAddress returns true for addresses used by the
+// precompiled contracts defined by the protocol (0x01 - 0x12).
+func isProtocolPrecompileAddress(addr common.Address) bool {
+	for _, b := range addr[:common.AddressLength-1] {
+		if b != 0 {
+			return false
+		}
+	}
+	last := addr[common.AddressLength-1]
+	return last >= 1 && last <= 18
+}
+
+// customPrecompile is a builtin installed by the chain configuration.
+type customPrecompile struct {
+	run     BuiltinPrecompile
+	pricing ctypes.CustomPrecompilePricing
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *customPrecompile) RequiredGas(input []byte) uint64 {
+	return c.pricing.RequiredGas(len(input))
+}
+
+func (c *customPrecompile) Run(input []byte) ([]byte, error) {
+	return c.run(input)
+}
+
+// runKeccak256 returns the Keccak-256 digest of the input.
+func runKeccak256(input []byte) ([]byte, error) {
+	return crypto.Keccak256(input), nil
+}
+
+// runSha3512 returns the (FIPS 202) SHA3-512 digest of the input.
+func runSha3512(input []byte) ([]byte, error) {
+	h := sha3.Sum512(input)
+	return h[:], nil
+}
+
+var (
+	// ed25519VerifyValid is returned by ed25519_verify for a valid signature.
+	ed25519VerifyValid = []byte{0, 0, 0, 0}
+
+	// ed25519VerifyInvalid is returned by ed25519_verify for an invalid signature.
+	ed25519VerifyInvalid = []byte{0xff, 0xff, 0xff, 0xff}
+)
+
+// runEd25519Verify verifies an Ed25519 signature as proposed by EIP-665.
+// The input is the 32 byte message digest, the 32 byte public key and the
+// 64 byte signature; the output is 4 zero bytes if the signature is valid,
+// 0xffffffff otherwise.
+func runEd25519Verify(input []byte) ([]byte, error) {
+	const ed25519VerifyInputLength = 128
+
+	if len(input) != ed25519VerifyInputLength {
+		return ed25519VerifyInvalid, nil
+	}
+	if ed25519.Verify(ed25519.PublicKey(input[32:64]), input[:32], input[64:128]) {
+		return ed25519VerifyValid, nil
+	}
+	return ed25519VerifyInvalid, nil
+}
@@ -18,12 +18,16 @@ package vm
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -401,8 +405,597 @@ var blake2FTests = []precompiledTest{
 	},
 }
 
+// bls12381G1AddTests are the test and benchmark data for the BLS12-381 G1 point
+// addition precompiled contract.
+var bls12381G1AddTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expected: "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28",
+		name:     "bls_g1add_g1+g1",
+	},
+	{
+		input:    "000000000000000000000000000000000ce881076270a57c351eb1d41cc864df86f308a62aaa2b2ae1ff585271f059797ae712cbfbf06f085aea367fd0e7de95000000000000000000000000000000000d2e81b71a9874aa9482efbe0706da039f6c0ca822092e0a433dfd906d08d504c5165b31edfbae650fb1b639cba4f5b50000000000000000000000000000000008e9a18c34f5d26f471b432649a4c65764d4dc43ab093d8534a33af60cf2b06345ca37943effb690422f590f26dcfd5200000000000000000000000000000000114a1913b9c52111da906ae69087633add61f1a7e3ed39ef780f558ec457c289c1e8e8ddfdb92a93923f75425171541e",
+		expected: "00000000000000000000000000000000063dfb6e098840bf4ae3cc9056bb543229d1776d3c7b155813755a8b4812380f49f3f23831550206a65614c4fe579ca0000000000000000000000000000000001869cfeed38e65ddd50be81fcc0444749a0228b2da8aa39637693fa15cfd72655fd0102cdea8581bdf8e06221e952765",
+		name:     "bls_g1add_(p0)+(q0)",
+	},
+	{
+		input:    "0000000000000000000000000000000000c30935045527ce02188982a1380b76a5087b82bad5a3c426507f2f63c1173c21df7a28fa1c292411a0530025ad84640000000000000000000000000000000008e3b13b9401e5779234d13996735de88ed7f3821bd9687b4d498b59723e2cc83ec96fceb3da4be4ec2785c0cd7512650000000000000000000000000000000015c6ce31633a904b4fd4b5004882fc320a241a821301b8080a4599e2f95e778bd32a4c736742d8a4a470c3b1098302db000000000000000000000000000000000805a1d8d46e224afba44e69f89ca9d5eeb4430c27aa5cb0fe4d2bf317c7acd3e57967b457b9b5d32e080305e3669736",
+		expected: "000000000000000000000000000000000470b6af29521c59c2bf2ff61cd83aa935ab1d94a9361c9b2fd8a5616dd9ad7be05c9a21e2856ef73b359a66baee22f70000000000000000000000000000000009abbdcca4847598037187b62e2169411952837f8dda7daa6bbe5cd1bd1a62b7edc6997e71b0c64f0e2de9e87d76f4fb",
+		name:     "bls_g1add_(p1)+(q1)",
+	},
+	{
+		input:    "000000000000000000000000000000001618442d9cdeae9f97858a01c3b80d2b7b0eba9d42f1970cc0824831384a8b8a4cab4ff601985682f24de1e626d413b70000000000000000000000000000000012b821534f5dbedf5ce509a04667cc1822937e445bf7fae94a45d3fd35a558ca537fc8159fdaa31ba07c62cb5b4a46b90000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "000000000000000000000000000000001618442d9cdeae9f97858a01c3b80d2b7b0eba9d42f1970cc0824831384a8b8a4cab4ff601985682f24de1e626d413b70000000000000000000000000000000012b821534f5dbedf5ce509a04667cc1822937e445bf7fae94a45d3fd35a558ca537fc8159fdaa31ba07c62cb5b4a46b9",
+		name:     "bls_g1add_p+0",
+	},
+	{
+		input:    "000000000000000000000000000000001618442d9cdeae9f97858a01c3b80d2b7b0eba9d42f1970cc0824831384a8b8a4cab4ff601985682f24de1e626d413b70000000000000000000000000000000012b821534f5dbedf5ce509a04667cc1822937e445bf7fae94a45d3fd35a558ca537fc8159fdaa31ba07c62cb5b4a46b9000000000000000000000000000000001618442d9cdeae9f97858a01c3b80d2b7b0eba9d42f1970cc0824831384a8b8a4cab4ff601985682f24de1e626d413b7000000000000000000000000000000000748f096ea2227baee369e15fce3e0bf41e3cd40978d17d61ceafea3c10b9d59cb2c37e911795ce419829d34a4b563f2",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_g1add_p+(-p)",
+	},
+}
+
+// bls12381G1MulTests are the test and benchmark data for the BLS12-381 G1 scalar
+// multiplication precompiled contract.
+var bls12381G1MulTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb7050000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_g1mul_0*p",
+	},
+	{
+		input:    "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb7050000000000000000000000000000000000000000000000000000000000000001",
+		expected: "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb705",
+		name:     "bls_g1mul_1*p",
+	},
+	{
+		input:    "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb70573eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_g1mul_q*p",
+	},
+	{
+		input:    "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb7052e6a8df14ab83a0a91ede99f7490bc5a3e6256770308fa497e7915c7edff60a7",
+		expected: "0000000000000000000000000000000013b81057420a02414f67067b532933286a16a12536b10c4ad2d038e641cf4065efb055bd8da46c6cbf1b7e36e3460cff00000000000000000000000000000000046f8ed357242d1ab0165f3b27f4cbeaa9d11251f7ea43af2a0012943138fcdc48e362bc756638e1426e8f665d909487",
+		name:     "bls_g1mul_(e0)*p",
+	},
+	{
+		input:    "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb7056978c355e5a4fe63bb2edb12a7f58fc4323a8b92b4d8e3c7cebe03218d3a1ec0",
+		expected: "000000000000000000000000000000001997764697edda6dbf9a56cf4539df9d0eda18238a342c038e948889da63271b008441fef38958a932f2b5cf6ae72fdd00000000000000000000000000000000092b1b10352ee48aa4c01973b3ffc6af8ddd2b9e7e69c564b53bc2ee5bc4c36ab836f46c907ecb0766449de3e4b8d6d1",
+		name:     "bls_g1mul_(e1)*p",
+	},
+	{
+		input:    "0000000000000000000000000000000001a0ce7e811ccf0b656e269a7a9a71a74d5ac3cebc1557255eee1ebac719eef96c2aaf33cb3f5c06e40aecd330399447000000000000000000000000000000000b8f46bd879b9a52d24b5c11930352f34560ec277dbc6e2db2f42f245e74db14328be783ed802922f0ab9a4adbdfb705ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		expected: "000000000000000000000000000000000ade939fc5b115e2d73a87b73d365bf5701f229ab7cc26b840e62359f8ef03d2a531a271ccae6102eef266e7d08562a7000000000000000000000000000000001298b4a40023828dd7a07ee9ce8e1ab69531ed8c5fef41c8fd93ff04158ba841d543735caf53c6be5a0cfee904d7793f",
+		name:     "bls_g1mul_(2^256-1)*p",
+	},
+}
+
+// bls12381G1MultiExpTests are the test and benchmark data for the BLS12-381 G1
+// multi exponentiation precompiled contract.
+var bls12381G1MultiExpTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000008dd1d636ad10ed49279f5feb975fab801f9ce8a345d8a7ed8fa764f01cbda889979d15b673376e560718a343dfbf2d300000000000000000000000000000000036f9cd2d02eded90a5fb7f5d87356ab7d643c938ac23e2b18174df1ed6ad49188c0aee91aec120c38227a7b7697524a2cda2dfb7ca33da8d94c524df2d9fee57a40042d8cb6a1384b372abbf9c9ac46",
+		expected: "000000000000000000000000000000001247dc0ca1ef2ca631c35b6098202b9c107eb7bdef487852ee250ef6636288fc0a3c04500074db5c32be721ea3333ba30000000000000000000000000000000000f3565ee255b237a2cd74ad238fe1ed18468ca8c7beae52b81d4324284c99830d6dfc7ab35aefd62d1742577fd2d435",
+		name:     "bls_g1multiexp_1",
+	},
+	{
+		input:    "000000000000000000000000000000000f07691ab7a3145986ae319bb4f9fbd82413652ae22b70bb4efef4f645d5f8775cbeb7d09875bbfa81779d958a833b71000000000000000000000000000000000b4fd3f699a5e7cdea754ced89a7cee6bb093774dfc177dbdcdcbbd687fe03422f1e5f27f912f4f6f17f5373ea120a8340a0d30cc62b8563861841f47cea2b48a83a72fb2f4dd8123e2ab393751d887e000000000000000000000000000000000ba24a40dccbd2476ce7e1c3ade195411ea8b13a2f460f6c81d241088902aaf75343c7b5acb765fbbb7328f352119deb00000000000000000000000000000000058c44ff86af3e8710b5a9bd9cb7dedcf81fd0368bc16f80730d8454d35481d8352ed251316282035994c68fef23da983e4c893880a111ba9c1ef58764ba12cef983f3b5bd92afbfa2e31abf305d835c",
+		expected: "000000000000000000000000000000000571c1e1b8a8dd853cfb34359247f4ae5dc29b2e46610ee7dad8c12a60c91ad41df567fc86b7aae259797bfff2509d2c0000000000000000000000000000000005ba7f223e6a0046ac348c7d4d20d06d01bb2c0f0235dc248a5b44366c3ec1044d03c52ac04d96964e6caf192b156c68",
+		name:     "bls_g1multiexp_2",
+	},
+	{
+		input:    "000000000000000000000000000000001466bd0cdf5395d597b72e2e90483f683d7e84af1bd8a4d01a7104918fe9ef4436ac534b4ad62873a14f156e791c93770000000000000000000000000000000017689a5f119ef046ae00f43670d0e0b82f766bd41cbb9ca45dc4f4d26a86585b0377da877670c76d6c3a449f8d400e775ff0346660b67c08168469585832adb07565505b3263266b5c41f91e44386816000000000000000000000000000000000022315975b8cf452c9f35418f3114df631370e3776da09e69fcdfc8c8b263ed80f1493475e002b20508f0ee3a2e979c000000000000000000000000000000000233095282fc237a9cfa576d03f42b96b2ddff6748546482ecf39fc321d52086df2045f5924bae860d171d836d7f3d97588a9b594bd06abf9c3fe300b899b5a78f40d9f43d3c8fa497cba4c56cfba7280000000000000000000000000000000005ad7fce8c97711168d98bb82af43969543b070d1b784791917582185027266dab34bb8a5f4ee98d065e99a324d653ba000000000000000000000000000000000a94e798311cdeb9de5060825d06540bf79b8a6604b6d431245352fd1be77f0fd6c0b58bda94f2d3b67c5b980aeb3d6559c5ec7e328d946d7ce3369a562f1af4a85858ccc66f2ce94a68b76bc063b1170000000000000000000000000000000018d78c1ad8bd52540b5c3a9a9cf0bc96fadf5cd922ac3b22ba10bbc369a7bc7a89c9d154e0b044ec977151417178ee4d0000000000000000000000000000000007ca767fa4618a7b9aabf3161fdbfe9d49f1bdef7923013a669c0060781a6a992e8051674f00cd99b57a7d0a9d4da5ea60a3b51b36bc5c0f766c40b77e846389b7828f819b122f30d275f29c87277a14",
+		expected: "000000000000000000000000000000000206d11cefb48e2d1a0a5c5fa1602aea497ef5361e6b1384a9283eb59cd17fa496b9f3fc485fde6ddf1d7c5b9602d7d90000000000000000000000000000000015edd8228261666cf71c34710d975a9828810ca561271cd9ce92df13f508f223d19f4eaa4c49a5a32b365ce9e8128383",
+		name:     "bls_g1multiexp_4",
+	},
+	{
+		input:    "000000000000000000000000000000000e1ed58c5cdd7e8c65b2c05a1cec3253b93e0fd1966238e65417cfe3341d1e1dd8f4d840bdc83be06577a41c72362a9000000000000000000000000000000000049e6cd648ff34ac833d71472ab02f6d3abd1650ff0c78d1bd002d4c2b7f2441aec4163ae2ef4286a6ce49fdb67a43834df7c5adf8976aa1e2558382625f8fdffb94080929a4aa9dc552e7632b90811000000000000000000000000000000000152252db9b2dfba4f90e52f330356d807576127a822ddd6c7ab440baa6e19735f9f39e92ef4a7b74968c2e4b99ee642b000000000000000000000000000000000758a959c317326ad6f4e443aee03eeb7c057f71d4a1769bbf6feebbfff094d84decbdf128bb6bf73ade746d3a2d7791536b9c49e8ed584e2afec265304cfa6dab6b6d02d6def8a1243ac95448043a09000000000000000000000000000000000ddaeacb7e00b047c81825fd02f68dfab2463c854b503dad8d24196aa7e5ea1b3ab4843b4f69fda8e032bfce007829360000000000000000000000000000000004276f0d19daf0dbb684d964d2e1c7c6cfd3cc92bdce9027b4ec91ed557c0bea530c936f2dc3f6d0ffbdffeead58598a5a0738ccfc7fc0e5a8129398828a8c03fb16f3f826d761a3c62a274b71ab38ac000000000000000000000000000000000cced6e9f8cebaa3fe75e641e604953b06cf74eccc86b857ae72bed22e2f915d3d2d2d6d37b66f13cb6fbc9b7a77eb2e00000000000000000000000000000000106f5642e914fed5e0d05982cf5402a3b9fde4e6d3256d49f60e61b7dab564bdddd0d8e91002e301f5cb0afcfb1ce4d10f6bc4cd2786d1470175658a37d0b3986839c49170c4c1a85ba75e016f340f45000000000000000000000000000000000dca0d8dd17b15b53d4bb0424df01e782e87566648422bfe1008bf5b3047dc81d40b2082b6bd0cd4b83a25dc479086210000000000000000000000000000000016bc9f4e48c259c8e47cef8a6e66b211c0a5372bc0758137158db4dc957892a2b30b3651a302c7aa505adc6a4d0d45824adea2b1c734e329b2e9ea3346a2a9da895e5b7a00b12bd1a5826700b2eeba5b0000000000000000000000000000000015b3367d956703d1097d814ee3481bd6eb5302d268bf776fd759f2aa5a3f679586da382761a6d421bbaabce79bc26e700000000000000000000000000000000002009a24457673cbe28a5fb71169c6e19772bdf4ac137f68d34a3c1b72cfeb5b194685dcd27e92dfe398274a9b24462253420f1a6eb2f66e48bb59485e831ee16a318e2f9fc68f70c088bfc5e59228ac000000000000000000000000000000001374710380996ebccb0fb0298c07218b1d5aa2e98b6d4c613251ee6eae49a7a0e882259772710d8cd06d3ef3b3eef9b300000000000000000000000000000000077479425ac65c37fe23911ffc03a4802033b003644c42c6bac49fb152cbb2b11e39c7042c64c184d58018947feccb1a6e61a0df3a089fbe7277ad89a995b8e324025cb93d5891f0fbcc684e001a464700000000000000000000000000000000174aed5ab0bed20a4215feacf22ca93c38a1de26efbdd98af49b36c3870a767d69fa8c395289e8d363305bab6f04a2ba0000000000000000000000000000000014ffad973ee634d248e8a39661cb1557b9203da3f5ebd82c848208d574ffb411d7cd3e76c841515bd8f0ab7e99c27e45105f736b444ce9c1e88f534c7769f6709b996d548cdab2fbf075493a4322be25",
+		expected: "0000000000000000000000000000000018bd6e8cc3d6e0c94867de580cc5d6f1c27eaa95e7411d8eeaeaab9af4dc90a9e64f70b8bbd027b380c30dc32b17c9660000000000000000000000000000000016bf8d64789e070e7ead998cab7f358283a964dfa79a0f16552bbaaea1cbbb5d04e2511cf9222ee05ac99515f696859e",
+		name:     "bls_g1multiexp_8",
+	},
+}
+
+// bls12381G2AddTests are the test and benchmark data for the BLS12-381 G2 point
+// addition precompiled contract.
+var bls12381G2AddTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "000000000000000000000000000000001638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053000000000000000000000000000000000a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c33577000000000000000000000000000000000468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899000000000000000000000000000000000f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf3",
+		name:     "bls_g2add_g2+g2",
+	},
+	{
+		input:    "00000000000000000000000000000000029e8e56dff51bc3106f8150d0deb936db397a103cea31188f772b679691aca79bd2dcb3603f509365a2911aa56c15ad000000000000000000000000000000000b879a33c196645d43ff776f926ba3cce698ce5024e49053431047d7963bda723caa2d77c06aad12a9548d1a033afc94000000000000000000000000000000000e230150128571348a70332fadfc7bc53882da35459dde3606f30af4662184f299541cd5a3bb26f7c9d45f387340ffec000000000000000000000000000000001930e4e488176ef4d0557557769c62d92c0c34e773c162972ecf4d980ee7f2dfdbdca5d12876d2b8a4744da05d1c060a0000000000000000000000000000000019847367b36eb27665abcc16c485ff32458290100b2d72719f4afbdfdfc2f2c252eef30fd90d43985695e767a1f42932000000000000000000000000000000001076b099aeaf9e7c814eae5b0f1fb62657f06aac1620da5c47986bbddd992497bc125a820cad31c75a15aa5d1a20479700000000000000000000000000000000143d31183208b3b7e6cc1914fcf51e1588bdbd84bbccbc5fdd18c9bdec884a09e4bbfe06e29ca91d5158438682fb1d600000000000000000000000000000000008f7d1d0e3d9be9d8e7f56a192981cf0c890cd1889ba513f9dcf775b13fee0b69d02f474b03d2a76cfa4975e41642bda",
+		expected: "0000000000000000000000000000000013ff211ad2e1b2ce6eff36d3a548842eb62029aa4769f047cca92e8bec54f65efe57dcad313fa2f0589749095e48268c000000000000000000000000000000000ec30aecd185f537fff2053aed1702ac6653864fdc0502e906b45cb68f3eea639766b53a974405b8c32f67639e064fbf0000000000000000000000000000000004a56763f0121709d7d7352d5c615dc4131c030431c7b8274e40b0fe08a6a6185e54e75459f24a15a7d964beccd3cb75000000000000000000000000000000000d6b98aa05f3a9cc74d721997436d593c09efbc2148a446ddac4e0a1733be7d78cacb6e5b710849a824fa26c64184287",
+		name:     "bls_g2add_(p0)+(q0)",
+	},
+	{
+		input:    "000000000000000000000000000000000655f652946fbec41e5b3c4f73f2f60de368830f6f20d40171a9afe0a44ce64d4faa71009b58a3ab49625812fc1ae7c100000000000000000000000000000000054c981f2f097d76fca926e3f6612c00d41e95b3ad9afb34affa7210b40abfc8826697d2d5f00772c54806c3f65e567d00000000000000000000000000000000166b257ebee9c5755b4e3c792109d48cc57b2c497c687790b991a43bbe09a56a7593d9b85717794b13fb7bd08875bb8700000000000000000000000000000000154a58f097940817454b0516c9295219d7c2a6d3391653f93b86d07ce918dded7ed4483f2d43c13028198cfd5beec62600000000000000000000000000000000154eac5337c2b8cde6d4cafda44921f7700f70ddfdac11bac74a3e8a45c5358adf6edefdba8685ea34dff33577d3f5de0000000000000000000000000000000008fa2ebe21ed57814040b160cbad5f4496a6dd6fe85bae1a4065dd140121f81604ad3b535e724c4db34d1aa0aa31660e000000000000000000000000000000000c37acb679f68e28ac77188ee87b2bc1fd67b1053d8f937fe82dc4425babbaf18d7232d91807d8c46ee353bc3fa7dcf40000000000000000000000000000000011e9dc693adf35664cdd63f18ece2d8222adcb5d19aea570c98aa85b2bcf4abad3b3539eb7a342132662a15b522aba18",
+		expected: "0000000000000000000000000000000007962337ca35af18184b9517e00fe5b33691c94849aef88a381b164ec9d1079509591b7c69129db24304dcbda0ea02580000000000000000000000000000000016874cc71378feef07e4a60e220c4549d1edd70c0b440b3908945fa054cf5880c67c7ff0648b3aa304885703d169744b000000000000000000000000000000000d42e6a4288ad67f5f0ca5cb0016448f204564e895382704d7a400e3733f0ba8fc8c3cba9e02e86d4132bf183344ccc700000000000000000000000000000000181ab3620e8765f72dda63a9cd2c62d56f2d11a3b72d6d852e93352677de52bf2a54ee9f1214c62640808a407e995ee6",
+		name:     "bls_g2add_(p1)+(q1)",
+	},
+	{
+		input:    "000000000000000000000000000000000886225782af81b3b88a7fccdb465da20975e81c9a5cb296ffdb4fecef9998e4c049f265822ac5fa4368c3837ae16dca0000000000000000000000000000000010eb95328c8960c60bc8eb7c5d686886efa09cf19ba6130d3d609898dea49f7830828b2b17f5d97fb5d6114d8413a72f00000000000000000000000000000000168de020c7f5f1ddd7adb7e890e7a924b55ebbf3db91494e26daa0fa8dccbba2981bb22a657390585822d4061a9bf7230000000000000000000000000000000002b7b8266f5f891be051ac58807b520f0a31405c34f889b0d385b22d70268d92924b0b30b05087b964c1deb8a7577b9600000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "000000000000000000000000000000000886225782af81b3b88a7fccdb465da20975e81c9a5cb296ffdb4fecef9998e4c049f265822ac5fa4368c3837ae16dca0000000000000000000000000000000010eb95328c8960c60bc8eb7c5d686886efa09cf19ba6130d3d609898dea49f7830828b2b17f5d97fb5d6114d8413a72f00000000000000000000000000000000168de020c7f5f1ddd7adb7e890e7a924b55ebbf3db91494e26daa0fa8dccbba2981bb22a657390585822d4061a9bf7230000000000000000000000000000000002b7b8266f5f891be051ac58807b520f0a31405c34f889b0d385b22d70268d92924b0b30b05087b964c1deb8a7577b96",
+		name:     "bls_g2add_p+0",
+	},
+	{
+		input:    "000000000000000000000000000000000886225782af81b3b88a7fccdb465da20975e81c9a5cb296ffdb4fecef9998e4c049f265822ac5fa4368c3837ae16dca0000000000000000000000000000000010eb95328c8960c60bc8eb7c5d686886efa09cf19ba6130d3d609898dea49f7830828b2b17f5d97fb5d6114d8413a72f00000000000000000000000000000000168de020c7f5f1ddd7adb7e890e7a924b55ebbf3db91494e26daa0fa8dccbba2981bb22a657390585822d4061a9bf7230000000000000000000000000000000002b7b8266f5f891be051ac58807b520f0a31405c34f889b0d385b22d70268d92924b0b30b05087b964c1deb8a7577b96000000000000000000000000000000000886225782af81b3b88a7fccdb465da20975e81c9a5cb296ffdb4fecef9998e4c049f265822ac5fa4368c3837ae16dca0000000000000000000000000000000010eb95328c8960c60bc8eb7c5d686886efa09cf19ba6130d3d609898dea49f7830828b2b17f5d97fb5d6114d8413a72f00000000000000000000000000000000037331c97189f4bc736defcdb26403b2af188f9117f3c971405631a668e43a8186904dd44be06fa761dc2bf9e563b38800000000000000000000000000000000174959c3ca205d7e6ac9fb5dc2d05ac85a460b28be8c890e93ab2073868a68918c60f4ce01037846553d214758a82f15",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_g2add_p+(-p)",
+	},
+}
+
+// bls12381G2MulTests are the test and benchmark data for the BLS12-381 G2 scalar
+// multiplication precompiled contract.
+var bls12381G2MulTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f8178160000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_g2mul_0*p",
+	},
+	{
+		input:    "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f8178160000000000000000000000000000000000000000000000000000000000000001",
+		expected: "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f817816",
+		name:     "bls_g2mul_1*p",
+	},
+	{
+		input:    "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f81781673eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_g2mul_q*p",
+	},
+	{
+		input:    "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f8178162951534bc86adac28c7d96b47a6e4209405c025b067ffe2e397ae36cd3d99c85",
+		expected: "00000000000000000000000000000000104e80abd19e9ae9487ae659de6e3d122b094590ed584ba43b888edf3319406cb77c35e6b9da4f3e1b2e695770743a5000000000000000000000000000000000073b1d4df315abbfacd7ad492d6374b19e2b383cdd2b251ba12a996916013c94fb270a2974eb6827b90585221f2cd24f00000000000000000000000000000000018d4f7b23155013699246cc6f417b5b0bfd30d6b61ed0ddd244ce405805661ab13cb234be743c6ad8390debfae037c5000000000000000000000000000000000591e80804efa1e9f694926ecf70e2cbe5fbcf9623c289b75e7a4cd59c3627852ee892258b9c7b96d5ef981ee3efb277",
+		name:     "bls_g2mul_(e0)*p",
+	},
+	{
+		input:    "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f8178160567aae02b19f0c79d6539f2c9cf08dfcec2d12d397241d19dc9ba894fcd1908",
+		expected: "000000000000000000000000000000000a9e48b426482910cf28d5529a12d1bb4fa4d3b5e11f4fe28d0f048acdde528d7d04229f0e10f60a949aede275b8de910000000000000000000000000000000014013ae5cfe8ada46b50c79f4d5c2560ca9e649af9624e9547810f166578399c150a84d45977c5dd1dfa3bec9e562835000000000000000000000000000000000c923ba3e38fb44fcbc1edaeabbfedfda3c66b5eb38efcd58bb412cf7654542c998382e5765f6578534c58cd5852f7420000000000000000000000000000000008f8e7740a3be905a0da58169f88bdef63fc61f2cc245d23200e1d31536e830308e0275ba3eed46468f41004defb5e06",
+		name:     "bls_g2mul_(e1)*p",
+	},
+	{
+		input:    "00000000000000000000000000000000015ac695b9c4bea11981805a3aea441a2f01c2efc88580b1367fb671710d28ea65d78cdf32fbd2ba3da11717bf785037000000000000000000000000000000000833e6afc7307a836a784563694c826faaa73e21abe811d02b88d2216c278b52fca5632468f3e8a7d1619400ddddb5980000000000000000000000000000000016e0a97a9938a60c1fa7b292af9b14471efd3a96d5009bea1c33dc0be9b77573843bf58b5f67be97996cbeb3fb2139750000000000000000000000000000000013044c6b3faf7dbcaf2811169b0ced54ee0a75522ec235b48bfc6eefe75bd38bf2df104e9cbf511364e819e87f817816ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		expected: "0000000000000000000000000000000005d157b57ff0af6c8dd41c0596b08794d325cf81f06ca3774adea9958f616cdff87414c03b623e2c440aacb6fb57c2d5000000000000000000000000000000000b5b83bd9375215c8d899064761f155d0f455ef55e72d7b2de6383ddf91d45c3e75cfc16a8246d9f7c42567b8f30729300000000000000000000000000000000159365f314eeacc0c4b1061fcfa89f7a9f4037682799a523f855486389f77478ab1ba39b69c3ba318d1cf979bd08d9d5000000000000000000000000000000000c852f98b0b34b36c60ff47983de2e1320e44cef2130aef76bca823118379f60da805e22b9132d5f5c0f468123f6db63",
+		name:     "bls_g2mul_(2^256-1)*p",
+	},
+}
+
+// bls12381G2MultiExpTests are the test and benchmark data for the BLS12-381 G2
+// multi exponentiation precompiled contract.
+var bls12381G2MultiExpTests = []precompiledTest{
+	{
+		input:    "000000000000000000000000000000001465bc1f9c35dd798b49b01c895d6fefd79c7c0070e29247d1a85ccbc6df2af21e2c9a6d07399204f02e5cff67ce76f800000000000000000000000000000000026efe73c49169602860cccde76d37398024d6caff80645f885295fdbced3438a96c35b32fbed37121e434e7ee5f1e3f000000000000000000000000000000001188e4f91e6cdae17acce93cc756ae7e068188e19bf4d71b354fb128e40bc578219ce6f232d604e7d8d636a1d4203431000000000000000000000000000000000b4dce73d0af102c2f1a4f49ddd7f093190b2c1950f58e1999a35441c07e9f7fc70613d782fd9e6446cf5c0825ec11dc11901b4bc59dbf47e5e79abe378fe3f677bf5dcc43cac090901af8f304b7b52a",
+		expected: "0000000000000000000000000000000007f115085145f40fc6518d988bf2723e431715641dc0d0b5a2210ae2257ca59e485cb8c7adc09ff3d5de8c382b345c23000000000000000000000000000000000f04d2dbe0cf7660d5b0fb507a3565c89357f19d1c7c1ccc84104993127ec5ac693bfc2c6d5f5c48a20ae0f4d2b255e0000000000000000000000000000000000bb9461d3ea9a705b2e6c62f3fe918179ffe0576bf8aca411b92188920c5b56e49e8b3b5cedaa8ee708272a7c6cbbb6e00000000000000000000000000000000162f27973a5af9fc404b1fb24d35e5eeec867b0ed72b995bd110fd20c17ca06f2814cacf17dad9747b98fb2ac301bf7c",
+		name:     "bls_g2multiexp_1",
+	},
+	{
+		input:    "000000000000000000000000000000000919ed033844a53f614bdef4b7e147ecce8bb5c6dee8e190cbd1816dc512d6c5358f654eba86f1171f15db21517d2f18000000000000000000000000000000001162af94544b947d8f7ceb7d99a1fe3249d04bf695b5ebe99cb2a73c9d9787e58b96a22d60a61f7582d5f4f34e953e640000000000000000000000000000000019d9d3aa4709ed6527bdc9914ae659e5be3cf187cd0f3c069c7eff4324b374a366a8e25676dcf9a4b3362513658e8b4a000000000000000000000000000000001489c18a3e6253d39581a2d06e92cb2385bcdc6ccfc3de16d0bf01b7bf3d0d1675eddff10bf710874d6931e05ae64cc15c7b2ff07a046254bb3eb55199601ab4e4b9a72c5345bfd41ec8fccb9cfa8316000000000000000000000000000000000446c4c34fcef933bd92f8379daede72ba9d55bf7cff3fa48f900b6f19ce87ba75a9635c92b3a89bf1b6cbceb4d55409000000000000000000000000000000001683d7bdc0e4c42805845a022cc1770c7180ba25acd5afd813c222b35e684f62b5d20917767b07ab6fb6abee9b6378cd00000000000000000000000000000000117941f686443c271345ce348b14fd00b313838bcaf573287da587b74e44f0bff41dd703087b5408402e0f75aaf4165c000000000000000000000000000000000cf4b9c3312bfa8b733580b84edff5be7d503ee5b1cda08d159a7155051e9878e0b3ce67658b2c1df35b062d4d6c2eab091cebd76397d547a81ec92ef78dce9a9aeb6a62bd06884d81d1fe9333026fe3",
+		expected: "0000000000000000000000000000000007f3d9352fbbd09c9ffd7a7cbc96404ed2ea83d6a1a484fd977a4d9900e0e3cbf54f9c884042b35db4947c605389596e0000000000000000000000000000000015a7c00b3a22af3fe23a242f9244e29b082d72dfaffd6b8f772ab2844178881bf1c497c573a6f1f9a8857023a418db1d00000000000000000000000000000000072d3259da9b2eb41457d718394bd2f04648553240552bf4300c4b452c9a4595f3850f2c8b2883b35dae44e83b8211ba0000000000000000000000000000000009071194ceb35cf044053f3a69e664dc753b31a5e956d5627e1800f4c3c748334df24903e252d76d59ec4654d4a7c290",
+		name:     "bls_g2multiexp_2",
+	},
+	{
+		input:    "00000000000000000000000000000000185ba579839ce1b1e19b99d6a732cf2a45b829a52f889e3b013ca867311274d83a293e79aa0a95b35dee387075e0f53500000000000000000000000000000000045dc1887d7e5cac8ad27ec33468613d7e3467f24d078f0a9ea78ec615bde21b9e1de4010c8644f4d785152da01c5d020000000000000000000000000000000002338a5a290e06f0cb4c15122b408951cf3cc778dd94791416b83d22dc421b867c5b5ee916b2faa5ee8f71e94004124600000000000000000000000000000000012414c2084b5762ec6f2dc9352663774479ded59f1ffcb3d7dd00083020af238219c09fee1228f06587d5f673807f1d2a0674c75bf0474253ff4876455ca4d320b726736f071c31c89d1b22e2a25f8c0000000000000000000000000000000016f428af4030e697ef62c6b27a8e6fe6e231ad4d6a8e191e3f5f37e76cd9043b1a829ba8e0328ef0217f24702b0660840000000000000000000000000000000015c9f34cd75b1039c3248d67af2a31550a5e206d851f8aa8d46ae9a26938178bc1e8d4b4841be373f39993d795fa1bf50000000000000000000000000000000013cb2c60abeaf861ea4addd4bfe7e37dc4a475599d39a3a0af0b367311a34bed6ae1047662c170428f26b0627801e6930000000000000000000000000000000000b9cda4221cf446a538bb6a7fa0d00a694ba3bc2e5e2bf0f8b90067fd6255e49b62129d20eeb4cac08634331b4dedd24536d659c7a721569e404e214646bce1c5ad6d739b9d2f4e85f450a2535c62260000000000000000000000000000000005bcfbb220b7a9d960f9c5e9d244550baea15b1689d98027ae7a19a24690192f2fbe8b5559db486fb66085cc1a5e0bcf0000000000000000000000000000000012de57555cdd7e98eef21b97f4cfa807a530f97b6c191b818a954b4354fd55320ffc1d612b1a7bb5822e144c89aa88a600000000000000000000000000000000194a47bfd5a0335c851a7766a0b5cc8c291aee0e94751318e68a4d2377842a9c5efdbe23af18c182735f68816e3703080000000000000000000000000000000012aa3bb3e671407988a6afba358db3142bd09a905573c68d5c356c8b76ccc993c357fe3e2ded5e532036c77a4c5ffc6b37115318fd1a539974408aa139708374ab015b5ff9b861b2dc3a63c8188714c4000000000000000000000000000000001470a866d9417162688ab87bb92c0569640025e19f6e6a8903d17aab797dbcdc5bdefec81a823ef9b0e224f1f877ab870000000000000000000000000000000008a581df7ebf1f3b5f032f498249d273fabfa262d5b03df60aa0340b51a1ff29bff200211aa5c5fc2f7f8058381aa130000000000000000000000000000000000f69a523c2456aa8f4aad1d37c8ae4d5568dc20df4de6fb5bba464f2d1c82333f4cee81001cf338ca1c4f7302312ef4a000000000000000000000000000000000d8acf8bb0081d8a5d070582dda6946b1c03dfb5b3573e8897f04be37b576cce03dfe022686ed9255b8fabddd3daffb41170161bed48e622555a9168a1ffb7a6fa9e1ead45fbf30263d980f2d4f0b4b2",
+		expected: "000000000000000000000000000000000724657c6b3c0948bac380fc2d3692fcaeb362d6d86fd08f2b02524cf51af022c9ec3054487a2217300cfdcac2825cfa00000000000000000000000000000000079a26ea328c5f0f09ae6c5eee2c31d8b6eece5299a9ba533f567b64aceef185aa277676d81fd1285b63dac9493cc0d300000000000000000000000000000000089257eee52ba3a62bcb2f8ee84863083621df968b0f97fbe29987e77bb895f6ccc520705580ba0e62f51153b5a6abf400000000000000000000000000000000116a4b5b203fc62c488974321281f02b1f1dea63e2ecfbd7bf11b5a655314d878dbfa8f67707f4794e379175177e6eba",
+		name:     "bls_g2multiexp_4",
+	},
+	{
+		input:    "000000000000000000000000000000001577553ba95a7397e4d9c79cf92802a20e9f9ce2f1997666fe69b9818a6bd751ca989394590c95f64bd16ba4d274fc8800000000000000000000000000000000119e4fffd3ebb09bb369318538726fa786f2b4ff7e4ecc59195be18517aaccf1e1fef6ec43bc59b9151a0ca1cda1c6fa0000000000000000000000000000000001bbda0e7335468df2dac1670000ff9823ddd0eff9d58c587745289bdb0c4f20ae5107c495eab98d15133d6ab891642f0000000000000000000000000000000006335adb52c995a8e8a56785ecd9f4434eba57856394f1e6630280f248e03b2c0693557c479a95b382a02045062ec9b50a5de2d991c94555658130e580d98937086655285620a930a662cd581fae6c560000000000000000000000000000000007ebdb06858a0bfe0dbe5cd40b3d04f76d6a63605fda9eef3c7191b6e7a78079d792303ee6d79a0fcfda4b182259612e000000000000000000000000000000000ad1de95d1dc1989e387836c7f9beeba052ab73f273269a3cc6612ad515e88d07ed0369217813152b598ba70886920f7000000000000000000000000000000000b6d2e3d19f9200cd8019311898b371d3842d47d7084e7a9f754cbc8e8f679457a9b28ba8e1c5ea1df80a9a3df2682df0000000000000000000000000000000003f390deaa126862e40b033e5b74487c480843ca67eb35f0b6d357bc1d47c8a7bb4652777af90ad32aa1fd5e04135dc65bf4bf4d0245999a7880fdd170682e8fc04ee47d2887fcb2615662d665f502cd0000000000000000000000000000000014523a11aa1039339976b14ed87402667e15a583c4c507f408fcfea390701c5681532e49e04d5de9328e6ce8e637214800000000000000000000000000000000191679376cdf0f4cd6146366553bfa326fe844f37e71b2622dcf845a024a04b4c82cc706e9801bfc6ba07df55c6f261a000000000000000000000000000000000284a57d51e04ae3ec62b18421addb35718f47c851061b646e874dcb73e2e6b7bfb1fe72e1d57e9aacb6d1c259e5b31100000000000000000000000000000000129618ce0ec1d2aa8333868ed34f76f912ec8c1b6bece7bb99f46dd2888704d130d4cf27f5c5a609eb7be192a122bc1a4338d8d1c72233eea31fffaa9fd8ac2a1e9bb9fe4bffefea091fda02c0e632920000000000000000000000000000000013cbf7643252906266a402b7fa4f82b24e0200227beca9f92a3be4d26df57030fa92b65578f253ca66539254830816820000000000000000000000000000000001bbd7592a86fe1f36382c8626f846bbeb9d9c6345c492fe4fff66b59bf04557d32c11e4c3542867421d59eeb0ad279400000000000000000000000000000000078f8d089195a00d1da985f5bbb704a9b3d45fe63e0847c22421a7179ff415757ece22bf6ab0d30810442fc908e85d370000000000000000000000000000000000980c109cfb10cf4cc1202c5547f949b92ff17acee7ba3d444062b43c54e3628c19ef5bc2e08b25ea1cbaab37c60c476b16ed4255fcc6619cd57b4953fac0ac99b18df7973f420081daf0e92311a59e0000000000000000000000000000000015a4dd2d047193d7cacc1b3ca8b0d8ab858fd090143b4ba518d950ffec5a8f735a267857443a01df32c25b87dae629770000000000000000000000000000000001adededabfeaa51c63211e54347d1c29741cf369cb6971710b1c8b682fe70ab52cba7cba6d8eb33ee9cb8695c03dc44000000000000000000000000000000001488545f29973d406edf4e6e8e693a689eaf1eaf035d0f1b6324ec81d9e8e603f278a9daa641d6ae92bf2e624810fe50000000000000000000000000000000000c46dd0ad8df943d9c98e38306ccac48224d44a0235ef0df19ed8e1fb1ed1bfce5f68c13119eadd29d201767933a17a044a35f715e68e6dc38ea72ffb350eeba991008c171b62b92eaf333047ad7699f000000000000000000000000000000000864ecddea051a6751a7c672da2dbb3e9574fbb7cff052b966c1d43367b9da0ac255f921d14847d9837937116545a56a0000000000000000000000000000000012790298e5531b846ca5e9c27ef5aa4718d42abc920637138202660c8fd0e5efe0bee997ebaa67e45d7582630a72d8e3000000000000000000000000000000001763965cbe27a3cd7287063335b436b9a2cf45348e77d276ed0e9445bf9f0702ed93c889155922960d2a61d4c03de2a1000000000000000000000000000000000bc02b7451d6077e74ed0596672629a11f46db782d3cabdf0fc1ffdcd98402bfe7560d371b7f365804ab1b1dcb8273391be54e548be46675401fd6e91fa7b2132370d0964e7626d217d76584686a4022000000000000000000000000000000001764e309b3546521212d3714c9522f31661e48287ce0219c22367ee877705251ffd66b41a379ac5a015fc9459d36d0e60000000000000000000000000000000013ef44d2f683c343bca17b1e86d52bca53fea6941d517a19e83befbe1298b56a66f10ca402841aa034cf6fb3a3d0282600000000000000000000000000000000191ee9493d60729c86fdeb2574e86add5173de8bcf6682215ded3499c2973a66bae10bc72fb8c1ad9f2554781cba3225000000000000000000000000000000001651c6f06295a5615c932444232684579dedbe4884d5388d18511fce47eb8377473a873453ef5aa4a3f3fd9129b0b9bf2344aaf04ee915b826cf4ca0711cc747aa0b34fea97e671e0d34c188ac49c7e000000000000000000000000000000000102c5786bfaf5c76ba8f3dd6d7bc5696deaca6859a46987008495d66f8a669207a8b6028b948d7ea1d484346f7c693eb0000000000000000000000000000000013d7671c62b55d42dddbfe3488294ea472307cf70764b072d175ab3e4dbb34da3aff0ad06e9329153f68bab924eaad4000000000000000000000000000000000141fa4937ef9e1660a183a04f06d55f5e5403b585478e345a4e9b95be7ea5616d1a9fc05b5f4788e0004c7302df179f50000000000000000000000000000000012d2ca841d53d460e5698c4559d7cf12d18bf33604ccfc6687fc7ef2622a372323106d116670f5cd37068c5443a04cb902ac36dbc1608fe8a5f8c7bc1ea297a1f82c662aba621ae4b91a97b7d826de69",
+		expected: "000000000000000000000000000000001023b984d35fe787005af3ce7b6454968fece8fb6312192f103e6f373f4901431f708f0d533d5bc69618a3cd57a73ee20000000000000000000000000000000013705da643960696ef86fbaccc9c88f6f90d288557ff86adc7762adc62fb7744b8ad9b072af0bbdd26deaa913080867000000000000000000000000000000000095dbefc6d035ca5805d4a5a4b1a144630ccb10717c431af9962d66fbba207dfab1d9c7499a5032a577c33b9e56cece10000000000000000000000000000000017411d6d87bee1b3bf9ffd1e812fd08433017047dd372472c2f3e5d9802c0e8389f7cd4fc6ef54fb54dd80f42ff95336",
+		name:     "bls_g2multiexp_8",
+	},
+}
+
+// bls12381PairingTests are the test and benchmark data for the BLS12-381 pairing
+// check precompiled contract.
+var bls12381PairingTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_pairing_e(g1,g2)",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb00000000000000000000000000000000114d1d6855d545a8aa7d76c8cf2e21f267816aef1db507c96655b9d5caac42364e6f38ba0ecb751bad54dcd6b939c2ca00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bls_pairing_e(g1,g2)*e(-g1,g2)",
+	},
+	{
+		input:    "0000000000000000000000000000000007fc6cc9bc49bbeec080f7fb7729e90594bfed873278d9a3fae24cf3e7b90e0fe5f443ef96d3e73e6d82f4d09af31b6200000000000000000000000000000000140dc7f7d7d2420be5bc6026a65afb5f34a8addc6d4d4ecd21bd1575de0ae183f0834000c27f2cac46cd189e2116832300000000000000000000000000000000144219c984c10d1cd488a7069b6d3d63d4abdfa212d28236085d0f6745fbc618d91499de9bae57f4778f5a64905e54a7000000000000000000000000000000000df68071311606155d73fc90b2ebaf2c8108909e57917932906ea2a07ca2eda3b8f09d5fc343ea2c629411f5ab392d51000000000000000000000000000000001807fa0c1c2bf57f3a71b7cb919b5a8f01c2239ebec3af12292de3cda2cccbba26981fbf5eb06d538bb39b92c86bb9110000000000000000000000000000000003eabb969714731a842f1d81f7295e41ed327ead80f32f5a1bd17918f516a770d9aabdf1684ccd4ea9b095ee5e7867eb0000000000000000000000000000000000f55ecd608d553f6a4be46866123de4a190c0b2ebae90de45b4f85b21104c09ece08880c9ce3052c758dcee5e6658880000000000000000000000000000000004f647163e035849d2c6eb0d8b5f588dbdad4f0f2cfa354e47e91301120da539ff85cfc520559d91653620471bd7d55100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bls_pairing_e(a*g1,b*g2)*e(-ab*g1,g2)",
+	},
+	{
+		input:    "0000000000000000000000000000000007fc6cc9bc49bbeec080f7fb7729e90594bfed873278d9a3fae24cf3e7b90e0fe5f443ef96d3e73e6d82f4d09af31b6200000000000000000000000000000000140dc7f7d7d2420be5bc6026a65afb5f34a8addc6d4d4ecd21bd1575de0ae183f0834000c27f2cac46cd189e2116832300000000000000000000000000000000144219c984c10d1cd488a7069b6d3d63d4abdfa212d28236085d0f6745fbc618d91499de9bae57f4778f5a64905e54a7000000000000000000000000000000000df68071311606155d73fc90b2ebaf2c8108909e57917932906ea2a07ca2eda3b8f09d5fc343ea2c629411f5ab392d51000000000000000000000000000000001807fa0c1c2bf57f3a71b7cb919b5a8f01c2239ebec3af12292de3cda2cccbba26981fbf5eb06d538bb39b92c86bb9110000000000000000000000000000000003eabb969714731a842f1d81f7295e41ed327ead80f32f5a1bd17918f516a770d9aabdf1684ccd4ea9b095ee5e7867eb0000000000000000000000000000000007fc6cc9bc49bbeec080f7fb7729e90594bfed873278d9a3fae24cf3e7b90e0fe5f443ef96d3e73e6d82f4d09af31b620000000000000000000000000000000005f349f261ada48e655f478f9cf0b1782fce9da88637c3f24573bd2b18a614a02e28bffdeed4d3537331e761dee9278800000000000000000000000000000000144219c984c10d1cd488a7069b6d3d63d4abdfa212d28236085d0f6745fbc618d91499de9bae57f4778f5a64905e54a7000000000000000000000000000000000df68071311606155d73fc90b2ebaf2c8108909e57917932906ea2a07ca2eda3b8f09d5fc343ea2c629411f5ab392d51000000000000000000000000000000001807fa0c1c2bf57f3a71b7cb919b5a8f01c2239ebec3af12292de3cda2cccbba26981fbf5eb06d538bb39b92c86bb9110000000000000000000000000000000003eabb969714731a842f1d81f7295e41ed327ead80f32f5a1bd17918f516a770d9aabdf1684ccd4ea9b095ee5e7867eb000000000000000000000000000000000ee566ba115e0eb7ae389d1b98bb1fe129f978965fe99ec261120834a4761db9ea00b49c2e799ea9e0efdc72101973020000000000000000000000000000000018a4cf6301ef8816dac3988d24260b8546bdc58f265964523ba32746167458ad855cfd0eccb6ac6637dc2b930e25ecc1000000000000000000000000000000000f70796ee9ee8ae6fdf70363cccc387c59320b9de8735fab21e7ad2ca9b86a64a5b42d6039c4009737d07c2b2ad6434d0000000000000000000000000000000002fba898d3c40019a6d0f2e31739ea577a4521a44e3f7195d7b0b61daed6471fcb4c78c420c61f9024781b7ac83422a20000000000000000000000000000000003d20a5c0835110a093d6e5c03149d2ab09ae2e4f188d6643514e8e37eca642db7366495f7afa2efb9bacc9481d31d6500000000000000000000000000000000012cf20f9ea6dc5e9c3d2c90eddc318337184c9e31fe3935a477fca1ce4379a8981869097e57bf95cfea6709f7bc79df000000000000000000000000000000000b236c2549419c0afafdc5381de924ccdfd76509a7b406a731c7f0a02efba28fb353776b1cd19ab02f04cfd3b6f6c420000000000000000000000000000000000cf554a0b4ebcff6014b06ca96f14d2e8747828dacb0ba65b33055bd8bf7e3ca7ec37af68fc8d3dc75f671e0b47a411c00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bls_pairing_4pairs_true",
+	},
+	{
+		input:    "0000000000000000000000000000000007fc6cc9bc49bbeec080f7fb7729e90594bfed873278d9a3fae24cf3e7b90e0fe5f443ef96d3e73e6d82f4d09af31b6200000000000000000000000000000000140dc7f7d7d2420be5bc6026a65afb5f34a8addc6d4d4ecd21bd1575de0ae183f0834000c27f2cac46cd189e2116832300000000000000000000000000000000144219c984c10d1cd488a7069b6d3d63d4abdfa212d28236085d0f6745fbc618d91499de9bae57f4778f5a64905e54a7000000000000000000000000000000000df68071311606155d73fc90b2ebaf2c8108909e57917932906ea2a07ca2eda3b8f09d5fc343ea2c629411f5ab392d51000000000000000000000000000000001807fa0c1c2bf57f3a71b7cb919b5a8f01c2239ebec3af12292de3cda2cccbba26981fbf5eb06d538bb39b92c86bb9110000000000000000000000000000000003eabb969714731a842f1d81f7295e41ed327ead80f32f5a1bd17918f516a770d9aabdf1684ccd4ea9b095ee5e7867eb0000000000000000000000000000000007fc6cc9bc49bbeec080f7fb7729e90594bfed873278d9a3fae24cf3e7b90e0fe5f443ef96d3e73e6d82f4d09af31b620000000000000000000000000000000005f349f261ada48e655f478f9cf0b1782fce9da88637c3f24573bd2b18a614a02e28bffdeed4d3537331e761dee9278800000000000000000000000000000000144219c984c10d1cd488a7069b6d3d63d4abdfa212d28236085d0f6745fbc618d91499de9bae57f4778f5a64905e54a7000000000000000000000000000000000df68071311606155d73fc90b2ebaf2c8108909e57917932906ea2a07ca2eda3b8f09d5fc343ea2c629411f5ab392d51000000000000000000000000000000001807fa0c1c2bf57f3a71b7cb919b5a8f01c2239ebec3af12292de3cda2cccbba26981fbf5eb06d538bb39b92c86bb9110000000000000000000000000000000003eabb969714731a842f1d81f7295e41ed327ead80f32f5a1bd17918f516a770d9aabdf1684ccd4ea9b095ee5e7867eb000000000000000000000000000000000ee566ba115e0eb7ae389d1b98bb1fe129f978965fe99ec261120834a4761db9ea00b49c2e799ea9e0efdc72101973020000000000000000000000000000000018a4cf6301ef8816dac3988d24260b8546bdc58f265964523ba32746167458ad855cfd0eccb6ac6637dc2b930e25ecc1000000000000000000000000000000000f70796ee9ee8ae6fdf70363cccc387c59320b9de8735fab21e7ad2ca9b86a64a5b42d6039c4009737d07c2b2ad6434d0000000000000000000000000000000002fba898d3c40019a6d0f2e31739ea577a4521a44e3f7195d7b0b61daed6471fcb4c78c420c61f9024781b7ac83422a20000000000000000000000000000000003d20a5c0835110a093d6e5c03149d2ab09ae2e4f188d6643514e8e37eca642db7366495f7afa2efb9bacc9481d31d6500000000000000000000000000000000012cf20f9ea6dc5e9c3d2c90eddc318337184c9e31fe3935a477fca1ce4379a8981869097e57bf95cfea6709f7bc79df000000000000000000000000000000000a92deea34670886a7a76397c26e43578a4c980542cc000f651f8902b47acb9b3b662e00d7b47e79cf6d34b8a50b7f5f00000000000000000000000000000000151a6e1ea862f1df8326bd9a26857ceefdd88eef351e50a61061dd0eb3e48093044e9bc8db94ce292f37901004a9419800000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "bls_pairing_4pairs_false",
+	},
+	{
+		input:    "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bls_pairing_zero_points",
+	},
+}
+
+// bls12381MapG1Tests are the test and benchmark data for the BLS12-381 map field
+// element to G1 precompiled contract.
+var bls12381MapG1Tests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000015b522702662c33ffdd11bf56bf55aee56d1fe7d5d67193bf62d7b2f164cbaeaa976d488419aa5539affb2795cc74607",
+		expected: "00000000000000000000000000000000016863977fdee3fd88ae33d7e05b8e78c029bc07d6dbbfc5ff1f3ef90c3ac59d0b4fd7318d6727b7741234edbbd72184000000000000000000000000000000001742fbf365e5499f1b356a223b9c724d4f9bc0dd412aeafc3f991877e8ec21a53cda4a2baeaa5fab0ed81f4a0934937f",
+		name:     "bls_mapg1_(u0)",
+	},
+	{
+		input:    "0000000000000000000000000000000007fdbcd8c1627a986dbefa6890e677dfe7564c50dbdd5fe3b73f8dd31aa5e384ef21ead2ed884d6113d5b718ccc5c2e1",
+		expected: "000000000000000000000000000000000ec988a82c9b3cde6543b30b1767f76b0532abf99d30b567240aa47fbf8638ba8ca27c8c8a993ec588028e41a3a404350000000000000000000000000000000000a66fa756d4f8576e06e6ef55f72d8c9034db6bb812251590ee2e1db2ed0742b42dac308118fc58fce5661483f1fd4d",
+		name:     "bls_mapg1_(u1)",
+	},
+	{
+		input:    "0000000000000000000000000000000001c09b1b7b55f248f0951fa237781e0d062728b578e0686ce7a3f383c704d9e6423bad41fecc44cf83886d9f70f2514c",
+		expected: "0000000000000000000000000000000009db6f08b63cfeac0f21aa53ea9d0b3e196ce0f53a06a9a82bafc7dd2e11542ccdf76ac148340e789a9bef60b7d0d5fa0000000000000000000000000000000016105abd51497050d52bdc49937683c1e04ff94b848b979cfa2c0c58984b014e7fd78afbf3f840aaa6867ad63e75b43b",
+		name:     "bls_mapg1_(u2)",
+	},
+	{
+		input:    "0000000000000000000000000000000019770d46693d6323a99c6ccddf354214bc098c35b80b28a531d57e2b5f1c99f500b6ea90e66b5266782ee054370a5fea",
+		expected: "000000000000000000000000000000000f826372b7983159e8166f4fad36058e414b9b2285b1da9b69759a4088d9ce21ad690c3777ded9e4385162107f2530090000000000000000000000000000000008290016536034dcce66a1ad784e002383283e866a22c2636629a45dda2d9cd04e538e76cbac952fde463434fae682f3",
+		name:     "bls_mapg1_(u3)",
+	},
+}
+
+// bls12381MapG2Tests are the test and benchmark data for the BLS12-381 map field
+// element to G2 precompiled contract.
+var bls12381MapG2Tests = []precompiledTest{
+	{
+		input:    "000000000000000000000000000000000446bcb39dd26f5b18773fd959a265745a0fca0e33ef7cdca61320dc373b8fccc739c9e13e026b7e150585f7dfeee16f00000000000000000000000000000000174347e4f6cae97a921727a061ed50735436e4f615352a969098f1cb5e2fa0d7eb0bc0618ee062171d5f894d04642156",
+		expected: "00000000000000000000000000000000130cd62bd431a07a3658b90a5f437ea11ac12a3b76ff64b298068d02a7cf2f7c721011a7849b5cfdd2becd3fec603c8c000000000000000000000000000000001827c7d91bd769b2f4b102cbe036e0ebd283799e58751ed85f0d7dd6ba4a663110776ceca28c95d5a3a25f1a9d02613300000000000000000000000000000000165f0a7b3308a7cc90c0d3a28dca54844297d7291842d73bdfb6d698104265914c5ed811620b60f94a78400aaba2e3e100000000000000000000000000000000069c836f6577b69f6d1f82907a4d4b6f73a48509b56af3850b77fd3f05e1258a3f51adc52499a67297333af42812e844",
+		name:     "bls_mapg2_(u0)",
+	},
+	{
+		input:    "000000000000000000000000000000000fe77ca68492bc95def641ad50666fd53aee32b950fb586d91b4170032ff4c0473789e41f45087e419b093a5e659b1d800000000000000000000000000000000182c28c18214d130a2c957af3d419b6cb5ad6d3bb5fe4683d8bc13b6710a5f65c480568b1b5c10737002e3e35b1027d0",
+		expected: "0000000000000000000000000000000014e7ffcd907dfea3463d823651ca6b01030b71acfce60d12a894b01617a9b9a29772abbd72f62cac20f1f75bd08ba34d000000000000000000000000000000001408fbb4d6239a450893a0a127160c2cb69a8056ae47a359f0f887ca85dcdf28670b38695fabdb25a3764e4fee04c83700000000000000000000000000000000002aef14991a31c90c47d9193beacad9775097de122ec40dc5577560462d282b88704c8ac43f0c58d6ccac5e666d966c00000000000000000000000000000000167d5fb2fcd9a6180637cb8a58cbb1023b01759cd768e8f2fb2f75a4f761af2234bb5dbaff65939d4dc4b586bd88842f",
+		name:     "bls_mapg2_(u1)",
+	},
+	{
+		input:    "000000000000000000000000000000001490de882ec269abfd0ac8cdf2e44883b77ac613edac588afbed942bd70c181dd3d173bdef0a33648bb7bd6dcfb0ca3d000000000000000000000000000000000a3ae3251e99aca585320c415706633f0dcca95e2663a34bb770b7db802f88a14a49d3a40eb76cae455dfa942ecd506e",
+		expected: "0000000000000000000000000000000013d571744bf576b66a148d9bc5d3cd92f3682da21596f35f43f6c294c2001ff1122ca3be64ac26a1594f70d50f0b409c0000000000000000000000000000000018f7c391168307518add7b617dca495b928a9623bf6be0f3755c3dd1b7fe7b77e01d2e62cca99b909077e740fe9004d000000000000000000000000000000000091b41d8a41f0ff18f1bfff731854eba879b683e54a81833946b87acd42bd7f2944907417d090b4b06a5e411b8729f51000000000000000000000000000000000ca727618f30b57257d4963967f3fc1d25ef90c85d9f8a32b43bc5b4e6ccd5e1f9fdb4912557366bfdbd455eac97f1e7",
+		name:     "bls_mapg2_(u2)",
+	},
+	{
+		input:    "000000000000000000000000000000000530d5a7e6ca9e50cde580a8f6895361081ec19314a826a359bb202f0f285ba98eade5e630325de7ab8543a71fa6760100000000000000000000000000000000162a4a53257b7c607c358fd88905e9dd793ccd4798183e074a13cf71ddb55748ef702a7a5a87ace8898f60de46be4b43",
+		expected: "00000000000000000000000000000000092722d0ced7c2393083f49fe8735d4d6a6a680b4bdc84344b6a717a005a05e2b84ab56df8523586e3497dad3aeed590000000000000000000000000000000001153035f416dc790e43f63587868a768a9f4521dff4bdb0ade5b9b1619c418dfc053afb823ebb5817fb7e85bd484e887000000000000000000000000000000000b60043413b31e98c285207fd15b8843562e79d29b68851486cc11cf4620515e7717f2d84a79f208962bfa438d0ee2fb0000000000000000000000000000000004897d74b31811930a7d389e0b2fdcd38f95fa5d3fe47b26ece671bb9c609b78b7ca87ab44c314f565924aac7bc12040",
+		name:     "bls_mapg2_(u3)",
+	},
+}
+
+// bls12381G1AddFailureTests are the malformed input test data for the BLS12-381
+// G1 point addition precompiled contract.
+var bls12381G1AddFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1add_empty_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1add_short_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1add_large_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e0",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_g1add_point_not_on_curve",
+	},
+	{
+		input:         "0100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_g1add_violate_top_bytes",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_g1add_invalid_field_element",
+	},
+}
+
+// bls12381G1MulFailureTests are the malformed input test data for the BLS12-381
+// G1 scalar multiplication precompiled contract.
+var bls12381G1MulFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1mul_empty_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1mul_short_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000000000000000000000000000000000000700",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1mul_large_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e00000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_g1mul_point_not_on_curve",
+	},
+	{
+		input:         "0100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_g1mul_violate_top_bytes",
+	},
+	{
+		input:         "000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_g1mul_invalid_field_element",
+	},
+}
+
+// bls12381G1MultiExpFailureTests are the malformed input test data for the BLS12-381
+// G1 multi exponentiation precompiled contract.
+var bls12381G1MultiExpFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1multiexp_empty_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000000070000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1multiexp_short_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000000070000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000000000000000000000000000000000000700",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g1multiexp_large_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000000070000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e00000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_g1multiexp_point_not_on_curve",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000000070100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_g1multiexp_violate_top_bytes",
+	},
+	{
+		input:         "000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_g1multiexp_invalid_field_element",
+	},
+}
+
+// bls12381G2AddFailureTests are the malformed input test data for the BLS12-381
+// G2 point addition precompiled contract.
+var bls12381G2AddFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2add_empty_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2add_short_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2add_large_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79bf",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_g2add_point_not_on_curve",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80100000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_g2add_violate_top_bytes",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_g2add_invalid_field_element",
+	},
+}
+
+// bls12381G2MulFailureTests are the malformed input test data for the BLS12-381
+// G2 scalar multiplication precompiled contract.
+var bls12381G2MulFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2mul_empty_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2mul_short_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000000000000000000000000000000000000700",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2mul_large_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79bf0000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_g2mul_point_not_on_curve",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80100000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_g2mul_violate_top_bytes",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_g2mul_invalid_field_element",
+	},
+}
+
+// bls12381G2MultiExpFailureTests are the malformed input test data for the BLS12-381
+// G2 multi exponentiation precompiled contract.
+var bls12381G2MultiExpFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2multiexp_empty_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000000000000000000000000000000000000700000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2multiexp_short_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000000000000000000000000000000000000700000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000000000000000000000000000000000000700",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_g2multiexp_large_input",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000000000000000000000000000000000000700000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79bf0000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_g2multiexp_point_not_on_curve",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000000000000000000000000000000000000700000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80100000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_g2multiexp_violate_top_bytes",
+	},
+	{
+		input:         "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000000000000000000000000000000000007",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_g2multiexp_invalid_field_element",
+	},
+}
+
+// bls12381PairingFailureTests are the malformed input test data for the BLS12-381
+// pairing check precompiled contract.
+var bls12381PairingFailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_pairing_empty_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_pairing_short_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be00",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_pairing_large_input",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79bf",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_pairing_g2_point_not_on_curve",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e000000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errors.New("point is not on curve"),
+		name:          "bls_pairing_g1_point_not_on_curve",
+	},
+	{
+		input:         "0100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_pairing_violate_top_bytes",
+	},
+	{
+		input:         "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000a989badd40d6212b33cffc3f3763e9bc760f988c9926b26da9dd85e928483446346b8ed00e1de5d5ea93e354abe706c00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expectedError: errBLS12381G1PointSubgroup,
+		name:          "bls_pairing_g1_not_in_correct_subgroup",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000013a59858b6809fca4d9a3b6539246a70051a3c88899964a42bc9a69cf9acdd9dd387cfa9086b894185b9a46a402be730000000000000000000000000000000002d27e0ec3356299a346a09ad7dc4ef68a483c3aed53f9139d2f929a3eecebf72082e5e58c6da24ee32e03040c406d4f",
+		expectedError: errBLS12381G2PointSubgroup,
+		name:          "bls_pairing_g2_not_in_correct_subgroup",
+	},
+}
+
+// bls12381MapG1FailureTests are the malformed input test data for the BLS12-381
+// map field element to G1 precompiled contract.
+var bls12381MapG1FailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_mapg1_empty_input",
+	},
+	{
+		input:         "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_mapg1_short_input",
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead00",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_mapg1_large_input",
+	},
+	{
+		input:         "0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_mapg1_top_bytes",
+	},
+	{
+		input:         "000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_mapg1_invalid_fq_element",
+	},
+}
+
+// bls12381MapG2FailureTests are the malformed input test data for the BLS12-381
+// map field element to G2 precompiled contract.
+var bls12381MapG2FailureTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_mapg2_empty_input",
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_mapg2_short_input",
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead00",
+		expectedError: errBLS12381InvalidInputLength,
+		name:          "bls_mapg2_large_input",
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead",
+		expectedError: errBLS12381InvalidFieldElementTopBytes,
+		name:          "bls_mapg2_top_bytes",
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dead000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+		expectedError: errors.New("must be less than modulus"),
+		name:          "bls_mapg2_invalid_fq_element",
+	},
+}
+
+// precompiledTestConfig is the chain configuration the precompiled contract
+// tests run against, extending AllEthashProtocolChanges with the precompiles
+// not (yet) part of any named hard fork.
+var precompiledTestConfig = func() ctypes.ChainConfigurator {
+	c := *params.AllEthashProtocolChanges
+	c.EIP2537Transition = big.NewInt(0)
+	return &c
+}()
+
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsForConfig(params.AllEthashProtocolChanges, big.NewInt(0))[common.HexToAddress(addr)]
+	p := PrecompiledContractsForConfig(precompiledTestConfig, big.NewInt(0))[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -421,7 +1014,7 @@ func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsForConfig(params.AllEthashProtocolChanges, big.NewInt(0))[common.HexToAddress(addr)]
+	p := PrecompiledContractsForConfig(precompiledTestConfig, big.NewInt(0))[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in)-1)
@@ -439,7 +1032,7 @@ func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
-	p := PrecompiledContractsForConfig(params.AllEthashProtocolChanges, big.NewInt(0))[common.HexToAddress(addr)]
+	p := PrecompiledContractsForConfig(precompiledTestConfig, big.NewInt(0))[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("31337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -471,6 +1064,17 @@ func TestIsPrecompiledContractEnabled(t *testing.T) {
 		common.BytesToAddress([]byte{8}),
 	}
 	var byzCts = append(homeCts, byzUniqCts...)
+	var bls12381Cts = []common.Address{
+		common.BytesToAddress([]byte{10}),
+		common.BytesToAddress([]byte{11}),
+		common.BytesToAddress([]byte{12}),
+		common.BytesToAddress([]byte{13}),
+		common.BytesToAddress([]byte{14}),
+		common.BytesToAddress([]byte{15}),
+		common.BytesToAddress([]byte{16}),
+		common.BytesToAddress([]byte{17}),
+		common.BytesToAddress([]byte{18}),
+	}
 	var nonCts = []common.Address{
 		{},
 		common.BytesToAddress([]byte{42}),
@@ -513,6 +1117,12 @@ func TestIsPrecompiledContractEnabled(t *testing.T) {
 		addCaseWhere(params.MainnetChainConfig, a, new(big.Int).Add(params.MainnetChainConfig.ByzantiumBlock, common.Big1), false)
 	}
 
+	for _, a := range bls12381Cts {
+		addCaseWhere(params.AllEthashProtocolChanges, a, big.NewInt(0), false)
+		addCaseWhere(params.MainnetChainConfig, a, new(big.Int).Add(params.MainnetChainConfig.ByzantiumBlock, common.Big1), false)
+		addCaseWhere(precompiledTestConfig, a, big.NewInt(0), true)
+	}
+
 	for i, c := range cases {
 		got := PrecompiledContractsForConfig(c.config, c.blockNum)[c.addr] != nil
 		if c.want != got {
@@ -538,6 +1148,17 @@ func TestIsPrecompiledContractEnabled(t *testing.T) {
 				common.BytesToAddress([]byte{8}): &bn256PairingByzantium{},
 			}
 		}
+		if c.config.IsEnabled(c.config.GetEIP2537Transition, c.blockNum) {
+			precomps[common.BytesToAddress([]byte{10})] = &bls12381G1Add{}
+			precomps[common.BytesToAddress([]byte{11})] = &bls12381G1Mul{}
+			precomps[common.BytesToAddress([]byte{12})] = &bls12381G1MultiExp{}
+			precomps[common.BytesToAddress([]byte{13})] = &bls12381G2Add{}
+			precomps[common.BytesToAddress([]byte{14})] = &bls12381G2Mul{}
+			precomps[common.BytesToAddress([]byte{15})] = &bls12381G2MultiExp{}
+			precomps[common.BytesToAddress([]byte{16})] = &bls12381Pairing{}
+			precomps[common.BytesToAddress([]byte{17})] = &bls12381MapG1{}
+			precomps[common.BytesToAddress([]byte{18})] = &bls12381MapG2{}
+		}
 		expect := precomps[c.addr] == nil
 		got = PrecompiledContractsForConfig(c.config, c.blockNum)[c.addr] == nil
 		if got != expect {
@@ -550,7 +1171,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 	if test.noBenchmark {
 		return
 	}
-	p := PrecompiledContractsForConfig(params.AllEthashProtocolChanges, big.NewInt(0))[common.HexToAddress(addr)]
+	p := PrecompiledContractsForConfig(precompiledTestConfig, big.NewInt(0))[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	reqGas := p.RequiredGas(in)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
@@ -702,6 +1323,195 @@ func TestPrecompileBlake2FMalformedInput(t *testing.T) {
 	}
 }
 
+// Tests the sample inputs from the BLS12-381 G1 point addition EIP 2537.
+func TestPrecompiledBLS12381G1Add(t *testing.T) {
+	for _, test := range bls12381G1AddTests {
+		testPrecompiled("0a", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 G1 point addition EIP 2537.
+func BenchmarkPrecompiledBLS12381G1Add(bench *testing.B) {
+	for _, test := range bls12381G1AddTests {
+		benchmarkPrecompiled("0a", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 G1 point addition EIP 2537.
+func TestPrecompiledBLS12381G1AddFail(t *testing.T) {
+	for _, test := range bls12381G1AddFailureTests {
+		testPrecompiledFailure("0a", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 G1 scalar multiplication EIP 2537.
+func TestPrecompiledBLS12381G1Mul(t *testing.T) {
+	for _, test := range bls12381G1MulTests {
+		testPrecompiled("0b", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 G1 scalar multiplication EIP 2537.
+func BenchmarkPrecompiledBLS12381G1Mul(bench *testing.B) {
+	for _, test := range bls12381G1MulTests {
+		benchmarkPrecompiled("0b", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 G1 scalar multiplication EIP 2537.
+func TestPrecompiledBLS12381G1MulFail(t *testing.T) {
+	for _, test := range bls12381G1MulFailureTests {
+		testPrecompiledFailure("0b", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 G1 multi exponentiation EIP 2537.
+func TestPrecompiledBLS12381G1MultiExp(t *testing.T) {
+	for _, test := range bls12381G1MultiExpTests {
+		testPrecompiled("0c", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 G1 multi exponentiation EIP 2537.
+func BenchmarkPrecompiledBLS12381G1MultiExp(bench *testing.B) {
+	for _, test := range bls12381G1MultiExpTests {
+		benchmarkPrecompiled("0c", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 G1 multi exponentiation EIP 2537.
+func TestPrecompiledBLS12381G1MultiExpFail(t *testing.T) {
+	for _, test := range bls12381G1MultiExpFailureTests {
+		testPrecompiledFailure("0c", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 G2 point addition EIP 2537.
+func TestPrecompiledBLS12381G2Add(t *testing.T) {
+	for _, test := range bls12381G2AddTests {
+		testPrecompiled("0d", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 G2 point addition EIP 2537.
+func BenchmarkPrecompiledBLS12381G2Add(bench *testing.B) {
+	for _, test := range bls12381G2AddTests {
+		benchmarkPrecompiled("0d", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 G2 point addition EIP 2537.
+func TestPrecompiledBLS12381G2AddFail(t *testing.T) {
+	for _, test := range bls12381G2AddFailureTests {
+		testPrecompiledFailure("0d", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 G2 scalar multiplication EIP 2537.
+func TestPrecompiledBLS12381G2Mul(t *testing.T) {
+	for _, test := range bls12381G2MulTests {
+		testPrecompiled("0e", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 G2 scalar multiplication EIP 2537.
+func BenchmarkPrecompiledBLS12381G2Mul(bench *testing.B) {
+	for _, test := range bls12381G2MulTests {
+		benchmarkPrecompiled("0e", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 G2 scalar multiplication EIP 2537.
+func TestPrecompiledBLS12381G2MulFail(t *testing.T) {
+	for _, test := range bls12381G2MulFailureTests {
+		testPrecompiledFailure("0e", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 G2 multi exponentiation EIP 2537.
+func TestPrecompiledBLS12381G2MultiExp(t *testing.T) {
+	for _, test := range bls12381G2MultiExpTests {
+		testPrecompiled("0f", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 G2 multi exponentiation EIP 2537.
+func BenchmarkPrecompiledBLS12381G2MultiExp(bench *testing.B) {
+	for _, test := range bls12381G2MultiExpTests {
+		benchmarkPrecompiled("0f", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 G2 multi exponentiation EIP 2537.
+func TestPrecompiledBLS12381G2MultiExpFail(t *testing.T) {
+	for _, test := range bls12381G2MultiExpFailureTests {
+		testPrecompiledFailure("0f", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 pairing check EIP 2537.
+func TestPrecompiledBLS12381Pairing(t *testing.T) {
+	for _, test := range bls12381PairingTests {
+		testPrecompiled("10", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 pairing check EIP 2537.
+func BenchmarkPrecompiledBLS12381Pairing(bench *testing.B) {
+	for _, test := range bls12381PairingTests {
+		benchmarkPrecompiled("10", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 pairing check EIP 2537.
+func TestPrecompiledBLS12381PairingFail(t *testing.T) {
+	for _, test := range bls12381PairingFailureTests {
+		testPrecompiledFailure("10", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 map field element to G1 EIP 2537.
+func TestPrecompiledBLS12381MapG1(t *testing.T) {
+	for _, test := range bls12381MapG1Tests {
+		testPrecompiled("11", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 map field element to G1 EIP 2537.
+func BenchmarkPrecompiledBLS12381MapG1(bench *testing.B) {
+	for _, test := range bls12381MapG1Tests {
+		benchmarkPrecompiled("11", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 map field element to G1 EIP 2537.
+func TestPrecompiledBLS12381MapG1Fail(t *testing.T) {
+	for _, test := range bls12381MapG1FailureTests {
+		testPrecompiledFailure("11", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLS12-381 map field element to G2 EIP 2537.
+func TestPrecompiledBLS12381MapG2(t *testing.T) {
+	for _, test := range bls12381MapG2Tests {
+		testPrecompiled("12", test, t)
+	}
+}
+
+// Benchmarks the sample inputs from the BLS12-381 map field element to G2 EIP 2537.
+func BenchmarkPrecompiledBLS12381MapG2(bench *testing.B) {
+	for _, test := range bls12381MapG2Tests {
+		benchmarkPrecompiled("12", test, bench)
+	}
+}
+
+// Tests the malformed inputs of the BLS12-381 map field element to G2 EIP 2537.
+func TestPrecompiledBLS12381MapG2Fail(t *testing.T) {
+	for _, test := range bls12381MapG2FailureTests {
+		testPrecompiledFailure("12", test, t)
+	}
+}
+
 // EcRecover test vectors
 var ecRecoverTests = []precompiledTest{
 	{
@@ -753,3 +1563,75 @@ func TestPrecompiledEcrecover(t *testing.T) {
 	}
 
 }
+
+func TestCustomPrecompiles(t *testing.T) {
+	var (
+		sha3Addr    = common.HexToAddress("0x0100")
+		ed25519Addr = common.HexToAddress("0x0101")
+		activation  = uint64(10)
+	)
+	config := &coregeth.CoreGethChainConfig{
+		NetworkID: 1,
+		ChainID:   big.NewInt(1),
+		Ethash:    new(ctypes.EthashConfig),
+		CustomPrecompiles: ctypes.CustomPrecompiles{
+			sha3Addr: {
+				Builtin:         "sha3_512",
+				Pricing:         ctypes.CustomPrecompilePricing{Linear: &ctypes.LinearPricing{Base: 60, Word: 12}},
+				ActivationBlock: &activation,
+			},
+			ed25519Addr: {
+				Builtin:         "ed25519_verify",
+				Pricing:         ctypes.CustomPrecompilePricing{Linear: &ctypes.LinearPricing{Base: 2000}},
+				ActivationBlock: &activation,
+			},
+		},
+	}
+	if err := ValidateCustomPrecompiles(config); err != nil {
+		t.Fatal(err)
+	}
+	if p := PrecompiledContractsForConfig(config, big.NewInt(9))[sha3Addr]; p != nil {
+		t.Fatal("custom precompile enabled before activation")
+	}
+	precompiles := PrecompiledContractsForConfig(config, big.NewInt(10))
+
+	// SHA3-512("abc"), FIPS 202 example
+	p := precompiles[sha3Addr]
+	if p == nil {
+		t.Fatal("custom precompile not enabled at activation")
+	}
+	if gas := p.RequiredGas(make([]byte, 33)); gas != 60+2*12 {
+		t.Errorf("gas mismatch: have %d, want %d", gas, 60+2*12)
+	}
+	res, err := RunPrecompiledContract(p, []byte("abc"), NewContract(AccountRef(common.Address{}), nil, new(big.Int), 72))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0"; common.Bytes2Hex(res) != want {
+		t.Errorf("sha3_512 mismatch: have %x, want %s", res, want)
+	}
+
+	// EIP-665 style ed25519 verification
+	p = precompiles[ed25519Addr]
+	priv := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	digest := crypto.Keccak256([]byte("core-geth"))
+	input := append(append(common.CopyBytes(digest), priv.Public().(ed25519.PublicKey)...), ed25519.Sign(priv, digest)...)
+	if res, _ := p.Run(input); !bytes.Equal(res, ed25519VerifyValid) {
+		t.Errorf("valid signature rejected: %x", res)
+	}
+	input[0] ^= 0xff
+	if res, _ := p.Run(input); !bytes.Equal(res, ed25519VerifyInvalid) {
+		t.Errorf("invalid signature accepted: %x", res)
+	}
+
+	// Configuration errors
+	config.CustomPrecompiles[common.HexToAddress("0x0102")] = ctypes.CustomPrecompile{Builtin: "nonexistent", Pricing: ctypes.CustomPrecompilePricing{Linear: &ctypes.LinearPricing{}}}
+	if err := ValidateCustomPrecompiles(config); err == nil {
+		t.Error("expected error for unknown builtin")
+	}
+	delete(config.CustomPrecompiles, common.HexToAddress("0x0102"))
+	config.CustomPrecompiles[common.BytesToAddress([]byte{2})] = ctypes.CustomPrecompile{Builtin: "keccak256", Pricing: ctypes.CustomPrecompilePricing{Linear: &ctypes.LinearPricing{}}}
+	if err := ValidateCustomPrecompiles(config); err == nil {
+		t.Error("expected error for protocol precompile address")
+	}
+}
@@ -106,3 +106,56 @@ func TestEIP2200(t *testing.T) {
 		}
 	}
 }
+
+var eip2929Tests = []struct {
+	original byte
+	gaspool  uint64
+	input    string
+	used     uint64
+	failure  error
+}{
+	{0, math.MaxUint64, "0x60005450600054", 2208, nil},                                                 // SLOAD cold, SLOAD warm
+	{0, math.MaxUint64, "0x60ff315060ff31", 2708, nil},                                                 // BALANCE cold, BALANCE warm
+	{0, math.MaxUint64, "0x60ff3b5060ff3b", 2708, nil},                                                 // EXTCODESIZE cold, EXTCODESIZE warm
+	{0, math.MaxUint64, "0x60ff3f5060ff3f", 2708, nil},                                                 // EXTCODEHASH cold, EXTCODEHASH warm
+	{0, math.MaxUint64, "0x60ff3b5060ff31", 2708, nil},                                                 // EXTCODESIZE cold, BALANCE warm
+	{0, math.MaxUint64, "0x6001600055", 22106, nil},                                                    // SSTORE cold 0 -> 1
+	{0, math.MaxUint64, "0x600054506001600055", 22111, nil},                                            // SLOAD cold, SSTORE warm 0 -> 1
+	{1, math.MaxUint64, "0x6001600055", 2206, nil},                                                     // SSTORE cold 1 -> 1
+	{1, math.MaxUint64, "0x6002600055", 5006, nil},                                                     // SSTORE cold 1 -> 2
+	{0, math.MaxUint64, "0x60ffff", 7603, nil},                                                         // SELFDESTRUCT cold
+	{0, math.MaxUint64, "0x6000600060006000600060ff6000f1506000600060006000600060ff6000f1", 2744, nil}, // CALL cold, CALL warm
+	{0, math.MaxUint64, "0x600060006000600060ff6000fa506000600060006000600060ff6000f1", 2741, nil},     // STATICCALL cold, CALL warm
+	{0, 2000, "0x600054", 2000, ErrOutOfGas},                                                           // SLOAD cold unaffordable
+	{0, 2110, "0x600054506000546000", 2110, ErrOutOfGas},                                               // SLOAD cold, SLOAD warm, PUSH1 unaffordable
+	{0, 2500, "0x6000600060006000600060ff6000f1", 2500, ErrOutOfGas},                                   // CALL cold unaffordable
+	{0, math.MaxUint64, "0x60006000600060ff3c6000600060006000600060ff6000f1", 2733, nil},               // EXTCODECOPY cold, CALL warm
+}
+
+func TestEIP2929(t *testing.T) {
+	for i, tt := range eip2929Tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, hexutil.MustDecode(tt.input))
+		statedb.SetState(address, common.Hash{}, common.BytesToHash([]byte{tt.original}))
+		statedb.Finalise(true) // Push the state into the "original" slot
+
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+			BlockNumber: new(big.Int),
+		}
+
+		vmenv := NewEVM(vmctx, statedb, params.AllEthashProtocolChanges, Config{ExtraEips: []int{2929}})
+
+		_, gas, err := vmenv.Call(AccountRef(common.Address{}), address, nil, tt.gaspool, new(big.Int))
+		if err != tt.failure {
+			t.Errorf("test %d: failure mismatch: have %v, want %v", i, err, tt.failure)
+		}
+		if used := tt.gaspool - gas; used != tt.used {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+	}
+}
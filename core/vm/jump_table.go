@@ -189,9 +189,26 @@ func instructionSetForConfig(config ctypes.ChainConfigurator, bn *big.Int) JumpT
 	if config.IsEnabled(config.GetEIP2200Transition, bn) && !config.IsEnabled(config.GetEIP2200DisableTransition, bn) {
 		enable2200(&instructionSet) // Net metered SSTORE - https://eips.ethereum.org/EIPS/eip-2200
 	}
+	if config.IsEnabled(config.GetEIP2929Transition, bn) {
+		enable2929(&instructionSet) // Access lists for trie-accessing opcodes - https://eips.ethereum.org/EIPS/eip-2929
+	}
 	return instructionSet
 }
 
+// ActiveOpCodes returns the opcodes which are valid at the given block number.
+func ActiveOpCodes(config ctypes.ChainConfigurator, bn *big.Int) []OpCode {
+	var (
+		jt  = instructionSetForConfig(config, bn)
+		ops []OpCode
+	)
+	for i, op := range jt {
+		if op.valid {
+			ops = append(ops, OpCode(i))
+		}
+	}
+	return ops
+}
+
 // newBaseInstructionSet returns Frontier instructions
 func newBaseInstructionSet() JumpTable {
 	return JumpTable{
@@ -0,0 +1,60 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls12381
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+)
+
+var fuz = 10
+
+func randScalar(max *big.Int) *big.Int {
+	a, _ := rand.Int(rand.Reader, max)
+	return a
+}
+
+func fromHex(size int, hexStrs ...string) []byte {
+	var out []byte
+	if size > 0 {
+		out = make([]byte, size*len(hexStrs))
+	}
+	for i := 0; i < len(hexStrs); i++ {
+		hexStr := hexStrs[i]
+		if hexStr[:2] == "0x" {
+			hexStr = hexStr[2:]
+		}
+		if len(hexStr)%2 == 1 {
+			hexStr = "0" + hexStr
+		}
+		bytes, err := hex.DecodeString(hexStr)
+		if err != nil {
+			panic(err)
+		}
+		if size <= 0 {
+			out = append(out, bytes...)
+		} else {
+			if len(bytes) > size {
+				panic("bad input string")
+			}
+			offset := i*size + (size - len(bytes))
+			copy(out[offset:], bytes)
+		}
+	}
+	return out
+}
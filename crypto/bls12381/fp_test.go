@@ -0,0 +1,1051 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls12381
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestFpSerialization(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		in := make([]byte, fpByteSize)
+		fe, err := fromBytes(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fe.isZero() {
+			t.Fatal("serialization failed")
+		}
+		if !bytes.Equal(in, toBytes(fe)) {
+			t.Fatal("serialization failed")
+		}
+	})
+	t.Run("bytes", func(t *testing.T) {
+		for i := 0; i < fuz; i++ {
+			a, _ := new(fe).rand(rand.Reader)
+			b, err := fromBytes(toBytes(a))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !a.equal(b) {
+				t.Fatal("serialization failed")
+			}
+		}
+	})
+	t.Run("string", func(t *testing.T) {
+		for i := 0; i < fuz; i++ {
+			a, _ := new(fe).rand(rand.Reader)
+			b, err := fromString(toString(a))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !a.equal(b) {
+				t.Fatal("encoding or decoding failed")
+			}
+		}
+	})
+	t.Run("big", func(t *testing.T) {
+		for i := 0; i < fuz; i++ {
+			a, _ := new(fe).rand(rand.Reader)
+			b, err := fromBig(toBig(a))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !a.equal(b) {
+				t.Fatal("encoding or decoding failed")
+			}
+		}
+	})
+}
+
+func TestFpAdditionCrossAgainstBigInt(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		b, _ := new(fe).rand(rand.Reader)
+		c := new(fe)
+		big_a := a.big()
+		big_b := b.big()
+		big_c := new(big.Int)
+		add(c, a, b)
+		out_1 := c.bytes()
+		out_2 := padBytes(big_c.Add(big_a, big_b).Mod(big_c, modulus.big()).Bytes(), fpByteSize)
+		if !bytes.Equal(out_1, out_2) {
+			t.Fatal("cross test against big.Int is failed A")
+		}
+		double(c, a)
+		out_1 = c.bytes()
+		out_2 = padBytes(big_c.Add(big_a, big_a).Mod(big_c, modulus.big()).Bytes(), fpByteSize)
+		if !bytes.Equal(out_1, out_2) {
+			t.Fatal("cross test against big.Int is failed B")
+		}
+		sub(c, a, b)
+		out_1 = c.bytes()
+		out_2 = padBytes(big_c.Sub(big_a, big_b).Mod(big_c, modulus.big()).Bytes(), fpByteSize)
+		if !bytes.Equal(out_1, out_2) {
+			t.Fatal("cross test against big.Int is failed C")
+		}
+		neg(c, a)
+		out_1 = c.bytes()
+		out_2 = padBytes(big_c.Neg(big_a).Mod(big_c, modulus.big()).Bytes(), fpByteSize)
+		if !bytes.Equal(out_1, out_2) {
+			t.Fatal("cross test against big.Int is failed D")
+		}
+	}
+}
+
+func TestFpAdditionProperties(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+
+		zero := new(fe).zero()
+		a, _ := new(fe).rand(rand.Reader)
+		b, _ := new(fe).rand(rand.Reader)
+		c_1, c_2 := new(fe), new(fe)
+		add(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a + 0 == a")
+		}
+		sub(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a - 0 == a")
+		}
+		double(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("2 * 0 == 0")
+		}
+		neg(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("-0 == 0")
+		}
+		sub(c_1, zero, a)
+		neg(c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("0-a == -a")
+		}
+		double(c_1, a)
+		add(c_2, a, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("2 * a == a + a")
+		}
+		add(c_1, a, b)
+		add(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a + b = b + a")
+		}
+		sub(c_1, a, b)
+		sub(c_2, b, a)
+		neg(c_2, c_2)
+		if !c_1.equal(c_2) {
+			t.Fatal("a - b = - ( b - a )")
+		}
+		c_x, _ := new(fe).rand(rand.Reader)
+		add(c_1, a, b)
+		add(c_1, c_1, c_x)
+		add(c_2, a, c_x)
+		add(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a + b) + c == (a + c ) + b")
+		}
+		sub(c_1, a, b)
+		sub(c_1, c_1, c_x)
+		sub(c_2, a, c_x)
+		sub(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a - b) - c == (a - c ) -b")
+		}
+	}
+}
+
+func TestFpMultiplicationCrossAgainstBigInt(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		b, _ := new(fe).rand(rand.Reader)
+		c := new(fe)
+		big_a := toBig(a)
+		big_b := toBig(b)
+		big_c := new(big.Int)
+		mul(c, a, b)
+		out_1 := toBytes(c)
+		out_2 := padBytes(big_c.Mul(big_a, big_b).Mod(big_c, modulus.big()).Bytes(), fpByteSize)
+		if !bytes.Equal(out_1, out_2) {
+			t.Fatal("cross test against big.Int is failed")
+		}
+	}
+}
+
+func TestFpMultiplicationProperties(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		b, _ := new(fe).rand(rand.Reader)
+		zero, one := new(fe).zero(), new(fe).one()
+		c_1, c_2 := new(fe), new(fe)
+		mul(c_1, a, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("a * 0 == 0")
+		}
+		mul(c_1, a, one)
+		if !c_1.equal(a) {
+			t.Fatal("a * 1 == a")
+		}
+		mul(c_1, a, b)
+		mul(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a * b == b * a")
+		}
+		c_x, _ := new(fe).rand(rand.Reader)
+		mul(c_1, a, b)
+		mul(c_1, c_1, c_x)
+		mul(c_2, c_x, b)
+		mul(c_2, c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a * b) * c == (a * c) * b")
+		}
+		square(a, zero)
+		if !a.equal(zero) {
+			t.Fatal("0^2 == 0")
+		}
+		square(a, one)
+		if !a.equal(one) {
+			t.Fatal("1^2 == 1")
+		}
+		_, _ = a.rand(rand.Reader)
+		square(c_1, a)
+		mul(c_2, a, a)
+		if !c_1.equal(c_1) {
+			t.Fatal("a^2 == a*a")
+		}
+	}
+}
+
+func TestFpExponentiation(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		u := new(fe)
+		exp(u, a, big.NewInt(0))
+		if !u.isOne() {
+			t.Fatal("a^0 == 1")
+		}
+		exp(u, a, big.NewInt(1))
+		if !u.equal(a) {
+			t.Fatal("a^1 == a")
+		}
+		v := new(fe)
+		mul(u, a, a)
+		mul(u, u, u)
+		mul(u, u, u)
+		exp(v, a, big.NewInt(8))
+		if !u.equal(v) {
+			t.Fatal("((a^2)^2)^2 == a^8")
+		}
+		p := modulus.big()
+		exp(u, a, p)
+		if !u.equal(a) {
+			t.Fatal("a^p == a")
+		}
+		exp(u, a, p.Sub(p, big.NewInt(1)))
+		if !u.isOne() {
+			t.Fatal("a^(p-1) == 1")
+		}
+	}
+}
+
+func TestFpInversion(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		u := new(fe)
+		zero, one := new(fe).zero(), new(fe).one()
+		inverse(u, zero)
+		if !u.equal(zero) {
+			t.Fatal("(0^-1) == 0)")
+		}
+		inverse(u, one)
+		if !u.equal(one) {
+			t.Fatal("(1^-1) == 1)")
+		}
+		a, _ := new(fe).rand(rand.Reader)
+		inverse(u, a)
+		mul(u, u, a)
+		if !u.equal(one) {
+			t.Fatal("(r*a) * r*(a^-1) == r)")
+		}
+		v := new(fe)
+		p := modulus.big()
+		exp(u, a, p.Sub(p, big.NewInt(2)))
+		inverse(v, a)
+		if !v.equal(u) {
+			t.Fatal("a^(p-2) == a^-1")
+		}
+	}
+}
+
+func TestFpSquareRoot(t *testing.T) {
+	if sqrt(new(fe), nonResidue1) {
+		t.Fatal("non residue cannot have a sqrt")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		r0, r1 := new(fe), new(fe)
+		d0 := sqrt(r0, a)
+		d1 := _sqrt(r1, a)
+		if d0 != d1 {
+			t.Fatal("sqrt decision failed")
+		}
+		if d0 {
+			square(r0, r0)
+			square(r1, r1)
+			if !r0.equal(r1) {
+				t.Fatal("sqrt failed")
+			}
+			if !r0.equal(a) {
+				t.Fatal("sqrt failed")
+			}
+		}
+	}
+}
+
+func TestFpNonResidue(t *testing.T) {
+	if !isQuadraticNonResidue(nonResidue1) {
+		t.Fatal("element is quadratic non residue, 1")
+	}
+	if isQuadraticNonResidue(new(fe).one()) {
+		t.Fatal("one is not quadratic non residue")
+	}
+	if !isQuadraticNonResidue(new(fe).zero()) {
+		t.Fatal("should accept zero as quadratic non residue")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		square(a, a)
+		if isQuadraticNonResidue(a) {
+			t.Fatal("element is not quadratic non residue")
+		}
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe).rand(rand.Reader)
+		if !sqrt(new(fe), a) {
+			if !isQuadraticNonResidue(a) {
+				t.Fatal("element is quadratic non residue, 2", i)
+			}
+		} else {
+			i -= 1
+		}
+	}
+
+}
+
+func TestFp2Serialization(t *testing.T) {
+	field := newFp2()
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe2).rand(rand.Reader)
+		b, err := field.fromBytes(field.toBytes(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b) {
+			t.Fatal("serialization failed")
+		}
+	}
+}
+
+func TestFp2AdditionProperties(t *testing.T) {
+	field := newFp2()
+	for i := 0; i < fuz; i++ {
+		zero := field.zero()
+		a, _ := new(fe2).rand(rand.Reader)
+		b, _ := new(fe2).rand(rand.Reader)
+		c_1 := field.new()
+		c_2 := field.new()
+		field.add(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a + 0 == a")
+		}
+		field.sub(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a - 0 == a")
+		}
+		field.double(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("2 * 0 == 0")
+		}
+		field.neg(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("-0 == 0")
+		}
+		field.sub(c_1, zero, a)
+		field.neg(c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("0-a == -a")
+		}
+		field.double(c_1, a)
+		field.add(c_2, a, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("2 * a == a + a")
+		}
+		field.add(c_1, a, b)
+		field.add(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a + b = b + a")
+		}
+		field.sub(c_1, a, b)
+		field.sub(c_2, b, a)
+		field.neg(c_2, c_2)
+		if !c_1.equal(c_2) {
+			t.Fatal("a - b = - ( b - a )")
+		}
+		c_x, _ := new(fe2).rand(rand.Reader)
+		field.add(c_1, a, b)
+		field.add(c_1, c_1, c_x)
+		field.add(c_2, a, c_x)
+		field.add(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a + b) + c == (a + c ) + b")
+		}
+		field.sub(c_1, a, b)
+		field.sub(c_1, c_1, c_x)
+		field.sub(c_2, a, c_x)
+		field.sub(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a - b) - c == (a - c ) -b")
+		}
+	}
+}
+
+func TestFp2MultiplicationProperties(t *testing.T) {
+	field := newFp2()
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe2).rand(rand.Reader)
+		b, _ := new(fe2).rand(rand.Reader)
+		zero := field.zero()
+		one := field.one()
+		c_1, c_2 := field.new(), field.new()
+		field.mul(c_1, a, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("a * 0 == 0")
+		}
+		field.mul(c_1, a, one)
+		if !c_1.equal(a) {
+			t.Fatal("a * 1 == a")
+		}
+		field.mul(c_1, a, b)
+		field.mul(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a * b == b * a")
+		}
+		c_x, _ := new(fe2).rand(rand.Reader)
+		field.mul(c_1, a, b)
+		field.mul(c_1, c_1, c_x)
+		field.mul(c_2, c_x, b)
+		field.mul(c_2, c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a * b) * c == (a * c) * b")
+		}
+		field.square(a, zero)
+		if !a.equal(zero) {
+			t.Fatal("0^2 == 0")
+		}
+		field.square(a, one)
+		if !a.equal(one) {
+			t.Fatal("1^2 == 1")
+		}
+		_, _ = a.rand(rand.Reader)
+		field.square(c_1, a)
+		field.mul(c_2, a, a)
+		if !c_2.equal(c_1) {
+			t.Fatal("a^2 == a*a")
+		}
+	}
+}
+
+func TestFp2Exponentiation(t *testing.T) {
+	field := newFp2()
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe2).rand(rand.Reader)
+		u := field.new()
+		field.exp(u, a, big.NewInt(0))
+		if !u.equal(field.one()) {
+			t.Fatal("a^0 == 1")
+		}
+		field.exp(u, a, big.NewInt(1))
+		if !u.equal(a) {
+			t.Fatal("a^1 == a")
+		}
+		v := field.new()
+		field.mul(u, a, a)
+		field.mul(u, u, u)
+		field.mul(u, u, u)
+		field.exp(v, a, big.NewInt(8))
+		if !u.equal(v) {
+			t.Fatal("((a^2)^2)^2 == a^8")
+		}
+	}
+}
+
+func TestFp2Inversion(t *testing.T) {
+	field := newFp2()
+	u := field.new()
+	zero := field.zero()
+	one := field.one()
+	field.inverse(u, zero)
+	if !u.equal(zero) {
+		t.Fatal("(0 ^ -1) == 0)")
+	}
+	field.inverse(u, one)
+	if !u.equal(one) {
+		t.Fatal("(1 ^ -1) == 1)")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe2).rand(rand.Reader)
+		field.inverse(u, a)
+		field.mul(u, u, a)
+		if !u.equal(one) {
+			t.Fatal("(r * a) * r * (a ^ -1) == r)")
+		}
+	}
+}
+
+func TestFp2SquareRoot(t *testing.T) {
+	e := newFp2()
+	if e.sqrtBLST(e.new(), nonResidue2) {
+		t.Fatal("non residue cannot have a sqrt")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe2).rand(rand.Reader)
+		r0, r1 := new(fe2), new(fe2)
+		d0 := e.sqrt(r0, a)
+		d1 := e.sqrtBLST(r1, a)
+		if d0 != d1 {
+			t.Fatal("sqrt decision failed")
+		}
+		if d0 {
+			e.square(r0, r0)
+			e.square(r1, r1)
+			if !r0.equal(r1) {
+				t.Fatal("sqrt failed")
+			}
+			if !r0.equal(a) {
+				t.Fatal("sqrt failed")
+			}
+		}
+	}
+}
+
+func TestFp6Serialization(t *testing.T) {
+	field := newFp6(nil)
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe6).rand(rand.Reader)
+		b, err := field.fromBytes(field.toBytes(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b) {
+			t.Fatal("serialization")
+		}
+	}
+}
+
+func TestFp6AdditionProperties(t *testing.T) {
+	field := newFp6(nil)
+	for i := 0; i < fuz; i++ {
+		zero := field.zero()
+		a, _ := new(fe6).rand(rand.Reader)
+		b, _ := new(fe6).rand(rand.Reader)
+		c_1 := field.new()
+		c_2 := field.new()
+		field.add(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a + 0 == a")
+		}
+		field.sub(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a - 0 == a")
+		}
+		field.double(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("2 * 0 == 0")
+		}
+		field.neg(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("-0 == 0")
+		}
+		field.sub(c_1, zero, a)
+		field.neg(c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("0-a == -a")
+		}
+		field.double(c_1, a)
+		field.add(c_2, a, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("2 * a == a + a")
+		}
+		field.add(c_1, a, b)
+		field.add(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a + b = b + a")
+		}
+		field.sub(c_1, a, b)
+		field.sub(c_2, b, a)
+		field.neg(c_2, c_2)
+		if !c_1.equal(c_2) {
+			t.Fatal("a - b = - ( b - a )")
+		}
+		c_x, _ := new(fe6).rand(rand.Reader)
+		field.add(c_1, a, b)
+		field.add(c_1, c_1, c_x)
+		field.add(c_2, a, c_x)
+		field.add(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a + b) + c == (a + c ) + b")
+		}
+		field.sub(c_1, a, b)
+		field.sub(c_1, c_1, c_x)
+		field.sub(c_2, a, c_x)
+		field.sub(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a - b) - c == (a - c ) -b")
+		}
+	}
+}
+
+func TestFp6SparseMultiplication(t *testing.T) {
+	fp6 := newFp6(nil)
+	var a, b, u *fe6
+	for j := 0; j < fuz; j++ {
+		a, _ = new(fe6).rand(rand.Reader)
+		b, _ = new(fe6).rand(rand.Reader)
+		u, _ = new(fe6).rand(rand.Reader)
+		b[2].zero()
+		fp6.mul(u, a, b)
+		fp6.mul01(a, a, &b[0], &b[1])
+		if !a.equal(u) {
+			t.Fatal("mul by 01")
+		}
+	}
+	for j := 0; j < fuz; j++ {
+		a, _ = new(fe6).rand(rand.Reader)
+		b, _ = new(fe6).rand(rand.Reader)
+		u, _ = new(fe6).rand(rand.Reader)
+		b[2].zero()
+		b[0].zero()
+		fp6.mul(u, a, b)
+		fp6.mul1(a, a, &b[1])
+		if !a.equal(u) {
+			t.Fatal("mul by 1")
+		}
+	}
+}
+
+func TestFp6MultiplicationProperties(t *testing.T) {
+	field := newFp6(nil)
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe6).rand(rand.Reader)
+		b, _ := new(fe6).rand(rand.Reader)
+		zero := field.zero()
+		one := field.one()
+		c_1, c_2 := field.new(), field.new()
+		field.mul(c_1, a, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("a * 0 == 0")
+		}
+		field.mul(c_1, a, one)
+		if !c_1.equal(a) {
+			t.Fatal("a * 1 == a")
+		}
+		field.mul(c_1, a, b)
+		field.mul(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a * b == b * a")
+		}
+		c_x, _ := new(fe6).rand(rand.Reader)
+		field.mul(c_1, a, b)
+		field.mul(c_1, c_1, c_x)
+		field.mul(c_2, c_x, b)
+		field.mul(c_2, c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a * b) * c == (a * c) * b")
+		}
+		field.square(a, zero)
+		if !a.equal(zero) {
+			t.Fatal("0^2 == 0")
+		}
+		field.square(a, one)
+		if !a.equal(one) {
+			t.Fatal("1^2 == 1")
+		}
+		_, _ = a.rand(rand.Reader)
+		field.square(c_1, a)
+		field.mul(c_2, a, a)
+		if !c_2.equal(c_1) {
+			t.Fatal("a^2 == a*a")
+		}
+	}
+}
+
+func TestFp6Exponentiation(t *testing.T) {
+	field := newFp6(nil)
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe6).rand(rand.Reader)
+		u := field.new()
+		field.exp(u, a, big.NewInt(0))
+		if !u.equal(field.one()) {
+			t.Fatal("a^0 == 1")
+		}
+		field.exp(u, a, big.NewInt(1))
+		if !u.equal(a) {
+			t.Fatal("a^1 == a")
+		}
+		v := field.new()
+		field.mul(u, a, a)
+		field.mul(u, u, u)
+		field.mul(u, u, u)
+		field.exp(v, a, big.NewInt(8))
+		if !u.equal(v) {
+			t.Fatal("((a^2)^2)^2 == a^8")
+		}
+	}
+}
+
+func TestFp6Inversion(t *testing.T) {
+	field := newFp6(nil)
+	for i := 0; i < fuz; i++ {
+		u := field.new()
+		zero := field.zero()
+		one := field.one()
+		field.inverse(u, zero)
+		if !u.equal(zero) {
+			t.Fatal("(0^-1) == 0)")
+		}
+		field.inverse(u, one)
+		if !u.equal(one) {
+			t.Fatal("(1^-1) == 1)")
+		}
+		a, _ := new(fe6).rand(rand.Reader)
+		field.inverse(u, a)
+		field.mul(u, u, a)
+		if !u.equal(one) {
+			t.Fatal("(r*a) * r*(a^-1) == r)")
+		}
+	}
+}
+
+func TestFp12Serialization(t *testing.T) {
+	field := newFp12(nil)
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe12).rand(rand.Reader)
+		b, err := field.fromBytes(field.toBytes(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b) {
+			t.Fatal("serialization")
+		}
+	}
+}
+
+func TestFp12AdditionProperties(t *testing.T) {
+	field := newFp12(nil)
+	for i := 0; i < fuz; i++ {
+		zero := field.zero()
+		a, _ := new(fe12).rand(rand.Reader)
+		b, _ := new(fe12).rand(rand.Reader)
+		c_1 := field.new()
+		c_2 := field.new()
+		field.add(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a + 0 == a")
+		}
+		field.sub(c_1, a, zero)
+		if !c_1.equal(a) {
+			t.Fatal("a - 0 == a")
+		}
+		field.double(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("2 * 0 == 0")
+		}
+		field.neg(c_1, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("-0 == 0")
+		}
+		field.sub(c_1, zero, a)
+		field.neg(c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("0-a == -a")
+		}
+		field.double(c_1, a)
+		field.add(c_2, a, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("2 * a == a + a")
+		}
+		field.add(c_1, a, b)
+		field.add(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a + b = b + a")
+		}
+		field.sub(c_1, a, b)
+		field.sub(c_2, b, a)
+		field.neg(c_2, c_2)
+		if !c_1.equal(c_2) {
+			t.Fatal("a - b = - ( b - a )")
+		}
+		c_x, _ := new(fe12).rand(rand.Reader)
+		field.add(c_1, a, b)
+		field.add(c_1, c_1, c_x)
+		field.add(c_2, a, c_x)
+		field.add(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a + b) + c == (a + c ) + b")
+		}
+		field.sub(c_1, a, b)
+		field.sub(c_1, c_1, c_x)
+		field.sub(c_2, a, c_x)
+		field.sub(c_2, c_2, b)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a - b) - c == (a - c ) -b")
+		}
+	}
+}
+
+func TestFp12MultiplicationProperties(t *testing.T) {
+	field := newFp12(nil)
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe12).rand(rand.Reader)
+		b, _ := new(fe12).rand(rand.Reader)
+		zero := field.zero()
+		one := field.one()
+		c_1, c_2 := field.new(), field.new()
+		field.mul(c_1, a, zero)
+		if !c_1.equal(zero) {
+			t.Fatal("a * 0 == 0")
+		}
+		field.mul(c_1, a, one)
+		if !c_1.equal(a) {
+			t.Fatal("a * 1 == a")
+		}
+		field.mul(c_1, a, b)
+		field.mul(c_2, b, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("a * b == b * a")
+		}
+		c_x, _ := new(fe12).rand(rand.Reader)
+		field.mul(c_1, a, b)
+		field.mul(c_1, c_1, c_x)
+		field.mul(c_2, c_x, b)
+		field.mul(c_2, c_2, a)
+		if !c_1.equal(c_2) {
+			t.Fatal("(a * b) * c == (a * c) * b")
+		}
+		field.square(a, zero)
+		if !a.equal(zero) {
+			t.Fatal("0^2 == 0")
+		}
+		field.square(a, one)
+		if !a.equal(one) {
+			t.Fatal("1^2 == 1")
+		}
+		_, _ = a.rand(rand.Reader)
+		field.square(c_1, a)
+		field.mul(c_2, a, a)
+		if !c_2.equal(c_1) {
+			t.Fatal("a^2 == a*a")
+		}
+	}
+}
+
+func TestFp12Exponentiation(t *testing.T) {
+	field := newFp12(nil)
+	for i := 0; i < fuz; i++ {
+		a, _ := new(fe12).rand(rand.Reader)
+		u := field.new()
+		field.exp(u, a, big.NewInt(0))
+		if !u.equal(field.one()) {
+			t.Fatal("a^0 == 1")
+		}
+		field.exp(u, a, big.NewInt(1))
+		if !u.equal(a) {
+			t.Fatal("a^1 == a")
+		}
+		v := field.new()
+		field.mul(u, a, a)
+		field.mul(u, u, u)
+		field.mul(u, u, u)
+		field.exp(v, a, big.NewInt(8))
+		if !u.equal(v) {
+			t.Fatal("((a^2)^2)^2 == a^8")
+		}
+	}
+}
+
+func TestFp12Inversion(t *testing.T) {
+	field := newFp12(nil)
+	for i := 0; i < fuz; i++ {
+		u := field.new()
+		zero := field.zero()
+		one := field.one()
+		field.inverse(u, zero)
+		if !u.equal(zero) {
+			t.Fatal("(0^-1) == 0)")
+		}
+		field.inverse(u, one)
+		if !u.equal(one) {
+			t.Fatal("(1^-1) == 1)")
+		}
+		a, _ := new(fe12).rand(rand.Reader)
+		field.inverse(u, a)
+		field.mul(u, u, a)
+		if !u.equal(one) {
+			t.Fatal("(r*a) * r*(a^-1) == r)")
+		}
+	}
+}
+
+func TestFrobeniusMapping2(t *testing.T) {
+	f := newFp2()
+	a, _ := new(fe2).rand(rand.Reader)
+	b0, b1, b2, b3 := new(fe2), new(fe2), new(fe2), new(fe2)
+	f.exp(b0, a, modulus.big())
+	f.conjugate(b1, a)
+	b2.set(a)
+	f.frobeniusMap1(b2)
+	b3.set(a)
+	f.frobeniusMap(b3, 1)
+	if !b0.equal(b3) {
+		t.Fatal("frobenius map failed")
+	}
+	if !b1.equal(b3) {
+		t.Fatal("frobenius map failed")
+	}
+	if !b2.equal(b3) {
+		t.Fatal("frobenius map failed")
+	}
+}
+
+func TestFrobeniusMapping6(t *testing.T) {
+	{
+		f := newFp2()
+		z := nonResidue2
+		for i := 0; i < 6; i++ {
+			p, r, e := modulus.big(), new(fe2), big.NewInt(0)
+			// p ^ i
+			p.Exp(p, big.NewInt(int64(i)), nil)
+			// (p ^ i - 1) / 3
+			e.Sub(p, big.NewInt(1)).Div(e, big.NewInt(3))
+			// r = z ^ (p ^ i - 1) / 3
+			f.exp(r, z, e)
+			if !r.equal(&frobeniusCoeffs61[i]) {
+				t.Fatalf("bad frobenius fp6 1q coefficient")
+			}
+		}
+		for i := 0; i < 6; i++ {
+			p, r, e := modulus.big(), new(fe2), big.NewInt(0)
+			// p ^ i
+			p.Exp(p, big.NewInt(int64(i)), nil).Mul(p, big.NewInt(2))
+			// (2 * p ^ i - 2) / 3
+			e.Sub(p, big.NewInt(2)).Div(e, big.NewInt(3))
+			// r = z ^ (2 * p ^ i - 2) / 3
+			f.exp(r, z, e)
+			if !r.equal(&frobeniusCoeffs62[i]) {
+				t.Fatalf("bad frobenius fp6 2q coefficient")
+			}
+		}
+	}
+	f := newFp6(nil)
+	r0, r1 := f.new(), f.new()
+	e, _ := new(fe6).rand(rand.Reader)
+	r0.set(e)
+	r1.set(e)
+	f.frobeniusMap(r1, 1)
+	f.frobeniusMap1(r0)
+	if !r0.equal(r1) {
+		t.Fatalf("frobenius mapping by 1 failed")
+	}
+	r0.set(e)
+	r1.set(e)
+	f.frobeniusMap(r1, 2)
+	f.frobeniusMap2(r0)
+	if !r0.equal(r1) {
+		t.Fatalf("frobenius mapping by 2 failed")
+	}
+	r0.set(e)
+	r1.set(e)
+	f.frobeniusMap(r1, 3)
+	f.frobeniusMap3(r0)
+	if !r0.equal(r1) {
+		t.Fatalf("frobenius mapping by 3 failed")
+	}
+}
+
+func TestFrobeniusMapping12(t *testing.T) {
+	{
+		f := newFp2()
+		z := nonResidue2
+		for i := 0; i < 12; i++ {
+			p, r, e := modulus.big(), new(fe2), big.NewInt(0)
+			// p ^ i
+			p.Exp(p, big.NewInt(int64(i)), nil)
+			// (p ^ i - 1) / 6
+			e.Sub(p, big.NewInt(1)).Div(e, big.NewInt(6))
+			// r = z ^ (p ^ i - 1) / 6
+			f.exp(r, z, e)
+			if !r.equal(&frobeniusCoeffs12[i]) {
+				t.Fatalf("bad frobenius fp12 coefficient")
+			}
+		}
+	}
+	f := newFp12(nil)
+	r0, r1 := f.new(), f.new()
+	e, _ := new(fe12).rand(rand.Reader)
+	p := modulus.big()
+	f.exp(r0, e, p)
+	r1.set(e)
+	f.frobeniusMap1(r1)
+	if !r0.equal(r1) {
+		t.Fatalf("frobenius mapping by 1 failed")
+	}
+	p.Mul(p, modulus.big())
+	f.exp(r0, e, p)
+	r1.set(e)
+	f.frobeniusMap2(r1)
+	if !r0.equal(r1) {
+		t.Fatalf("frobenius mapping by 2 failed")
+	}
+	p.Mul(p, modulus.big())
+	f.exp(r0, e, p)
+	r1.set(e)
+	f.frobeniusMap3(r1)
+	if !r0.equal(r1) {
+		t.Fatalf("frobenius mapping by 2 failed")
+	}
+}
+
+func BenchmarkMultiplication(t *testing.B) {
+	a, _ := new(fe).rand(rand.Reader)
+	b, _ := new(fe).rand(rand.Reader)
+	c, _ := new(fe).rand(rand.Reader)
+	t.ResetTimer()
+	for i := 0; i < t.N; i++ {
+		mul(c, a, b)
+	}
+}
+
+func padBytes(in []byte, size int) []byte {
+	out := make([]byte, size)
+	if len(in) > size {
+		panic("bad input for padding")
+	}
+	copy(out[size-len(in):], in)
+	return out
+}
@@ -0,0 +1,533 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls12381
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// PointG2 is type for point in G2 and used for both affine and Jacobian representation.
+// A point is accounted as in affine form if z is equal to one.
+type PointG2 [3]fe2
+
+// Set copies valeus of one point to another.
+func (p *PointG2) Set(p2 *PointG2) *PointG2 {
+	p[0].set(&p2[0])
+	p[1].set(&p2[1])
+	p[2].set(&p2[2])
+	return p
+}
+
+func (p *PointG2) Zero() *PointG2 {
+	p[0].zero()
+	p[1].one()
+	p[2].zero()
+	return p
+}
+
+// IsAffine checks a G1 point whether it is in affine form.
+func (p *PointG2) IsAffine() bool {
+	return p[2].isOne()
+}
+
+type tempG2 struct {
+	t [9]*fe2
+}
+
+// G2 is struct for G2 group.
+type G2 struct {
+	f *fp2
+	tempG2
+}
+
+// NewG2 constructs a new G2 instance.
+func NewG2() *G2 {
+	return newG2(nil)
+}
+
+func newG2(f *fp2) *G2 {
+	if f == nil {
+		f = newFp2()
+	}
+	t := newTempG2()
+	return &G2{f, t}
+}
+
+func newTempG2() tempG2 {
+	t := [9]*fe2{}
+	for i := 0; i < 9; i++ {
+		t[i] = &fe2{}
+	}
+	return tempG2{t}
+}
+
+// FromBytes constructs a new point given uncompressed byte input.
+// Input string expected to be 192 bytes and concatenation of x and y values
+// Point (0, 0) is considered as infinity.
+func (g *G2) FromBytes(in []byte) (*PointG2, error) {
+	if len(in) != 4*fpByteSize {
+		return nil, errors.New("input string length must be equal to 192 bytes")
+	}
+	p0, err := g.f.fromBytes(in[:2*fpByteSize])
+	if err != nil {
+		return nil, err
+	}
+	p1, err := g.f.fromBytes(in[2*fpByteSize:])
+	if err != nil {
+		return nil, err
+	}
+	// check if given input points to infinity
+	if p0.isZero() && p1.isZero() {
+		return g.Zero(), nil
+	}
+	p2 := new(fe2).one()
+	p := &PointG2{*p0, *p1, *p2}
+	if !g.IsOnCurve(p) {
+		return nil, errors.New("point is not on curve")
+	}
+	return p, nil
+}
+
+// ToBytes serializes a point into bytes in uncompressed form,
+// returns (0, 0) if point is infinity.
+func (g *G2) ToBytes(p *PointG2) []byte {
+	out := make([]byte, 4*fpByteSize)
+	if g.IsZero(p) {
+		return out
+	}
+	g.Affine(p)
+	copy(out[:2*fpByteSize], g.f.toBytes(&p[0]))
+	copy(out[2*fpByteSize:], g.f.toBytes(&p[1]))
+	return out
+}
+
+// DecodePoint given encoded (x, y) coordinates in 256 bytes returns a valid G2 Point.
+func (g *G2) DecodePoint(in []byte) (*PointG2, error) {
+	if len(in) != encodedG2PointSize {
+		return nil, errors.New("invalid g2 point length")
+	}
+	pointBytes := make([]byte, 4*fpByteSize)
+	x0Bytes, err := decodeFieldElement(in[:64])
+	if err != nil {
+		return nil, err
+	}
+	x1Bytes, err := decodeFieldElement(in[64:128])
+	if err != nil {
+		return nil, err
+	}
+	y0Bytes, err := decodeFieldElement(in[128:192])
+	if err != nil {
+		return nil, err
+	}
+	y1Bytes, err := decodeFieldElement(in[192:])
+	if err != nil {
+		return nil, err
+	}
+	copy(pointBytes[:fpByteSize], x1Bytes)
+	copy(pointBytes[fpByteSize:2*fpByteSize], x0Bytes)
+	copy(pointBytes[2*fpByteSize:3*fpByteSize], y1Bytes)
+	copy(pointBytes[3*fpByteSize:], y0Bytes)
+	return g.FromBytes(pointBytes)
+}
+
+// EncodePoint encodes a point into 256 bytes.
+func (g *G2) EncodePoint(p *PointG2) []byte {
+	outRaw := g.ToBytes(p)
+	out := make([]byte, encodedG2PointSize)
+	// encode x
+	copy(out[16:16+fpByteSize], outRaw[fpByteSize:2*fpByteSize])
+	copy(out[80:80+fpByteSize], outRaw[:fpByteSize])
+	// encode y
+	copy(out[144:144+fpByteSize], outRaw[3*fpByteSize:])
+	copy(out[208:208+fpByteSize], outRaw[2*fpByteSize:3*fpByteSize])
+	return out
+}
+
+// New creates a new G2 Point which is equal to zero in other words point at infinity.
+func (g *G2) New() *PointG2 {
+	return new(PointG2).Zero()
+}
+
+// Zero returns a new G2 Point which is equal to point at infinity.
+func (g *G2) Zero() *PointG2 {
+	return new(PointG2).Zero()
+}
+
+// One returns a new G2 Point which is equal to generator point.
+func (g *G2) One() *PointG2 {
+	p := &PointG2{}
+	return p.Set(&g2One)
+}
+
+// IsZero returns true if given point is equal to zero.
+func (g *G2) IsZero(p *PointG2) bool {
+	return p[2].isZero()
+}
+
+// Equal checks if given two G2 point is equal in their affine form.
+func (g *G2) Equal(p1, p2 *PointG2) bool {
+	if g.IsZero(p1) {
+		return g.IsZero(p2)
+	}
+	if g.IsZero(p2) {
+		return g.IsZero(p1)
+	}
+	t := g.t
+	g.f.square(t[0], &p1[2])
+	g.f.square(t[1], &p2[2])
+	g.f.mul(t[2], t[0], &p2[0])
+	g.f.mul(t[3], t[1], &p1[0])
+	g.f.mulAssign(t[0], &p1[2])
+	g.f.mulAssign(t[1], &p2[2])
+	g.f.mulAssign(t[1], &p1[1])
+	g.f.mulAssign(t[0], &p2[1])
+	return t[0].equal(t[1]) && t[2].equal(t[3])
+}
+
+// IsOnCurve checks a G2 point is on curve.
+func (g *G2) IsOnCurve(p *PointG2) bool {
+	if g.IsZero(p) {
+		return true
+	}
+	t := g.t
+	g.f.square(t[0], &p[1])    // y^2
+	g.f.square(t[1], &p[0])    // x^2
+	g.f.mul(t[1], t[1], &p[0]) // x^3
+	if p.IsAffine() {
+		g.f.add(t[1], t[1], b2) // x^2 + b
+		return t[0].equal(t[1]) // y^2 ?= x^3 + b
+	}
+	g.f.square(t[2], &p[2])   // z^2
+	g.f.square(t[3], t[2])    // z^4
+	g.f.mulAssign(t[2], t[3]) // z^6
+	g.f.mulAssign(t[2], b2)   // b*z^6
+	g.f.addAssign(t[1], t[2]) // x^3 + b * z^6
+	return t[0].equal(t[1])   // y^2 ?= x^3 + b * z^6
+}
+
+// IsAffine checks a G2 point whether it is in affine form.
+func (g *G2) IsAffine(p *PointG2) bool {
+	return p[2].isOne()
+}
+
+// Affine calculates affine form of given G2 point.
+func (g *G2) Affine(p *PointG2) *PointG2 {
+	return g.affine(p, p)
+}
+
+func (g *G2) affine(r, p *PointG2) *PointG2 {
+	if g.IsZero(p) {
+		return r.Zero()
+	}
+	if !g.IsAffine(p) {
+		t := g.t
+		g.f.inverse(t[0], &p[2])   // z^-1
+		g.f.square(t[1], t[0])     // z^-2
+		g.f.mulAssign(&r[0], t[1]) // x = x * z^-2
+		g.f.mulAssign(t[0], t[1])  // z^-3
+		g.f.mulAssign(&r[1], t[0]) // y = y * z^-3
+		r[2].one()                 // z = 1
+	} else {
+		r.Set(p)
+	}
+	return r
+}
+
+// Add adds two G2 points p1, p2 and assigns the result to point at first argument.
+func (g *G2) Add(r, p1, p2 *PointG2) *PointG2 {
+	// http://www.hyperelliptic.org/EFD/gp/auto-shortw-jacobian-0.html#addition-add-2007-bl
+	if g.IsZero(p1) {
+		return r.Set(p2)
+	}
+	if g.IsZero(p2) {
+		return r.Set(p1)
+	}
+	if g.IsAffine(p2) {
+		return g.AddMixed(r, p1, p2)
+	}
+	t := g.t
+	g.f.square(t[7], &p1[2])    // z1z1
+	g.f.mul(t[1], &p2[0], t[7]) // u2 = x2 * z1z1
+	g.f.mul(t[2], &p1[2], t[7]) // z1z1 * z1
+	g.f.mul(t[0], &p2[1], t[2]) // s2 = y2 * z1z1 * z1
+	g.f.square(t[8], &p2[2])    // z2z2
+	g.f.mul(t[3], &p1[0], t[8]) // u1 = x1 * z2z2
+	g.f.mul(t[4], &p2[2], t[8]) // z2z2 * z2
+	g.f.mul(t[2], &p1[1], t[4]) // s1 = y1 * z2z2 * z2
+	if t[1].equal(t[3]) {
+		if t[0].equal(t[2]) {
+			return g.Double(r, p1)
+		} else {
+			return r.Zero()
+		}
+	}
+	g.f.subAssign(t[1], t[3])     // h = u2 - u1
+	g.f.double(t[4], t[1])        // 2h
+	g.f.squareAssign(t[4])        // i = 2h^2
+	g.f.mul(t[5], t[1], t[4])     // j = h*i
+	g.f.subAssign(t[0], t[2])     // s2 - s1
+	g.f.doubleAssign(t[0])        // r = 2*(s2 - s1)
+	g.f.square(t[6], t[0])        // r^2
+	g.f.subAssign(t[6], t[5])     // r^2 - j
+	g.f.mulAssign(t[3], t[4])     // v = u1 * i
+	g.f.double(t[4], t[3])        // 2*v
+	g.f.sub(&r[0], t[6], t[4])    // x3 = r^2 - j - 2*v
+	g.f.sub(t[4], t[3], &r[0])    // v - x3
+	g.f.mul(t[6], t[2], t[5])     // s1 * j
+	g.f.doubleAssign(t[6])        // 2 * s1 * j
+	g.f.mulAssign(t[0], t[4])     // r * (v - x3)
+	g.f.sub(&r[1], t[0], t[6])    // y3 = r * (v - x3) - (2 * s1 * j)
+	g.f.add(t[0], &p1[2], &p2[2]) // z1 + z2
+	g.f.squareAssign(t[0])        // (z1 + z2)^2
+	g.f.subAssign(t[0], t[7])     // (z1 + z2)^2 - z1z1
+	g.f.subAssign(t[0], t[8])     // (z1 + z2)^2 - z1z1 - z2z2
+	g.f.mul(&r[2], t[0], t[1])    // z3 = ((z1 + z2)^2 - z1z1 - z2z2) * h
+	return r
+}
+
+// Add adds two G1 points p1, p2 and assigns the result to point at first argument.
+// Expects the second point p2 in affine form.
+func (g *G2) AddMixed(r, p1, p2 *PointG2) *PointG2 {
+	// http://www.hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-madd-2007-bl
+	if g.IsZero(p1) {
+		return r.Set(p2)
+	}
+	if g.IsZero(p2) {
+		return r.Set(p1)
+	}
+	t := g.t
+	g.f.square(t[7], &p1[2])    // z1z1
+	g.f.mul(t[1], &p2[0], t[7]) // u2 = x2 * z1z1
+	g.f.mul(t[2], &p1[2], t[7]) // z1z1 * z1
+	g.f.mul(t[0], &p2[1], t[2]) // s2 = y2 * z1z1 * z1
+
+	if p1[0].equal(t[1]) && p1[1].equal(t[0]) {
+		return g.Double(r, p1)
+	}
+
+	g.f.sub(t[1], t[1], &p1[0]) // h = u2 - x1
+	g.f.square(t[2], t[1])      // hh
+	g.f.double(t[4], t[2])
+	g.f.doubleAssign(t[4])      // 4hh
+	g.f.mul(t[5], t[1], t[4])   // j = h*i
+	g.f.subAssign(t[0], &p1[1]) // s2 - y1
+	g.f.doubleAssign(t[0])      // r = 2*(s2 - y1)
+	g.f.square(t[6], t[0])      // r^2
+	g.f.subAssign(t[6], t[5])   // r^2 - j
+	g.f.mul(t[3], &p1[0], t[4]) // v = x1 * i
+	g.f.double(t[4], t[3])      // 2*v
+	g.f.sub(&r[0], t[6], t[4])  // x3 = r^2 - j - 2*v
+	g.f.sub(t[4], t[3], &r[0])  // v - x3
+	g.f.mul(t[6], &p1[1], t[5]) // y1 * j
+	g.f.doubleAssign(t[6])      // 2 * y1 * j
+	g.f.mulAssign(t[0], t[4])   // r * (v - x3)
+	g.f.sub(&r[1], t[0], t[6])  // y3 = r * (v - x3) - (2 * y1 * j)
+	g.f.add(t[0], &p1[2], t[1]) // z1 + h
+	g.f.squareAssign(t[0])      // (z1 + h)^2
+	g.f.subAssign(t[0], t[7])   // (z1 + h)^2 - z1z1
+	g.f.sub(&r[2], t[0], t[2])  // z3 = (z1 + z2)^2 - z1z1 - hh
+	return r
+}
+
+// Double doubles a G2 point p and assigns the result to the point at first argument.
+func (g *G2) Double(r, p *PointG2) *PointG2 {
+	// http://www.hyperelliptic.org/EFD/gp/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
+	if g.IsZero(p) {
+		return r.Set(p)
+	}
+	t := g.t
+	g.f.square(t[0], &p[0])     // a = x^2
+	g.f.square(t[1], &p[1])     // b = y^2
+	g.f.square(t[2], t[1])      // c = b^2
+	g.f.addAssign(t[1], &p[0])  // b + x1
+	g.f.squareAssign(t[1])      // (b + x1)^2
+	g.f.subAssign(t[1], t[0])   // (b + x1)^2 - a
+	g.f.subAssign(t[1], t[2])   // (b + x1)^2 - a - c
+	g.f.doubleAssign(t[1])      // d = 2((b+x1)^2 - a - c)
+	g.f.double(t[3], t[0])      // 2a
+	g.f.addAssign(t[0], t[3])   // e = 3a
+	g.f.square(t[4], t[0])      // f = e^2
+	g.f.double(t[3], t[1])      // 2d
+	g.f.sub(&r[0], t[4], t[3])  // x3 = f - 2d
+	g.f.sub(t[1], t[1], &r[0])  // d-x3
+	g.f.doubleAssign(t[2])      //
+	g.f.doubleAssign(t[2])      //
+	g.f.doubleAssign(t[2])      // 8c
+	g.f.mulAssign(t[0], t[1])   // e * (d - x3)
+	g.f.sub(t[1], t[0], t[2])   // x3 = e * (d - x3) - 8c
+	g.f.mul(t[0], &p[1], &p[2]) // y1 * z1
+	r[1].set(t[1])              //
+	g.f.double(&r[2], t[0])     // z3 = 2(y1 * z1)
+	return r
+}
+
+// Neg negates a G2 point p and assigns the result to the point at first argument.
+func (g *G2) Neg(r, p *PointG2) *PointG2 {
+	r[0].set(&p[0])
+	g.f.neg(&r[1], &p[1])
+	r[2].set(&p[2])
+	return r
+}
+
+// Sub subtracts two G2 points p1, p2 and assigns the result to point at first argument.
+func (g *G2) Sub(c, a, b *PointG2) *PointG2 {
+	d := &PointG2{}
+	g.Neg(d, b)
+	g.Add(c, a, d)
+	return c
+}
+
+// MulScalar multiplies a point by given scalar value in big.Int and assigns the result to point at first argument.
+func (g *G2) MulScalar(c, p *PointG2, e *big.Int) *PointG2 {
+	q, n := &PointG2{}, &PointG2{}
+	n.Set(p)
+	l := e.BitLen()
+	for i := 0; i < l; i++ {
+		if e.Bit(i) == 1 {
+			g.Add(q, q, n)
+		}
+		g.Double(n, n)
+	}
+	return c.Set(q)
+}
+
+// MultiExp calculates multi exponentiation. Given pairs of G2 point and scalar values
+// (P_0, e_0), (P_1, e_1), ... (P_n, e_n) calculates r = e_0 * P_0 + e_1 * P_1 + ... + e_n * P_n
+// Length of points and scalars are expected to be equal, otherwise an error is returned.
+// Result is assigned to point at first argument.
+func (g *G2) MultiExp(r *PointG2, points []*PointG2, scalars []*big.Int) (*PointG2, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("point and scalar vectors should be in same length")
+	}
+
+	c := 3
+	if len(scalars) >= 32 {
+		c = int(math.Ceil(math.Log(float64(len(scalars)))))
+	}
+
+	bucketSize := (1 << c) - 1
+	windows := make([]PointG2, scalarBitSize/c+1)
+	bucket := make([]PointG2, bucketSize)
+
+	for j := 0; j < len(windows); j++ {
+
+		for i := 0; i < bucketSize; i++ {
+			bucket[i].Zero()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			index := bucketSize & int(windowOf(scalars[i], c*j, c))
+			if index != 0 {
+				g.Add(&bucket[index-1], &bucket[index-1], points[i])
+			}
+		}
+
+		acc, sum := g.New(), g.New()
+		for i := bucketSize - 1; i >= 0; i-- {
+			g.Add(sum, sum, &bucket[i])
+			g.Add(acc, acc, sum)
+		}
+		windows[j].Set(acc)
+	}
+
+	acc := g.New()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < c; j++ {
+			g.Double(acc, acc)
+		}
+		g.Add(acc, acc, &windows[i])
+	}
+	return r.Set(acc), nil
+}
+
+// InCorrectSubgroup checks whether given point is in correct subgroup.
+func (g *G2) InCorrectSubgroup(p *PointG2) bool {
+	tmp := &PointG2{}
+	g.MulScalar(tmp, p, q)
+	return g.IsZero(tmp)
+}
+
+// ClearCofactor maps given a G2 point to correct subgroup
+func (g *G2) ClearCofactor(p *PointG2) *PointG2 {
+
+	// Efficient hash maps to G2 on BLS curves
+	// A. Budroni, F. Pintore
+	// https://eprint.iacr.org/2017/419.pdf
+
+	// [h(ψ)]P = [x^2 − x − 1]P + [x − 1]ψ(P) + ψ^2(2P)
+	t0, t1, t2, t3 := g.New().Set(p), g.New().Set(p), g.New().Set(p), g.New()
+
+	g.Double(t0, t0)
+	g.psi(t0)
+	g.psi(t0)  // P2 = ψ^2(2P)
+	g.psi(t2)  // P1 = ψ(P)
+	g.mulX(t1) // -xP0
+
+	g.Sub(t3, t1, t2) // -xP0 - P1
+	g.mulX(t3)        // (x^2)P0 + xP1
+	g.Sub(t1, t1, p)  // (-x-1)P0
+	g.Add(t3, t3, t1) // (x^2-x-1)P0 + xP1
+	g.Sub(t3, t3, t2) // (x^2-x-1)P0 + (x-1)P1
+	g.Add(t3, t3, t0) // (x^2-x-1)P0 + (x-1)P1 + P2
+	return p.Set(t3)
+}
+
+func (g *G2) psi(p *PointG2) {
+	g.f.conjugate(&p[0], &p[0])
+	g.f.conjugate(&p[1], &p[1])
+	g.f.conjugate(&p[2], &p[2])
+	g.f.mul(&p[0], &p[0], &psix)
+	g.f.mul(&p[1], &p[1], &psiy)
+}
+
+func (g *G2) mulX(p *PointG2) {
+
+	chain := func(p0 *PointG2, n int, p1 *PointG2) {
+		g.Add(p0, p0, p1)
+		for i := 0; i < n; i++ {
+			g.Double(p0, p0)
+		}
+	}
+
+	t := g.New().Set(p)
+	g.Double(p, t)
+	chain(p, 2, t)
+	chain(p, 3, t)
+	chain(p, 9, t)
+	chain(p, 32, t)
+	chain(p, 16, t)
+}
+
+// MapToCurve given a byte slice returns a valid G2 point.
+// This mapping function implements the Simplified Shallue-van de Woestijne-Ulas method.
+// https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-05#section-6.6.2
+// Input byte slice should be a valid field element, otherwise an error is returned.
+func (g *G2) MapToCurve(in []byte) (*PointG2, error) {
+	fp2 := g.f
+	u, err := fp2.fromBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	x, y := swuMapG2(fp2, u)
+	isogenyMapG2(fp2, x, y)
+	z := new(fe2).one()
+	q := &PointG2{*x, *y, *z}
+	g.ClearCofactor(q)
+	return g.Affine(q), nil
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -313,6 +314,17 @@ func NewPrivateDebugAPI(eth *Ethereum) *PrivateDebugAPI {
 	return &PrivateDebugAPI{eth: eth}
 }
 
+// NewOfflineDebugAPI creates the private debug methods of a chain outside of an
+// Ethereum service, for offline tools. Only the methods relying on the chain and
+// its database alone, like tracing its blocks, are available.
+func NewOfflineDebugAPI(chain *core.BlockChain, chainDb ethdb.Database) *PrivateDebugAPI {
+	return &PrivateDebugAPI{eth: &Ethereum{
+		blockchain: chain,
+		chainDb:    chainDb,
+		engine:     chain.Engine(),
+	}}
+}
+
 // Preimage is a debug API function that returns the preimage for a sha3 hash, if known.
 func (api *PrivateDebugAPI) Preimage(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	if preimage := rawdb.ReadPreimage(api.eth.ChainDb(), hash); preimage != nil {
@@ -323,21 +335,41 @@ func (api *PrivateDebugAPI) Preimage(ctx context.Context, hash common.Hash) (hex
 
 // BadBlockArgs represents the entries in the list returned when bad blocks are queried.
 type BadBlockArgs struct {
-	Hash  common.Hash            `json:"hash"`
-	Block map[string]interface{} `json:"block"`
-	RLP   string                 `json:"rlp"`
+	Hash       common.Hash            `json:"hash"`
+	Block      map[string]interface{} `json:"block"`
+	RLP        string                 `json:"rlp"`
+	Reason     string                 `json:"reason"`
+	Peer       string                 `json:"peer"`
+	Time       hexutil.Uint64         `json:"time"`
+	ConfigHash common.Hash            `json:"configHash"`
+	Computed   *BadBlockResults       `json:"computed"`
+}
+
+// BadBlockResults represents the results of the execution of a bad block, to be
+// compared to the ones of its header.
+type BadBlockResults struct {
+	StateRoot    common.Hash    `json:"stateRoot"`
+	ReceiptsRoot common.Hash    `json:"receiptsRoot"`
+	LogsBloom    types.Bloom    `json:"logsBloom"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
 }
 
 // GetBadBlocks returns a list of the last 'bad blocks' that the client has seen on the network
-// and returns them as a JSON list of block-hashes
+// and returns them as a JSON list of block-hashes. The blocks are kept across restarts, along
+// with the reasons of their rejection and the results of their execution, if executed.
 func (api *PrivateDebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error) {
-	blocks := api.eth.BlockChain().BadBlocks()
-	results := make([]*BadBlockArgs, len(blocks))
+	bad := rawdb.ReadAllBadBlocks(api.eth.ChainDb())
+	results := make([]*BadBlockArgs, len(bad))
 
 	var err error
-	for i, block := range blocks {
+	for i, b := range bad {
+		block := b.Block()
 		results[i] = &BadBlockArgs{
-			Hash: block.Hash(),
+			Hash:       block.Hash(),
+			Reason:     b.Reason,
+			Peer:       b.Peer,
+			Time:       hexutil.Uint64(b.Time),
+			ConfigHash: b.ConfigHash,
 		}
 		if rlpBytes, err := rlp.EncodeToBytes(block); err != nil {
 			results[i].RLP = err.Error() // Hacky, but hey, it works
@@ -347,6 +379,14 @@ func (api *PrivateDebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs,
 		if results[i].Block, err = ethapi.RPCMarshalBlock(block, true, true); err != nil {
 			results[i].Block = map[string]interface{}{"error": err.Error()}
 		}
+		if b.Executed() {
+			results[i].Computed = &BadBlockResults{
+				StateRoot:    b.Root,
+				ReceiptsRoot: b.ReceiptHash,
+				LogsBloom:    b.Bloom,
+				GasUsed:      hexutil.Uint64(b.GasUsed),
+			}
+		}
 	}
 	return results, nil
 }
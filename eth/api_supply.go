@@ -0,0 +1,145 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxIssuanceRange is the maximum number of blocks an issuance can be summed up
+// over in a single request.
+const maxIssuanceRange = 100000
+
+var errSupplyIndexDisabled = errors.New("supply index disabled, enable it with --supplyindex")
+
+// PublicSupplyAPI provides an API to access the ether supply and issuance of the
+// blocks indexed by the supply indexer.
+type PublicSupplyAPI struct {
+	e *Ethereum
+}
+
+// NewPublicSupplyAPI creates a new ether supply API for full nodes.
+func NewPublicSupplyAPI(e *Ethereum) *PublicSupplyAPI {
+	return &PublicSupplyAPI{e}
+}
+
+// IssuanceResult is the ether issued and burnt by a range of blocks, along with
+// the total supply after them.
+type IssuanceResult struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+	Reward    *hexutil.Big   `json:"reward"`
+	Uncles    *hexutil.Big   `json:"uncleRewards"`
+	Alloc     *hexutil.Big   `json:"genesisAlloc"`
+	Burnt     *hexutil.Big   `json:"burnt"`
+	Issuance  *hexutil.Big   `json:"issuance"`
+	Supply    *hexutil.Big   `json:"supply"`
+}
+
+// SupplyAt returns the total ether supply after the given block.
+func (api *PublicSupplyAPI) SupplyAt(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	header, err := api.e.APIBackend.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	issuance, err := api.issuance(header)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(issuance.Supply), nil
+}
+
+// Issuance returns the ether issued and burnt by the blocks in the given range,
+// both ends included, and the total supply after them.
+func (api *PublicSupplyAPI) Issuance(ctx context.Context, from, to rpc.BlockNumber) (*IssuanceResult, error) {
+	first, err := api.e.APIBackend.HeaderByNumber(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	last, err := api.e.APIBackend.HeaderByNumber(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil || last == nil {
+		return nil, errors.New("block not found")
+	}
+	start, end := first.Number.Uint64(), last.Number.Uint64()
+	if start > end {
+		return nil, fmt.Errorf("invalid block range %d-%d", start, end)
+	}
+	if end-start >= maxIssuanceRange {
+		return nil, fmt.Errorf("block range %d-%d exceeds the maximum of %d blocks", start, end, maxIssuanceRange)
+	}
+	var (
+		reward, uncles, alloc, burnt = new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+		issuance                     *rawdb.Issuance
+	)
+	for number := start; number <= end; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		header := last
+		if number < end {
+			header = api.e.blockchain.GetHeaderByNumber(number)
+		}
+		if issuance, err = api.issuance(header); err != nil {
+			return nil, err
+		}
+		reward.Add(reward, issuance.Reward)
+		uncles.Add(uncles, issuance.Uncles)
+		alloc.Add(alloc, issuance.Alloc)
+		burnt.Add(burnt, issuance.Burnt)
+	}
+	issued := new(big.Int).Add(reward, uncles)
+	issued.Add(issued, alloc)
+	issued.Sub(issued, burnt)
+
+	return &IssuanceResult{
+		FromBlock: hexutil.Uint64(start),
+		ToBlock:   hexutil.Uint64(end),
+		Reward:    (*hexutil.Big)(reward),
+		Uncles:    (*hexutil.Big)(uncles),
+		Alloc:     (*hexutil.Big)(alloc),
+		Burnt:     (*hexutil.Big)(burnt),
+		Issuance:  (*hexutil.Big)(issued),
+		Supply:    (*hexutil.Big)(issuance.Supply),
+	}, nil
+}
+
+// issuance retrieves the indexed issuance of the block with the given header.
+func (api *PublicSupplyAPI) issuance(header *types.Header) (*rawdb.Issuance, error) {
+	if api.e.supplyIndexer == nil {
+		return nil, errSupplyIndexDisabled
+	}
+	if header == nil {
+		return nil, errors.New("block not found")
+	}
+	issuance := rawdb.ReadIssuance(api.e.chainDb, header.Number.Uint64(), header.Hash())
+	if issuance == nil {
+		sections, _, _ := api.e.supplyIndexer.Sections()
+		return nil, fmt.Errorf("supply of block #%d not indexed yet, %d blocks indexed", header.Number, sections*SupplySectionSize)
+	}
+	return issuance, nil
+}
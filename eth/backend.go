@@ -31,10 +31,12 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/dev"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -90,6 +92,8 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	supplyIndexer *core.ChainIndexer // Supply indexer operating during block imports, if enabled
+
 	APIBackend *EthAPIBackend
 
 	miner     *miner.Miner
@@ -145,18 +149,29 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
-	chainConfig, genesisHash, genesisErr := core.SetupGenesisBlock(chainDb, config.Genesis)
+	// Finish an interrupted offline state pruning before the chain is loaded
+	if err := pruner.RecoverPruning(ctx.ResolvePath(""), chainDb); err != nil {
+		log.Error("Failed to recover state", "err", err)
+	}
+	chainConfig, genesisHash, genesisErr := core.SetupGenesisBlockWithOverride(chainDb, config.Genesis, config.OverrideTransitions)
 	if _, ok := genesisErr.(*confp.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
 	}
 	log.Info("Initialised chain configuration", "config", chainConfig)
 
+	var engine consensus.Engine
+	if config.InstantSeal {
+		log.Warn("Blocks sealed by the developer engine", "period", config.InstantSealPeriod)
+		engine = dev.New(chainConfig, config.InstantSealPeriod)
+	} else {
+		engine = CreateConsensusEngine(ctx, chainConfig, &config.Ethash, config.Miner.Notify, config.Miner.Noverify, chainDb)
+	}
 	eth := &Ethereum{
 		config:            config,
 		chainDb:           chainDb,
 		eventMux:          ctx.EventMux,
 		accountManager:    ctx.AccountManager,
-		engine:            CreateConsensusEngine(ctx, chainConfig, &config.Ethash, config.Miner.Notify, config.Miner.Noverify, chainDb),
+		engine:            engine,
 		closeBloomHandler: make(chan struct{}),
 		networkID:         config.NetworkId,
 		gasPrice:          config.Miner.GasPrice,
@@ -206,6 +221,13 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		rawdb.WriteChainConfig(chainDb, genesisHash, chainConfig)
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
+	if config.SupplyIndex {
+		eth.supplyIndexer = NewSupplyIndexer(chainDb, eth.blockchain, SupplySectionSize, supplyConfirms)
+		eth.supplyIndexer.Start(eth.blockchain)
+	}
+	if c, ok := eth.engine.(*clique.Clique); ok {
+		c.StartStatusMetrics(eth.blockchain)
+	}
 
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
@@ -227,6 +249,9 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 	}
 	eth.miner = miner.New(eth, &config.Miner, chainConfig, eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
+	if config.InstantSeal {
+		eth.miner.DisablePreseal() // Sealing is instant, don't seal empty blocks in advance
+	}
 
 	eth.APIBackend = &EthAPIBackend{ctx.ExtRPCEnabled(), eth, nil}
 	gpoParams := config.GPO
@@ -267,6 +292,9 @@ func CreateConsensusEngine(ctx *node.ServiceContext, chainConfig ctypes.ChainCon
 		return clique.New(&ctypes.CliqueConfig{
 			Period: chainConfig.GetCliquePeriod(),
 			Epoch:  chainConfig.GetCliqueEpoch(),
+
+			OnDemandBlock: chainConfig.GetCliqueOnDemandTransition(),
+			MaxIdle:       chainConfig.GetCliqueMaxIdle(),
 		}, db)
 	}
 	// Otherwise assume proof-of-work
@@ -282,14 +310,18 @@ func CreateConsensusEngine(ctx *node.ServiceContext, chainConfig ctypes.ChainCon
 		return ethash.NewShared()
 	default:
 		engine := ethash.New(ethash.Config{
-			CacheDir:         ctx.ResolvePath(config.CacheDir),
-			CachesInMem:      config.CachesInMem,
-			CachesOnDisk:     config.CachesOnDisk,
-			CachesLockMmap:   config.CachesLockMmap,
-			DatasetDir:       config.DatasetDir,
-			DatasetsInMem:    config.DatasetsInMem,
-			DatasetsOnDisk:   config.DatasetsOnDisk,
-			DatasetsLockMmap: config.DatasetsLockMmap,
+			CacheDir:          ctx.ResolvePath(config.CacheDir),
+			CachesInMem:       config.CachesInMem,
+			CachesOnDisk:      config.CachesOnDisk,
+			CachesLockMmap:    config.CachesLockMmap,
+			DatasetDir:        config.DatasetDir,
+			DatasetsInMem:     config.DatasetsInMem,
+			DatasetsOnDisk:    config.DatasetsOnDisk,
+			DatasetsLockMmap:  config.DatasetsLockMmap,
+			StratumAddr:       config.StratumAddr,
+			StratumDifficulty: config.StratumDifficulty,
+			ECIP1099Block:     chainConfig.GetEthashECIP1099Transition(),
+			ECIP1049Block:     chainConfig.GetEthashECIP1049Transition(),
 		}, notify, noverify)
 		engine.SetThreads(-1) // Disable CPU mining
 		return engine
@@ -325,6 +357,11 @@ func (s *Ethereum) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicMinerAPI(s),
 			Public:    true,
+		}, {
+			Namespace: "eth",
+			Version:   "1.0",
+			Service:   NewPublicSupplyAPI(s),
+			Public:    true,
 		}, {
 			Namespace: "eth",
 			Version:   "1.0",
@@ -582,6 +619,9 @@ func (s *Ethereum) Stop() error {
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	if s.supplyIndexer != nil {
+		s.supplyIndexer.Close()
+	}
 	s.txPool.Stop()
 	s.miner.Stop()
 	s.blockchain.Stop()
@@ -109,6 +109,8 @@ type Config struct {
 
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 
+	SupplyIndex bool `toml:",omitempty"` // Whether to index the ether supply and issuance of each block
+
 	// Whitelist of required block number -> hash values to accept
 	Whitelist map[uint64]common.Hash `toml:"-"`
 
@@ -140,6 +142,12 @@ type Config struct {
 	// Ethash options
 	Ethash ethash.Config
 
+	// Developer engine options, replacing the consensus engine of the chain to
+	// seal blocks without proof-of-work every InstantSealPeriod seconds, or as
+	// soon as they have transactions if zero.
+	InstantSeal       bool   `toml:",omitempty"`
+	InstantSealPeriod uint64 `toml:",omitempty"`
+
 	// Transaction pool options
 	TxPool core.TxPoolConfig
 
@@ -166,4 +174,8 @@ type Config struct {
 
 	// CheckpointOracle is the configuration for checkpoint oracle.
 	CheckpointOracle *ctypes.CheckpointOracleConfig `toml:",omitempty"`
+
+	// OverrideTransitions overrides the named chain config transitions
+	// (see confp.TransitionNames) with the given block numbers.
+	OverrideTransitions map[string]uint64 `toml:",omitempty"`
 }
@@ -267,8 +267,8 @@ func (dl *downloadTester) InsertHeaderChain(headers []*types.Header, checkFreq i
 	return len(headers), nil
 }
 
-// InsertChain injects a new batch of blocks into the simulated chain.
-func (dl *downloadTester) InsertChain(blocks types.Blocks) (i int, err error) {
+// InsertChainFrom injects a new batch of blocks into the simulated chain.
+func (dl *downloadTester) InsertChainFrom(peer string, blocks types.Blocks) (i int, err error) {
 	dl.lock.Lock()
 	defer dl.lock.Unlock()
 
@@ -28,6 +28,7 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -233,6 +234,75 @@ func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, er
 	return rpcSub, nil
 }
 
+// ReorgBlock identifies a block of a chain reorganisation.
+type ReorgBlock struct {
+	Hash   common.Hash    `json:"hash"`
+	Number hexutil.Uint64 `json:"number"`
+}
+
+// Reorg is the notification of a chain reorganisation. The dropped and added
+// blocks are ordered by ascending number, the added ones ending with the new head.
+type Reorg struct {
+	CommonAncestor      ReorgBlock     `json:"commonAncestor"`
+	Depth               hexutil.Uint64 `json:"depth"`
+	Dropped             []ReorgBlock   `json:"dropped"`
+	Added               []ReorgBlock   `json:"added"`
+	RemovedTransactions []common.Hash  `json:"removedTransactions"`
+}
+
+// newReorg creates the notification of the given chain reorganisation.
+func newReorg(ev *core.ChainReorgEvent) *Reorg {
+	reorg := &Reorg{
+		CommonAncestor:      ReorgBlock{Hash: ev.CommonAncestor.Hash(), Number: hexutil.Uint64(ev.CommonAncestor.Number.Uint64())},
+		Depth:               hexutil.Uint64(len(ev.Dropped)),
+		Dropped:             make([]ReorgBlock, len(ev.Dropped)),
+		Added:               make([]ReorgBlock, len(ev.Added)),
+		RemovedTransactions: make([]common.Hash, len(ev.RemovedTxs)),
+	}
+	for i, block := range ev.Dropped {
+		reorg.Dropped[i] = ReorgBlock{Hash: block.Hash(), Number: hexutil.Uint64(block.NumberU64())}
+	}
+	for i, block := range ev.Added {
+		reorg.Added[i] = ReorgBlock{Hash: block.Hash(), Number: hexutil.Uint64(block.NumberU64())}
+	}
+	for i, tx := range ev.RemovedTxs {
+		reorg.RemovedTransactions[i] = tx.Hash()
+	}
+	return reorg
+}
+
+// Reorgs send a notification each time the canonical chain is reorganised, with
+// the common ancestor, the dropped and added blocks, and the transactions removed
+// from the canonical chain.
+func (api *PublicFilterAPI) Reorgs(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		reorgs := make(chan *core.ChainReorgEvent)
+		reorgsSub := api.events.SubscribeReorgs(reorgs)
+
+		for {
+			select {
+			case ev := <-reorgs:
+				notifier.Notify(rpcSub.ID, newReorg(ev))
+			case <-rpcSub.Err():
+				reorgsSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				reorgsSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
 func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
@@ -52,6 +52,8 @@ const (
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
+	// ReorgsSubscription queries for reorganisations of the canonical chain
+	ReorgsSubscription
 	// LastSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -66,6 +68,8 @@ const (
 	logsChanSize = 10
 	// chainEvChanSize is the size of channel listening to ChainEvent.
 	chainEvChanSize = 10
+	// reorgEvChanSize is the size of channel listening to ChainReorgEvent.
+	reorgEvChanSize = 10
 )
 
 type subscription struct {
@@ -76,6 +80,7 @@ type subscription struct {
 	logs      chan []*types.Log
 	hashes    chan []common.Hash
 	headers   chan *types.Header
+	reorgs    chan *core.ChainReorgEvent
 	installed chan struct{} // closed when the filter is installed
 	err       chan error    // closed when the filter is uninstalled
 }
@@ -93,6 +98,7 @@ type EventSystem struct {
 	rmLogsSub      event.Subscription // Subscription for removed log event
 	pendingLogsSub event.Subscription // Subscription for pending log event
 	chainSub       event.Subscription // Subscription for new chain event
+	reorgSub       event.Subscription // Subscription for chain reorg event
 
 	// Channels
 	install       chan *subscription         // install filter for event notification
@@ -102,6 +108,7 @@ type EventSystem struct {
 	pendingLogsCh chan []*types.Log          // Channel to receive new log event
 	rmLogsCh      chan core.RemovedLogsEvent // Channel to receive removed log event
 	chainCh       chan core.ChainEvent       // Channel to receive new chain event
+	reorgCh       chan core.ChainReorgEvent  // Channel to receive chain reorg event
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -121,6 +128,7 @@ func NewEventSystem(backend Backend, lightMode bool) *EventSystem {
 		rmLogsCh:      make(chan core.RemovedLogsEvent, rmLogsChanSize),
 		pendingLogsCh: make(chan []*types.Log, logsChanSize),
 		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
+		reorgCh:       make(chan core.ChainReorgEvent, reorgEvChanSize),
 	}
 
 	// Subscribe events
@@ -129,9 +137,10 @@ func NewEventSystem(backend Backend, lightMode bool) *EventSystem {
 	m.rmLogsSub = m.backend.SubscribeRemovedLogsEvent(m.rmLogsCh)
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
 	m.pendingLogsSub = m.backend.SubscribePendingLogsEvent(m.pendingLogsCh)
+	m.reorgSub = m.backend.SubscribeChainReorgEvent(m.reorgCh)
 
 	// Make sure none of the subscriptions are empty
-	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil {
+	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil || m.reorgSub == nil {
 		log.Crit("Subscribe for event system failed")
 	}
 
@@ -167,6 +176,7 @@ func (sub *Subscription) Unsubscribe() {
 			case <-sub.f.logs:
 			case <-sub.f.hashes:
 			case <-sub.f.headers:
+			case <-sub.f.reorgs:
 			}
 		}
 
@@ -234,6 +244,7 @@ func (es *EventSystem) subscribeMinedPendingLogs(crit ethereum.FilterQuery, logs
 		logs:      logs,
 		hashes:    make(chan []common.Hash),
 		headers:   make(chan *types.Header),
+		reorgs:    make(chan *core.ChainReorgEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -251,6 +262,7 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 		logs:      logs,
 		hashes:    make(chan []common.Hash),
 		headers:   make(chan *types.Header),
+		reorgs:    make(chan *core.ChainReorgEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -268,6 +280,7 @@ func (es *EventSystem) subscribePendingLogs(crit ethereum.FilterQuery, logs chan
 		logs:      logs,
 		hashes:    make(chan []common.Hash),
 		headers:   make(chan *types.Header),
+		reorgs:    make(chan *core.ChainReorgEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -284,6 +297,7 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *types.Header) *Subscripti
 		logs:      make(chan []*types.Log),
 		hashes:    make(chan []common.Hash),
 		headers:   headers,
+		reorgs:    make(chan *core.ChainReorgEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -300,6 +314,24 @@ func (es *EventSystem) SubscribePendingTxs(hashes chan []common.Hash) *Subscript
 		logs:      make(chan []*types.Log),
 		hashes:    hashes,
 		headers:   make(chan *types.Header),
+		reorgs:    make(chan *core.ChainReorgEvent),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
+// SubscribeReorgs creates a subscription that writes the reorganisations of the
+// canonical chain.
+func (es *EventSystem) SubscribeReorgs(reorgs chan *core.ChainReorgEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       ReorgsSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan []common.Hash),
+		headers:   make(chan *types.Header),
+		reorgs:    reorgs,
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -366,6 +398,12 @@ func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent)
 	}
 }
 
+func (es *EventSystem) handleReorgEvent(filters filterIndex, ev core.ChainReorgEvent) {
+	for _, f := range filters[ReorgsSubscription] {
+		f.reorgs <- &ev
+	}
+}
+
 func (es *EventSystem) lightFilterNewHead(newHeader *types.Header, callBack func(*types.Header, bool)) {
 	oldh := es.lastHead
 	es.lastHead = newHeader
@@ -448,6 +486,7 @@ func (es *EventSystem) eventLoop() {
 		es.rmLogsSub.Unsubscribe()
 		es.pendingLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
+		es.reorgSub.Unsubscribe()
 	}()
 
 	index := make(filterIndex)
@@ -467,6 +506,8 @@ func (es *EventSystem) eventLoop() {
 			es.handlePendingLogs(index, ev)
 		case ev := <-es.chainCh:
 			es.handleChainEvent(index, ev)
+		case ev := <-es.reorgCh:
+			es.handleReorgEvent(index, ev)
 
 		case f := <-es.install:
 			if f.typ == MinedAndPendingLogsSubscription {
@@ -497,6 +538,8 @@ func (es *EventSystem) eventLoop() {
 			return
 		case <-es.chainSub.Err():
 			return
+		case <-es.reorgSub.Err():
+			return
 		}
 	}
 }
@@ -49,6 +49,7 @@ type testBackend struct {
 	rmLogsFeed      event.Feed
 	pendingLogsFeed event.Feed
 	chainFeed       event.Feed
+	reorgFeed       event.Feed
 }
 
 func (b *testBackend) ChainDb() ethdb.Database {
@@ -123,6 +124,10 @@ func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subsc
 	return b.chainFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeChainReorgEvent(ch chan<- core.ChainReorgEvent) event.Subscription {
+	return b.reorgFeed.Subscribe(ch)
+}
+
 func (b *testBackend) BloomStatus() (uint64, uint64) {
 	return vars.BloomBitsBlocks, b.sections
 }
@@ -210,6 +215,50 @@ func TestBlockSubscription(t *testing.T) {
 	<-sub1.Err()
 }
 
+// TestReorgSubscription tests that chain reorg events are delivered to reorg
+// subscriptions, and converted into notifications.
+func TestReorgSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		backend  = &testBackend{db: db}
+		api      = NewPublicFilterAPI(backend, false)
+		genesis  = core.MustCommitGenesis(db, new(genesisT.Genesis))
+		chain, _ = core.GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 3, func(i int, gen *core.BlockGen) {})
+		fork, _  = core.GenerateChain(params.TestChainConfig, chain[0], ethash.NewFaker(), db, 3, func(i int, gen *core.BlockGen) {
+			gen.SetCoinbase(common.Address{0xff})
+		})
+		tx    = types.NewTransaction(0, common.Address{}, big.NewInt(1), 21000, nil, nil)
+		event = core.ChainReorgEvent{CommonAncestor: chain[0].Header(), Dropped: chain[1:], Added: fork, RemovedTxs: types.Transactions{tx}}
+	)
+	reorgs := make(chan *core.ChainReorgEvent)
+	sub := api.events.SubscribeReorgs(reorgs)
+	defer sub.Unsubscribe()
+
+	time.Sleep(1 * time.Second)
+	backend.reorgFeed.Send(event)
+
+	select {
+	case ev := <-reorgs:
+		reorg := newReorg(ev)
+		if reorg.CommonAncestor.Hash != chain[0].Hash() || reorg.CommonAncestor.Number != 1 {
+			t.Errorf("common ancestor mismatch: have %v", reorg.CommonAncestor)
+		}
+		if reorg.Depth != 2 || len(reorg.Dropped) != 2 || reorg.Dropped[1].Hash != chain[2].Hash() {
+			t.Errorf("dropped blocks mismatch: have %v", reorg.Dropped)
+		}
+		if len(reorg.Added) != 3 || reorg.Added[2].Hash != fork[2].Hash() || reorg.Added[2].Number != 4 {
+			t.Errorf("added blocks mismatch: have %v", reorg.Added)
+		}
+		if len(reorg.RemovedTransactions) != 1 || reorg.RemovedTransactions[0] != tx.Hash() {
+			t.Errorf("removed transactions mismatch: have %v", reorg.RemovedTransactions)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no reorg received")
+	}
+}
+
 // TestPendingTxFilter tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilter(t *testing.T) {
 	t.Parallel()
@@ -163,7 +163,7 @@ func NewProtocolManager(config ctypes.ChainConfigurator, checkpoint *ctypes.Trus
 	heighter := func() uint64 {
 		return blockchain.CurrentBlock().NumberU64()
 	}
-	inserter := func(blocks types.Blocks) (int, error) {
+	inserter := func(peer string, blocks types.Blocks) (int, error) {
 		// If sync hasn't reached the checkpoint yet, deny importing weird blocks.
 		//
 		// Ideally we would also compare the head block's timestamp and similarly reject
@@ -183,7 +183,7 @@ func NewProtocolManager(config ctypes.ChainConfigurator, checkpoint *ctypes.Trus
 			log.Warn("Fast syncing, discarded propagated block", "number", blocks[0].Number(), "hash", blocks[0].Hash())
 			return 0, nil
 		}
-		n, err := manager.blockchain.InsertChain(blocks)
+		n, err := manager.blockchain.InsertChainFrom(peer, blocks)
 		if err == nil {
 			atomic.StoreUint32(&manager.acceptTxs, 1) // Mark initial sync done on any fetcher import
 		}
@@ -245,6 +245,9 @@ func (pm *ProtocolManager) removePeer(id string) {
 	if err := pm.peers.Unregister(id); err != nil {
 		log.Error("Peer removal failed", "peer", id, "err", err)
 	}
+	if pm.blockchain.IsArtificialFinalityEnabled() && pm.peers.Len() < minArtificialFinalityPeers {
+		pm.blockchain.EnableArtificialFinality(false, "reason", "low peers", "peers", pm.peers.Len())
+	}
 	// Hard disconnect at the networking layer
 	peer.Peer.Disconnect(p2p.DiscUselessPeer)
 }
@@ -0,0 +1,428 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const (
+	// SupplySectionSize is the number of blocks in a supply index section. The state
+	// of the last block of each section is persisted into the indexer's own state
+	// tables to resume indexing from.
+	SupplySectionSize = 1024
+
+	// supplyConfirms is the number of confirmation blocks before a supply index
+	// section is processed.
+	supplyConfirms = 64
+
+	// supplyThrottling is the time to wait between processing two consecutive
+	// supply index sections.
+	supplyThrottling = 100 * time.Millisecond
+
+	// supplyTrieCacheLimit is the memory allowance of the state tries regenerated
+	// by the supply indexer before they are flushed to disk.
+	supplyTrieCacheLimit = 256 * 1024 * 1024
+)
+
+// supplyStateCompaction is the number of sections after which the state tables
+// of the supply indexer are compacted, dropping the states of the past sections.
+var supplyStateCompaction = uint64(64)
+
+// burnAddresses are the addresses no one holds the key of, ether sent to them is
+// considered burnt.
+var burnAddresses = []common.Address{{}}
+
+// SupplyIndexer implements a core.ChainIndexer, tracking the ether issued and
+// burnt by each block of the canonical chain, and the total supply after it.
+//
+// As ether can be burnt by self-destructs, blocks are re-executed on top of a
+// state maintained by the indexer itself, starting from the genesis state, so
+// an archive node is not required. The state is kept in tables of its own, out
+// of reach of the chain's state pruning, and compacted periodically so that it
+// doesn't grow like an archive.
+type SupplyIndexer struct {
+	db         ethdb.Database   // database instance to write index data into
+	chain      *core.BlockChain // blockchain to re-execute the indexed blocks with
+	size       uint64           // number of blocks in a section
+	generation uint64           // generation of the state table in use
+	table      ethdb.Database   // state table to regenerate the states of blocks into
+	cache      state.Database   // state database on top of the state table
+	batch      ethdb.Batch      // batch of index data of the section being processed
+	head       *types.Header    // last header processed
+	state      *state.StateDB   // state after the last header processed
+	prev       *rawdb.Issuance  // issuance of the last header processed
+}
+
+// NewSupplyIndexer returns a chain indexer that tracks the ether issuance of the
+// canonical chain.
+func NewSupplyIndexer(db ethdb.Database, chain *core.BlockChain, size, confirms uint64) *core.ChainIndexer {
+	backend := &SupplyIndexer{
+		db:         db,
+		chain:      chain,
+		size:       size,
+		generation: rawdb.ReadSupplyStateGeneration(db),
+	}
+	backend.table = supplyStateTable(db, backend.generation)
+	backend.cache = state.NewDatabaseWithCache(backend.table, 16)
+
+	table := rawdb.NewTable(db, string(rawdb.SupplyIndexPrefix))
+
+	return core.NewChainIndexer(db, table, backend, size, confirms, supplyThrottling, "supply")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new supply index section
+// on top of the state of the last section head.
+func (b *SupplyIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	b.batch = b.db.NewBatch()
+
+	// Continue from the last section if it's the one indexed before
+	if b.head != nil && b.head.Hash() == lastSectionHead {
+		return nil
+	}
+	b.release()
+	if section == 0 {
+		return nil
+	}
+	header := b.chain.GetHeaderByHash(lastSectionHead)
+	if header == nil {
+		return fmt.Errorf("section head %x unknown", lastSectionHead)
+	}
+	prev := rawdb.ReadIssuance(b.db, header.Number.Uint64(), lastSectionHead)
+	if prev == nil {
+		return fmt.Errorf("issuance of block #%d unknown", header.Number)
+	}
+	statedb, err := state.New(header.Root, b.cache, nil)
+	if err != nil {
+		return fmt.Errorf("state of block #%d unavailable: %v", header.Number, err)
+	}
+	b.cache.TrieDB().Reference(header.Root, common.Hash{})
+	b.head, b.state, b.prev = header, statedb, prev
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, re-executing the block of a new
+// header to record its issuance into the index.
+func (b *SupplyIndexer) Process(ctx context.Context, header *types.Header) error {
+	var (
+		issuance *rawdb.Issuance
+		err      error
+	)
+	if header.Number.Sign() == 0 {
+		issuance, err = b.processGenesis(header)
+	} else {
+		issuance, err = b.processBlock(header)
+	}
+	if err != nil {
+		return err
+	}
+	rawdb.WriteIssuance(b.batch, header.Number.Uint64(), header.Hash(), issuance)
+
+	b.head, b.prev = header, issuance
+	return nil
+}
+
+// processGenesis sums up the ether allocated in the genesis state.
+func (b *SupplyIndexer) processGenesis(header *types.Header) (*rawdb.Issuance, error) {
+	// The states of all the other blocks are regenerated on top of the genesis
+	// one, so copy it over from the chain database
+	if err := copyState(b.db, b.table, header.Root); err != nil {
+		return nil, fmt.Errorf("genesis state unavailable: %v", err)
+	}
+	tr, err := b.cache.OpenTrie(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	alloc := new(big.Int)
+	it := trie.NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		var account state.Account
+		if err := rlp.DecodeBytes(it.Value, &account); err != nil {
+			return nil, err
+		}
+		alloc.Add(alloc, account.Balance)
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	if b.state, err = state.New(header.Root, b.cache, nil); err != nil {
+		return nil, err
+	}
+	b.cache.TrieDB().Reference(header.Root, common.Hash{})
+
+	return &rawdb.Issuance{
+		Reward: new(big.Int),
+		Uncles: new(big.Int),
+		Alloc:  alloc,
+		Burnt:  new(big.Int),
+		Supply: new(big.Int).Set(alloc),
+	}, nil
+}
+
+// processBlock re-executes the block of the given header on top of the state of
+// its parent, measuring the rewards of its miners and the ether it burnt.
+func (b *SupplyIndexer) processBlock(header *types.Header) (*rawdb.Issuance, error) {
+	number := header.Number.Uint64()
+	if b.head == nil || b.head.Hash() != header.ParentHash {
+		return nil, fmt.Errorf("state of block #%d parent unavailable", number)
+	}
+	block := b.chain.GetBlock(header.Hash(), number)
+	if block == nil {
+		return nil, fmt.Errorf("block #%d [%x…] not found", number, header.Hash().Bytes()[:4])
+	}
+	issuance := &rawdb.Issuance{
+		Reward: new(big.Int),
+		Uncles: new(big.Int),
+		Alloc:  new(big.Int),
+		Burnt:  new(big.Int),
+	}
+	config := b.chain.Config()
+	if config.GetConsensusEngineType().IsEthash() {
+		reward, uncles := ethash.GetRewards(config, header, block.Uncles())
+		issuance.Reward.Set(reward)
+		for _, r := range uncles {
+			issuance.Uncles.Add(issuance.Uncles, r)
+		}
+	}
+	// Re-execute the block, tracking the ether burnt by self-destructs and sent
+	// to the burn addresses
+	for _, addr := range burnAddresses {
+		issuance.Burnt.Sub(issuance.Burnt, b.state.GetBalance(addr))
+	}
+	tracer := newBurnTracer()
+	if _, _, _, err := b.chain.Processor().Process(block, b.state, vm.Config{Debug: true, Tracer: tracer}); err != nil {
+		return nil, err
+	}
+	for _, addr := range burnAddresses {
+		issuance.Burnt.Add(issuance.Burnt, b.state.GetBalance(addr))
+	}
+	issuance.Burnt.Add(issuance.Burnt, tracer.burnt)
+
+	// Commit the state, making sure it's the one of the canonical block
+	root, err := b.state.Commit(config.IsEnabled(config.GetEIP161dTransition, header.Number))
+	if err != nil {
+		return nil, err
+	}
+	if root != header.Root {
+		return nil, fmt.Errorf("state root mismatch of block #%d: have %x, want %x", number, root, header.Root)
+	}
+	triedb := b.cache.TrieDB()
+	triedb.Reference(root, common.Hash{})
+	triedb.Dereference(b.head.Root)
+	if nodes, _ := triedb.Size(); nodes > supplyTrieCacheLimit {
+		triedb.Cap(supplyTrieCacheLimit - ethdb.IdealBatchSize)
+	}
+	if b.state, err = state.New(root, b.cache, nil); err != nil {
+		return nil, err
+	}
+	issuance.Supply = new(big.Int).Add(b.prev.Supply, issuance.Reward)
+	issuance.Supply.Add(issuance.Supply, issuance.Uncles)
+	issuance.Supply.Sub(issuance.Supply, issuance.Burnt)
+	return issuance, nil
+}
+
+// Commit implements core.ChainIndexerBackend, persisting the state of the last
+// block of the section and writing the index data out into the database.
+func (b *SupplyIndexer) Commit() error {
+	if err := b.cache.TrieDB().Commit(b.head.Root, false); err != nil {
+		return err
+	}
+	if err := b.batch.Write(); err != nil {
+		return err
+	}
+	if sections := (b.head.Number.Uint64() + 1) / b.size; sections%supplyStateCompaction == 0 {
+		return b.compact()
+	}
+	return nil
+}
+
+// compact copies the state of the last header processed over into the state
+// table of the next generation, and deletes the table of the current one along
+// with the states of the past sections.
+func (b *SupplyIndexer) compact() error {
+	var (
+		start      = time.Now()
+		generation = b.generation + 1
+		table      = supplyStateTable(b.db, generation)
+	)
+	// Clear out the leftovers of any interrupted compaction first
+	if err := wipeTable(table); err != nil {
+		return err
+	}
+	if err := copyState(b.table, table, b.head.Root); err != nil {
+		return err
+	}
+	rawdb.WriteSupplyStateGeneration(b.db, generation)
+
+	cache := state.NewDatabaseWithCache(table, 16)
+	statedb, err := state.New(b.head.Root, cache, nil)
+	if err != nil {
+		return err
+	}
+	if err := wipeTable(b.table); err != nil {
+		return err
+	}
+	b.generation, b.table, b.cache, b.state = generation, table, cache, statedb
+	b.cache.TrieDB().Reference(b.head.Root, common.Hash{})
+
+	log.Info("Compacted supply index state", "number", b.head.Number, "generation", generation, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// release drops the state of the last header processed.
+func (b *SupplyIndexer) release() {
+	if b.head != nil {
+		b.cache.TrieDB().Dereference(b.head.Root)
+	}
+	b.head, b.state, b.prev = nil, nil, nil
+}
+
+// supplyStateTable returns the state table of the supply indexer of the given
+// generation. Generations alternate between two tables, so the state in use can
+// be copied over into one while the other is deleted.
+func supplyStateTable(db ethdb.Database, generation uint64) ethdb.Database {
+	prefix := append(common.CopyBytes(rawdb.SupplyStatePrefix), byte(generation%2))
+	return rawdb.NewTable(db, string(prefix))
+}
+
+// copyState copies the trie nodes and contract codes of the state with the given
+// root from one database to another.
+func copyState(src, dst ethdb.Database, root common.Hash) error {
+	statedb, err := state.New(root, state.NewDatabase(src), nil)
+	if err != nil {
+		return err
+	}
+	batch := dst.NewBatch()
+	it := state.NewNodeIterator(statedb)
+	for it.Next() {
+		// Embedded nodes don't have a hash
+		if it.Hash == (common.Hash{}) {
+			continue
+		}
+		blob, err := src.Get(it.Hash.Bytes())
+		if err != nil {
+			return err
+		}
+		batch.Put(it.Hash.Bytes(), blob)
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if it.Error != nil {
+		return it.Error
+	}
+	return batch.Write()
+}
+
+// wipeTable deletes all the entries of the given table.
+func wipeTable(db ethdb.Database) error {
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		batch.Delete(it.Key())
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// burnTracer is a vm.Tracer measuring the ether destroyed by self-destructs:
+// the balance of contracts self-destructing to themselves, and the ether sent
+// to self-destructed contracts before the end of the transaction.
+type burnTracer struct {
+	env      *vm.EVM
+	suicides map[common.Address]*big.Int // Ether self-destructed to itself per contract
+	burnt    *big.Int                    // Ether burnt by the transactions traced
+}
+
+func newBurnTracer() *burnTracer {
+	return &burnTracer{
+		suicides: make(map[common.Address]*big.Int),
+		burnt:    new(big.Int),
+	}
+}
+
+// CaptureStart implements vm.Tracer.
+func (t *burnTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	return nil
+}
+
+// CaptureState implements vm.Tracer, recording the contracts self-destructing.
+func (t *burnTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if op != vm.SELFDESTRUCT || err != nil {
+		return nil
+	}
+	t.env = env
+
+	addr, amount := contract.Address(), new(big.Int)
+	if common.BigToAddress(stack.Back(0)) == addr {
+		amount.Set(env.StateDB.GetBalance(addr))
+	}
+	if prev := t.suicides[addr]; prev != nil {
+		amount.Add(amount, prev)
+	}
+	t.suicides[addr] = amount
+	return nil
+}
+
+// CaptureFault implements vm.Tracer.
+func (t *burnTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer, accounting the ether destroyed along with the
+// contracts still self-destructed at the end of the transaction.
+func (t *burnTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	for addr, amount := range t.suicides {
+		if t.env.StateDB.HasSuicided(addr) {
+			t.burnt.Add(t.burnt, amount)
+			t.burnt.Add(t.burnt, t.env.StateDB.GetBalance(addr))
+		}
+	}
+	if len(t.suicides) > 0 {
+		t.suicides = make(map[common.Address]*big.Int)
+		log.Trace("Measured ether burnt by self-destructs", "total", t.burnt)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// stateBalance sums up the balances of all the accounts in the given state, but
+// the burn addresses.
+func stateBalance(t *testing.T, db ethdb.Database, root common.Hash) *big.Int {
+	cache := state.NewDatabase(db)
+	tr, err := cache.OpenTrie(root)
+	if err != nil {
+		t.Fatalf("failed to open state trie: %v", err)
+	}
+	balance := new(big.Int)
+	it := trie.NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		var account state.Account
+		if err := rlp.DecodeBytes(it.Value, &account); err != nil {
+			t.Fatalf("failed to decode account: %v", err)
+		}
+		balance.Add(balance, account.Balance)
+	}
+	statedb, _ := state.New(root, cache, nil)
+	for _, addr := range burnAddresses {
+		balance.Sub(balance, statedb.GetBalance(addr))
+	}
+	return balance
+}
+
+// waitSections waits until the indexer processed the given number of sections.
+func waitSections(t *testing.T, indexer *core.ChainIndexer, sections uint64) {
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if have, _, _ := indexer.Sections(); have >= sections {
+			return
+		}
+		if time.Since(start) > 5*time.Second {
+			have, _, _ := indexer.Sections()
+			t.Fatalf("timeout indexing sections: have %d, want %d", have, sections)
+		}
+	}
+}
+
+// Tests that the supply indexer tracks the rewards and the ether burnt by each
+// block, resuming from the state persisted for the last section even though the
+// chain's states were pruned, and that the indexed supply matches the balances
+// in the state.
+func TestSupplyIndexer(t *testing.T) {
+	defer func(compaction uint64) { supplyStateCompaction = compaction }(supplyStateCompaction)
+	supplyStateCompaction = 2
+
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(vars.Ether)
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &genesisT.Genesis{
+			Config: params.AllEthashProtocolChanges,
+			Alloc:  genesisT.GenesisAlloc{address: {Balance: funds}},
+		}
+		genesis = core.MustCommitGenesis(db, gspec)
+		signer  = types.NewEIP155Signer(gspec.Config.GetChainID())
+		engine  = ethash.NewFaker()
+	)
+	uncles, _ := core.GenerateChain(gspec.Config, genesis, engine, db, 1, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.Address{0x02})
+	})
+	blocks, _ := core.GenerateChain(gspec.Config, genesis, engine, db, 11, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.Address{0x01})
+		switch i {
+		case 1:
+			// Include an uncle
+			gen.AddUncle(uncles[0].Header())
+		case 2:
+			// Burn ether by sending it to the zero address
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{}, big.NewInt(1000), vars.TxGas, nil, nil), signer, key)
+			gen.AddTx(tx)
+		case 5:
+			// Burn ether by creating a contract self-destructing to itself
+			tx, _ := types.SignTx(types.NewContractCreation(gen.TxNonce(address), big.NewInt(2000), 100000, nil, []byte{byte(vm.ADDRESS), byte(vm.SELFDESTRUCT)}), signer, key)
+			gen.AddTx(tx)
+
+			// But not by creating one self-destructing to someone else
+			tx, _ = types.SignTx(types.NewContractCreation(gen.TxNonce(address), big.NewInt(4000), 100000, nil, []byte{byte(vm.CALLER), byte(vm.SELFDESTRUCT)}), signer, key)
+			gen.AddTx(tx)
+		}
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec.Config, engine, vm.Config{}, nil, nil)
+	defer chain.Stop()
+
+	// Index the first section, and resume indexing the others after restarting
+	if _, err := chain.InsertChain(blocks[:5]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	indexer := NewSupplyIndexer(db, chain, 4, 0)
+	indexer.Start(chain)
+	waitSections(t, indexer, 1)
+	indexer.Close()
+
+	if _, err := chain.InsertChain(blocks[5:]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	balances := make([]*big.Int, 12)
+	for number := range balances {
+		balances[number] = stateBalance(t, db, chain.GetHeaderByNumber(uint64(number)).Root)
+	}
+	// Drop all the trie nodes of the chain, like a state pruning would
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		if len(it.Key()) == common.HashLength {
+			db.Delete(it.Key())
+		}
+	}
+	it.Release()
+
+	indexer = NewSupplyIndexer(db, chain, 4, 0)
+	indexer.Start(chain)
+	waitSections(t, indexer, 3)
+	indexer.Close()
+
+	// The state tables should have been compacted after the second section
+	if generation := rawdb.ReadSupplyStateGeneration(db); generation != 1 {
+		t.Errorf("state generation mismatch: have %d, want 1", generation)
+	}
+	it = supplyStateTable(db, 0).NewIterator(nil, nil)
+	if it.Next() {
+		t.Errorf("stale state left after compaction: %x", it.Key())
+	}
+	it.Release()
+
+	reward := ctypes.EthashBlockReward(gspec.Config, common.Big1)
+	for number := uint64(0); number < 12; number++ {
+		header := chain.GetHeaderByNumber(number)
+		issuance := rawdb.ReadIssuance(db, number, header.Hash())
+		if issuance == nil {
+			t.Fatalf("block %d: issuance missing", number)
+		}
+		if have, want := issuance.Supply, balances[number]; have.Cmp(want) != 0 {
+			t.Errorf("block %d: supply mismatch: have %v, want %v", number, have, want)
+		}
+		var (
+			wantReward = new(big.Int)
+			wantUncles = new(big.Int)
+			wantAlloc  = new(big.Int)
+			wantBurnt  = new(big.Int)
+		)
+		switch number {
+		case 0:
+			wantAlloc.Set(funds)
+		case 2:
+			wantReward.Add(reward, new(big.Int).Div(reward, big.NewInt(32)))
+			wantUncles.Div(new(big.Int).Mul(reward, big.NewInt(7)), big.NewInt(8))
+		case 3:
+			wantReward.Set(reward)
+			wantBurnt.SetInt64(1000)
+		case 6:
+			wantReward.Set(reward)
+			wantBurnt.SetInt64(2000)
+		default:
+			wantReward.Set(reward)
+		}
+		for _, field := range []struct {
+			name       string
+			have, want *big.Int
+		}{
+			{"reward", issuance.Reward, wantReward},
+			{"uncles", issuance.Uncles, wantUncles},
+			{"alloc", issuance.Alloc, wantAlloc},
+			{"burnt", issuance.Burnt, wantBurnt},
+		} {
+			if field.have.Cmp(field.want) != 0 {
+				t.Errorf("block %d: %s mismatch: have %v, want %v", number, field.name, field.have, field.want)
+			}
+		}
+	}
+}
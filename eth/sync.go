@@ -34,6 +34,11 @@ const (
 	forceSyncCycle      = 10 * time.Second // Time interval to force syncs, even if few peers are available
 	defaultMinSyncPeers = 5                // Amount of peers desired to start syncing
 
+	// minArtificialFinalityPeers defines the minimum number of peers our node must be connected
+	// to in order to enable artificial finality features.
+	// A minimum number of peer connections mitigates the risk of lower-powered eclipse attacks.
+	minArtificialFinalityPeers = defaultMinSyncPeers
+
 	// This is the target size for the packs of transactions sent by txsyncLoop64.
 	// A pack can get larger than this if a single transactions exceeds this size.
 	txsyncPackSize = 100 * 1024
@@ -325,6 +330,13 @@ func (pm *ProtocolManager) doSync(op *chainSyncOp) error {
 		}
 	}
 
+	// Artificial finality is only meaningful once the node has synced to a
+	// recent head, and is connected to a reasonable number of peers.
+	if atomic.LoadUint32(&pm.acceptTxs) == 1 && pm.peers.Len() >= minArtificialFinalityPeers &&
+		!pm.blockchain.IsArtificialFinalityEnabled() {
+		pm.blockchain.EnableArtificialFinality(true, "reason", "synced", "peers", pm.peers.Len())
+	}
+
 	if head.NumberU64() > 0 {
 		// We've completed a sync cycle, notify all peers of new state. This path is
 		// essential in star-topology networks where a gateway node needs to notify
@@ -27,8 +27,12 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/parity"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -71,6 +75,107 @@ func (ec *Client) ChainID(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&result), err
 }
 
+// ChainConfig is the chain configuration of a node, with its forks and fork IDs.
+type ChainConfig struct {
+	Config     ctypes.ChainConfigurator
+	Forks      []uint64
+	ForkID     forkid.ID
+	NextForkID *forkid.ID // nil if no further forks are configured
+}
+
+type rpcForkID struct {
+	Hash hexutil.Bytes  `json:"hash"`
+	Next hexutil.Uint64 `json:"next"`
+}
+
+func (id *rpcForkID) forkID() forkid.ID {
+	var fid forkid.ID
+	copy(fid.Hash[:], id.Hash)
+	fid.Next = uint64(id.Next)
+	return fid
+}
+
+// ChainConfig retrieves the chain configuration of the node, serialized in the given
+// format: "coregeth", "geth" or "parity". An empty format defaults to "coregeth".
+func (ec *Client) ChainConfig(ctx context.Context, format string) (*ChainConfig, error) {
+	if format == "" {
+		format = "coregeth"
+	}
+	var config ctypes.ChainConfigurator
+	switch format {
+	case "coregeth":
+		config = &coregeth.CoreGethChainConfig{}
+	case "geth":
+		config = &goethereum.ChainConfig{}
+	case "parity":
+		config = &parity.ParityChainSpec{}
+	default:
+		return nil, fmt.Errorf("unsupported chain configuration format: %s", format)
+	}
+	var result struct {
+		Config     json.RawMessage  `json:"config"`
+		Forks      []hexutil.Uint64 `json:"forks"`
+		ForkID     *rpcForkID       `json:"forkId"`
+		NextForkID *rpcForkID       `json:"nextForkId"`
+	}
+	if err := ec.c.CallContext(ctx, &result, "eth_chainConfig", format); err != nil {
+		return nil, err
+	}
+	if result.ForkID == nil {
+		return nil, errors.New("missing fork ID")
+	}
+	if err := json.Unmarshal(result.Config, config); err != nil {
+		return nil, err
+	}
+	cc := &ChainConfig{Config: config, ForkID: result.ForkID.forkID()}
+	for _, fork := range result.Forks {
+		cc.Forks = append(cc.Forks, uint64(fork))
+	}
+	if result.NextForkID != nil {
+		next := result.NextForkID.forkID()
+		cc.NextForkID = &next
+	}
+	return cc, nil
+}
+
+// Features is the set of protocol features active at a block.
+type Features struct {
+	Number          uint64
+	ConsensusEngine string
+	Features        map[string]uint64 // activation blocks of the active EIPs and ECIPs
+	Precompiles     []common.Address
+	ForkID          forkid.ID
+}
+
+// FeaturesAt returns the EIPs, ECIPs and precompiled contracts active at the given block,
+// which may be in the future. If number is nil, the latest known block is used.
+func (ec *Client) FeaturesAt(ctx context.Context, number *big.Int) (*Features, error) {
+	var result struct {
+		Number          hexutil.Uint64            `json:"number"`
+		ConsensusEngine string                    `json:"consensusEngine"`
+		Features        map[string]hexutil.Uint64 `json:"features"`
+		Precompiles     []common.Address          `json:"precompiles"`
+		ForkID          *rpcForkID                `json:"forkId"`
+	}
+	if err := ec.c.CallContext(ctx, &result, "eth_featuresAt", toBlockNumArg(number)); err != nil {
+		return nil, err
+	}
+	if result.ForkID == nil {
+		return nil, ethereum.NotFound
+	}
+	features := &Features{
+		Number:          uint64(result.Number),
+		ConsensusEngine: result.ConsensusEngine,
+		Features:        make(map[string]uint64, len(result.Features)),
+		Precompiles:     result.Precompiles,
+		ForkID:          result.ForkID.forkID(),
+	}
+	for name, n := range result.Features {
+		features.Features[name] = uint64(n)
+	}
+	return features, nil
+}
+
 // BlockByHash returns the given full block.
 //
 // Note that loading full blocks requires two requests. Use HeaderByHash
@@ -510,7 +615,7 @@ func (ec *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64
 // If the transaction was a contract creation use the TransactionReceipt method to get the
 // contract address after the transaction has been mined.
 func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	data, err := rlp.EncodeToBytes(tx)
+	data, err := tx.MarshalBinary()
 	if err != nil {
 		return err
 	}
@@ -534,5 +639,8 @@ func toCallArg(msg ethereum.CallMsg) interface{} {
 	if msg.GasPrice != nil {
 		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
 	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
 	return arg
 }
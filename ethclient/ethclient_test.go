@@ -336,3 +336,59 @@ func TestChainID(t *testing.T) {
 		t.Fatalf("ChainID returned wrong number: %+v", id)
 	}
 }
+
+func TestChainConfig(t *testing.T) {
+	backend, _ := newTestBackend(t)
+	client, _ := backend.Attach()
+	defer backend.Stop()
+	defer client.Close()
+	ec := NewClient(client)
+
+	for _, format := range []string{"", "geth", "parity"} {
+		cc, err := ec.ChainConfig(context.Background(), format)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", format, err)
+		}
+		if id := cc.Config.GetChainID(); id == nil || id.Cmp(params.AllEthashProtocolChanges.ChainID) != 0 {
+			t.Errorf("%q: wrong chain ID: %v", format, id)
+		}
+		if n := cc.Config.GetEIP1884Transition(); n == nil || *n != 0 {
+			t.Errorf("%q: wrong EIP1884 transition: %v", format, n)
+		}
+		// All forks are activated at genesis.
+		if len(cc.Forks) != 0 || cc.ForkID.Next != 0 || cc.NextForkID != nil {
+			t.Errorf("%q: unexpected forks: %v, fork ID %v, next %v", format, cc.Forks, cc.ForkID, cc.NextForkID)
+		}
+	}
+	if _, err := ec.ChainConfig(context.Background(), "foo"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestFeaturesAt(t *testing.T) {
+	backend, chain := newTestBackend(t)
+	client, _ := backend.Attach()
+	defer backend.Stop()
+	defer client.Close()
+	ec := NewClient(client)
+
+	features, err := ec.FeaturesAt(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if features.Number != chain[len(chain)-1].NumberU64() {
+		t.Errorf("wrong block number: %d", features.Number)
+	}
+	if features.ConsensusEngine != "ethash" {
+		t.Errorf("wrong consensus engine: %s", features.ConsensusEngine)
+	}
+	for _, name := range []string{"EIP155", "EIP1884", "EIP2200"} {
+		if _, ok := features.Features[name]; !ok {
+			t.Errorf("missing feature %s", name)
+		}
+	}
+	// Istanbul precompiles, from ecrecover to blake2F.
+	if len(features.Precompiles) != 9 || features.Precompiles[8] != common.BytesToAddress([]byte{9}) {
+		t.Errorf("wrong precompiles: %v", features.Precompiles)
+	}
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -897,6 +896,7 @@ func (p *Pending) EstimateGas(ctx context.Context, args struct {
 // Resolver is the top-level object in the GraphQL hierarchy.
 type Resolver struct {
 	backend ethapi.Backend
+	reorgs  *reorgTracker
 }
 
 func (r *Resolver) Block(ctx context.Context, args struct {
@@ -983,7 +983,7 @@ func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Has
 
 func (r *Resolver) SendRawTransaction(ctx context.Context, args struct{ Data hexutil.Bytes }) (common.Hash, error) {
 	tx := new(types.Transaction)
-	if err := rlp.DecodeBytes(args.Data, tx); err != nil {
+	if err := tx.UnmarshalBinary(args.Data); err != nil {
 		return common.Hash{}, err
 	}
 	hash, err := ethapi.SubmitTransaction(ctx, r.backend, tx)
@@ -1038,6 +1038,15 @@ func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
 	return hexutil.Big(*price), err
 }
 
+func (r *Resolver) Reorgs(ctx context.Context) []*Reorg {
+	events := r.reorgs.recent()
+	reorgs := make([]*Reorg, len(events))
+	for i, ev := range events {
+		reorgs[i] = &Reorg{backend: r.backend, ev: ev}
+	}
+	return reorgs
+}
+
 func (r *Resolver) ProtocolVersion(ctx context.Context) (int32, error) {
 	return int32(r.backend.ProtocolVersion()), nil
 }
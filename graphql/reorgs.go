@@ -0,0 +1,139 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxReorgs is the number of recent chain reorganisations kept for queries.
+const maxReorgs = 64
+
+// reorgTracker keeps the most recent chain reorganisations observed since the
+// service started.
+type reorgTracker struct {
+	sub event.Subscription
+
+	lock   sync.RWMutex
+	reorgs []*core.ChainReorgEvent
+}
+
+// newReorgTracker starts tracking the chain reorganisations of the backend.
+func newReorgTracker(backend ethapi.Backend) *reorgTracker {
+	var (
+		t  = new(reorgTracker)
+		ch = make(chan core.ChainReorgEvent, 10)
+	)
+	t.sub = backend.SubscribeChainReorgEvent(ch)
+	go t.loop(ch)
+	return t
+}
+
+func (t *reorgTracker) loop(ch chan core.ChainReorgEvent) {
+	for {
+		select {
+		case ev := <-ch:
+			t.lock.Lock()
+			t.reorgs = append(t.reorgs, &ev)
+			if len(t.reorgs) > maxReorgs {
+				t.reorgs = t.reorgs[len(t.reorgs)-maxReorgs:]
+			}
+			t.lock.Unlock()
+		case <-t.sub.Err():
+			return
+		}
+	}
+}
+
+// stop terminates the tracking.
+func (t *reorgTracker) stop() {
+	t.sub.Unsubscribe()
+}
+
+// recent returns the tracked chain reorganisations, oldest first.
+func (t *reorgTracker) recent() []*core.ChainReorgEvent {
+	if t == nil {
+		return nil
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return append([]*core.ChainReorgEvent(nil), t.reorgs...)
+}
+
+// Reorg represents a reorganisation of the canonical chain.
+type Reorg struct {
+	backend ethapi.Backend
+	ev      *core.ChainReorgEvent
+}
+
+func (r *Reorg) CommonAncestor(ctx context.Context) *Block {
+	numberOrHash := rpc.BlockNumberOrHashWithHash(r.ev.CommonAncestor.Hash(), false)
+	return &Block{
+		backend:      r.backend,
+		numberOrHash: &numberOrHash,
+		hash:         r.ev.CommonAncestor.Hash(),
+		header:       r.ev.CommonAncestor,
+	}
+}
+
+func (r *Reorg) Depth(ctx context.Context) int32 {
+	return int32(len(r.ev.Dropped))
+}
+
+func (r *Reorg) Dropped(ctx context.Context) []*Block {
+	return r.blocks(r.ev.Dropped)
+}
+
+func (r *Reorg) Added(ctx context.Context) []*Block {
+	return r.blocks(r.ev.Added)
+}
+
+func (r *Reorg) RemovedTransactions(ctx context.Context) []*Transaction {
+	txs := make([]*Transaction, len(r.ev.RemovedTxs))
+	for i, tx := range r.ev.RemovedTxs {
+		txs[i] = &Transaction{
+			backend: r.backend,
+			hash:    tx.Hash(),
+			tx:      tx,
+		}
+	}
+	return txs
+}
+
+// blocks wraps the blocks of the reorganisation, which might not be canonical.
+func (r *Reorg) blocks(blocks []*types.Block) []*Block {
+	ret := make([]*Block, len(blocks))
+	for i, block := range blocks {
+		numberOrHash := rpc.BlockNumberOrHashWithHash(block.Hash(), false)
+		ret[i] = &Block{
+			backend:      r.backend,
+			numberOrHash: &numberOrHash,
+			hash:         block.Hash(),
+			header:       block.Header(),
+			block:        block,
+		}
+	}
+	return ret
+}
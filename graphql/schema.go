@@ -294,6 +294,23 @@ const schema string = `
       estimateGas(data: CallData!): Long!
     }
 
+    # Reorg is a reorganisation of the canonical chain observed by the node.
+    type Reorg {
+        # CommonAncestor is the last block shared by the dropped and added chains.
+        commonAncestor: Block!
+        # Depth is the number of blocks dropped from the canonical chain.
+        depth: Int!
+        # Dropped is the list of blocks removed from the canonical chain, by
+        # ascending number.
+        dropped: [Block!]!
+        # Added is the list of blocks added to the canonical chain, by ascending
+        # number, ending with the new head.
+        added: [Block!]!
+        # RemovedTransactions is the list of transactions of the dropped blocks
+        # not included in the added ones.
+        removedTransactions: [Transaction!]!
+    }
+
     type Query {
         # Block fetches an Ethereum block by number or by hash. If neither is
         # supplied, the most recent known block is returned.
@@ -314,6 +331,9 @@ const schema string = `
         protocolVersion: Int!
         # Syncing returns information on the current synchronisation state.
         syncing: SyncState
+        # Reorgs returns the most recent reorganisations of the canonical chain
+        # observed since the node started, oldest first.
+        reorgs: [Reorg!]!
     }
 
     type Mutation {
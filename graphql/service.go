@@ -37,6 +37,7 @@ type Service struct {
 	vhosts   []string         // Recognised vhosts
 	timeouts rpc.HTTPTimeouts // Timeout settings for HTTP requests.
 	backend  ethapi.Backend   // The backend that queries will operate on.
+	reorgs   *reorgTracker    // Recent chain reorganisations, for the reorgs query.
 	handler  http.Handler     // The `http.Handler` used to answer queries.
 	listener net.Listener     // The listening socket.
 }
@@ -62,8 +63,10 @@ func (s *Service) APIs() []rpc.API { return nil }
 // layer was also initialized to spawn any goroutines required by the service.
 func (s *Service) Start(server *p2p.Server) error {
 	var err error
-	s.handler, err = newHandler(s.backend)
+	s.reorgs = newReorgTracker(s.backend)
+	s.handler, err = newHandler(s.backend, s.reorgs)
 	if err != nil {
+		s.reorgs.stop()
 		return err
 	}
 	if s.listener, err = net.Listen("tcp", s.endpoint); err != nil {
@@ -87,8 +90,8 @@ func (s *Service) Start(server *p2p.Server) error {
 
 // newHandler returns a new `http.Handler` that will answer GraphQL queries.
 // It additionally exports an interactive query browser on the / endpoint.
-func newHandler(backend ethapi.Backend) (http.Handler, error) {
-	q := Resolver{backend}
+func newHandler(backend ethapi.Backend, reorgs *reorgTracker) (http.Handler, error) {
+	q := Resolver{backend, reorgs}
 
 	s, err := graphql.ParseSchema(schema, &q)
 	if err != nil {
@@ -106,6 +109,10 @@ func newHandler(backend ethapi.Backend) (http.Handler, error) {
 // Stop terminates all goroutines belonging to the service, blocking until they
 // are all terminated.
 func (s *Service) Stop() error {
+	if s.reorgs != nil {
+		s.reorgs.stop()
+		s.reorgs = nil
+	}
 	if s.listener != nil {
 		s.listener.Close()
 		s.listener = nil
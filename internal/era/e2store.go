@@ -0,0 +1,131 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// headerSize is the size of an entry header: a 2 byte type, a 4 byte little
+// endian length and 2 reserved zero bytes.
+const headerSize = 8
+
+// valueSizeLimit caps the length of a single entry, to avoid allocating huge
+// buffers when reading corrupted files.
+const valueSizeLimit = 1024 * 1024 * 50
+
+// Entry is a type-length-value record of an e2store file.
+type Entry struct {
+	Type  uint16
+	Value []byte
+}
+
+// Writer writes entries into an e2store stream.
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter creates an e2store writer on top of the given stream.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes a single entry with the given type and value, returning the
+// number of bytes written.
+func (w *Writer) Write(typ uint16, value []byte) (int, error) {
+	var header [headerSize]byte
+	binary.LittleEndian.PutUint16(header[:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(value)))
+
+	n, err := w.w.Write(header[:])
+	if err != nil {
+		return n, err
+	}
+	m, err := w.w.Write(value)
+	return n + m, err
+}
+
+// Reader reads entries from an e2store file.
+type Reader struct {
+	r      io.ReaderAt
+	offset int64
+}
+
+// NewReader creates an e2store reader on top of the given file.
+func NewReader(r io.ReaderAt) *Reader {
+	return &Reader{r: r}
+}
+
+// Read reads the next entry of the file, returning io.EOF at the end of it.
+func (r *Reader) Read() (*Entry, error) {
+	entry, length, err := r.ReadAt(r.offset)
+	if err != nil {
+		return nil, err
+	}
+	r.offset += int64(length)
+	return entry, nil
+}
+
+// ReadAt reads the entry at the given offset, also returning its total length.
+func (r *Reader) ReadAt(off int64) (*Entry, int, error) {
+	typ, length, err := r.readHeader(off)
+	if err != nil {
+		return nil, 0, err
+	}
+	entry := &Entry{Type: typ}
+	if length > 0 {
+		entry.Value = make([]byte, length)
+		if _, err := r.r.ReadAt(entry.Value, off+headerSize); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, 0, err
+		}
+	}
+	return entry, headerSize + int(length), nil
+}
+
+// LengthAt returns the total length of the entry at the given offset.
+func (r *Reader) LengthAt(off int64) (int64, error) {
+	_, length, err := r.readHeader(off)
+	if err != nil {
+		return 0, err
+	}
+	return headerSize + int64(length), nil
+}
+
+// readHeader reads and validates the header of the entry at the given offset.
+func (r *Reader) readHeader(off int64) (uint16, uint32, error) {
+	var header [headerSize]byte
+	if n, err := r.r.ReadAt(header[:], off); err != nil {
+		if err == io.EOF && n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, 0, err
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return 0, 0, errors.New("reserved bytes are non-zero")
+	}
+	length := binary.LittleEndian.Uint32(header[2:6])
+	if length > valueSizeLimit {
+		return 0, 0, fmt.Errorf("entry too large: %d bytes", length)
+	}
+	return binary.LittleEndian.Uint16(header[:2]), length, nil
+}
@@ -0,0 +1,410 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package era implements history archives of the ancient store: e2store files
+// holding a fixed number of consecutive blocks with their receipts and total
+// difficulties, as kept by the ancient store, followed by an accumulator and a
+// block index.
+//
+// The layout resembles that of era1 archives, but the files are not era1 ones:
+// entries hold the storage encodings of the ancient store, total difficulties
+// are big endian and the accumulator is a plain keccak256 hash. They have their
+// own extension and entry types so the two are never mixed up.
+//
+// A history archive is laid out as
+//
+//	Version | (CompressedHeader | CompressedBody | CompressedReceipts | TotalDifficulty)* | Accumulator | BlockIndex
+//
+// where headers, bodies and receipts are the snappy compressed RLP encodings of
+// the ancient store, total difficulties are 32 byte big endian integers, and the
+// block index is the starting block number, the file offset of each block and
+// the block count, all 8 byte little endian integers.
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/snappy"
+	"golang.org/x/crypto/sha3"
+)
+
+// Entry types of a history archive. The version is the one of all e2store files.
+const (
+	TypeVersion            uint16 = 0x3265
+	TypeCompressedHeader   uint16 = 0x6801
+	TypeCompressedBody     uint16 = 0x6802
+	TypeCompressedReceipts uint16 = 0x6803
+	TypeTotalDifficulty    uint16 = 0x6804
+	TypeAccumulator        uint16 = 0x6805
+	TypeBlockIndex         uint16 = 0x6806
+)
+
+// MaxSize is the number of blocks of a history archive. All archives of a chain
+// are aligned on multiples of it, only the last one may hold fewer blocks.
+const MaxSize = 8192
+
+// Extension is the file extension of history archives.
+const Extension = ".erh"
+
+// ChecksumsFile is the name of the file listing the SHA-256 checksums of the
+// history archives of a directory, one per line in the order of the files.
+const ChecksumsFile = "checksums.txt"
+
+// Filename returns the name of the history archive of the given network and
+// epoch, tagged with the first bytes of its accumulator root.
+func Filename(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%x%s", network, epoch, root[:4], Extension)
+}
+
+// ReadDir returns the history archives of the given network in the directory,
+// sorted by epoch, as the directory listing is sorted by name. The epochs must
+// be contiguous, starting at the first one found.
+func ReadDir(dir, network string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		next  = -1
+		files []string
+	)
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), network+"-") || filepath.Ext(entry.Name()) != Extension {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(entry.Name(), Extension), "-")
+		if len(parts) != 3 || parts[0] != network {
+			return nil, fmt.Errorf("malformed history archive filename: %s", entry.Name())
+		}
+		var epoch int
+		if _, err := fmt.Sscanf(parts[1], "%05d", &epoch); err != nil {
+			return nil, fmt.Errorf("malformed history archive filename: %s", entry.Name())
+		}
+		if next != -1 && epoch != next {
+			return nil, fmt.Errorf("missing epoch %d", next)
+		}
+		next = epoch + 1
+		files = append(files, entry.Name())
+	}
+	return files, nil
+}
+
+// ComputeAccumulator returns the accumulator root of a run of blocks, committing
+// to their hashes and total difficulties.
+func ComputeAccumulator(hashes []common.Hash, tds []*big.Int) (common.Hash, error) {
+	if len(hashes) != len(tds) {
+		return common.Hash{}, errors.New("must have equal number of hashes and total difficulties")
+	}
+	if len(hashes) > MaxSize {
+		return common.Hash{}, fmt.Errorf("too many records: have %d, max %d", len(hashes), MaxSize)
+	}
+	hasher := sha3.NewLegacyKeccak256()
+	for i, hash := range hashes {
+		hasher.Write(hash[:])
+		hasher.Write(common.BigToHash(tds[i]).Bytes())
+	}
+	return common.BytesToHash(hasher.Sum(nil)), nil
+}
+
+// Builder writes a run of blocks into a history archive.
+type Builder struct {
+	w        *Writer
+	startNum *uint64
+	written  int
+
+	indexes []uint64
+	hashes  []common.Hash
+	tds     []*big.Int
+}
+
+// NewBuilder creates a history archive builder writing into the given stream.
+func NewBuilder(w io.Writer) *Builder {
+	return &Builder{w: NewWriter(w)}
+}
+
+// Add appends a block, given its hash, its raw RLP encoded header, body and
+// receipts as kept by the ancient store, and its total difficulty.
+func (b *Builder) Add(number uint64, hash common.Hash, header, body, receipts []byte, td *big.Int) error {
+	if len(b.indexes) >= MaxSize {
+		return fmt.Errorf("exceeds maximum batch size of %d", MaxSize)
+	}
+	if b.startNum == nil {
+		if err := b.write(TypeVersion, nil); err != nil {
+			return err
+		}
+		b.startNum = &number
+	} else if want := *b.startNum + uint64(len(b.indexes)); number != want {
+		return fmt.Errorf("non-contiguous block: have %d, want %d", number, want)
+	}
+	b.indexes = append(b.indexes, uint64(b.written))
+	b.hashes = append(b.hashes, hash)
+	b.tds = append(b.tds, new(big.Int).Set(td))
+
+	for _, entry := range []struct {
+		typ  uint16
+		blob []byte
+	}{
+		{TypeCompressedHeader, header},
+		{TypeCompressedBody, body},
+		{TypeCompressedReceipts, receipts},
+	} {
+		if err := b.write(entry.typ, snappy.Encode(nil, entry.blob)); err != nil {
+			return err
+		}
+	}
+	return b.write(TypeTotalDifficulty, common.BigToHash(td).Bytes())
+}
+
+// Finalize writes the accumulator and the block index, and returns the root of
+// the accumulator.
+func (b *Builder) Finalize() (common.Hash, error) {
+	if b.startNum == nil {
+		return common.Hash{}, errors.New("finalize called on empty builder")
+	}
+	root, err := ComputeAccumulator(b.hashes, b.tds)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := b.write(TypeAccumulator, root.Bytes()); err != nil {
+		return common.Hash{}, err
+	}
+	index := make([]byte, 16+8*len(b.indexes))
+	binary.LittleEndian.PutUint64(index, *b.startNum)
+	for i, offset := range b.indexes {
+		binary.LittleEndian.PutUint64(index[8+8*i:], offset)
+	}
+	binary.LittleEndian.PutUint64(index[8+8*len(b.indexes):], uint64(len(b.indexes)))
+	if err := b.write(TypeBlockIndex, index); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+// write writes an entry, tracking the offset into the file.
+func (b *Builder) write(typ uint16, value []byte) error {
+	n, err := b.w.Write(typ, value)
+	b.written += n
+	return err
+}
+
+// ReadAtSeekCloser is the file interface needed to read a history archive.
+type ReadAtSeekCloser interface {
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// Era is a reader of a history archive.
+type Era struct {
+	f ReadAtSeekCloser
+	s *Reader
+
+	start  uint64 // number of the first block
+	count  uint64 // number of blocks
+	length int64  // length of the file
+}
+
+// Open opens the history archive at the given path.
+func Open(filename string) (*Era, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	e, err := From(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// From creates a history archive reader on top of the given file, validating its layout.
+func From(f ReadAtSeekCloser) (*Era, error) {
+	length, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	e := &Era{f: f, s: NewReader(f), length: length}
+
+	entry, _, err := e.s.ReadAt(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version: %v", err)
+	}
+	if entry.Type != TypeVersion || len(entry.Value) != 0 {
+		return nil, errors.New("invalid version entry")
+	}
+	// Read the block count off the end of the index, and the start at its beginning
+	var buf [8]byte
+	if length < 2*headerSize+16 {
+		return nil, errors.New("file too short")
+	}
+	if _, err := f.ReadAt(buf[:], length-8); err != nil {
+		return nil, err
+	}
+	e.count = binary.LittleEndian.Uint64(buf[:])
+	if e.count == 0 || e.count > MaxSize {
+		return nil, fmt.Errorf("invalid block count %d", e.count)
+	}
+	index, _, err := e.s.ReadAt(e.indexOffset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block index: %v", err)
+	}
+	if index.Type != TypeBlockIndex || uint64(len(index.Value)) != 16+8*e.count {
+		return nil, errors.New("invalid block index")
+	}
+	e.start = binary.LittleEndian.Uint64(index.Value)
+	return e, nil
+}
+
+// Close closes the underlying file.
+func (e *Era) Close() error {
+	return e.f.Close()
+}
+
+// Start returns the number of the first block of the file.
+func (e *Era) Start() uint64 {
+	return e.start
+}
+
+// Count returns the number of blocks of the file.
+func (e *Era) Count() uint64 {
+	return e.count
+}
+
+// Accumulator returns the accumulator root stored in the file.
+func (e *Era) Accumulator() (common.Hash, error) {
+	entry, _, err := e.s.ReadAt(e.indexOffset() - headerSize - common.HashLength)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if entry.Type != TypeAccumulator || len(entry.Value) != common.HashLength {
+		return common.Hash{}, errors.New("invalid accumulator entry")
+	}
+	return common.BytesToHash(entry.Value), nil
+}
+
+// GetRawHeaderByNumber returns the RLP encoded header of the given block.
+func (e *Era) GetRawHeaderByNumber(number uint64) ([]byte, error) {
+	return e.readCompressed(number, 0, TypeCompressedHeader)
+}
+
+// GetRawBodyByNumber returns the RLP encoded body of the given block.
+func (e *Era) GetRawBodyByNumber(number uint64) ([]byte, error) {
+	return e.readCompressed(number, 1, TypeCompressedBody)
+}
+
+// GetRawReceiptsByNumber returns the RLP encoded receipts of the given block, in
+// their storage encoding.
+func (e *Era) GetRawReceiptsByNumber(number uint64) ([]byte, error) {
+	return e.readCompressed(number, 2, TypeCompressedReceipts)
+}
+
+// GetTdByNumber returns the total difficulty of the given block.
+func (e *Era) GetTdByNumber(number uint64) (*big.Int, error) {
+	entry, err := e.readEntry(number, 3)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type != TypeTotalDifficulty || len(entry.Value) != common.HashLength {
+		return nil, fmt.Errorf("block %d: invalid total difficulty entry", number)
+	}
+	return new(big.Int).SetBytes(entry.Value), nil
+}
+
+// readCompressed reads and decompresses the given entry of a block.
+func (e *Era) readCompressed(number uint64, skip int, typ uint16) ([]byte, error) {
+	entry, err := e.readEntry(number, skip)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type != typ {
+		return nil, fmt.Errorf("block %d: entry type mismatch: have %#x, want %#x", number, entry.Type, typ)
+	}
+	return snappy.Decode(nil, entry.Value)
+}
+
+// readEntry reads the entry of a block, skipping the given number of entries
+// from its first one.
+func (e *Era) readEntry(number uint64, skip int) (*Entry, error) {
+	if number < e.start || number >= e.start+e.count {
+		return nil, fmt.Errorf("block %d out of range [%d, %d)", number, e.start, e.start+e.count)
+	}
+	var buf [8]byte
+	if _, err := e.f.ReadAt(buf[:], e.indexOffset()+headerSize+8+8*int64(number-e.start)); err != nil {
+		return nil, err
+	}
+	off := int64(binary.LittleEndian.Uint64(buf[:]))
+	for i := 0; i < skip; i++ {
+		length, err := e.s.LengthAt(off)
+		if err != nil {
+			return nil, err
+		}
+		off += length
+	}
+	if off >= e.indexOffset() {
+		return nil, fmt.Errorf("block %d: offset out of range", number)
+	}
+	entry, _, err := e.s.ReadAt(off)
+	return entry, err
+}
+
+// indexOffset returns the offset of the block index entry.
+func (e *Era) indexOffset() int64 {
+	return e.length - headerSize - 16 - 8*int64(e.count)
+}
+
+// Verify recomputes the accumulator root from the blocks of the file, and checks
+// it against the stored one.
+func (e *Era) Verify() (common.Hash, error) {
+	var (
+		hashes = make([]common.Hash, 0, e.count)
+		tds    = make([]*big.Int, 0, e.count)
+	)
+	for n := e.start; n < e.start+e.count; n++ {
+		header, err := e.GetRawHeaderByNumber(n)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		td, err := e.GetTdByNumber(n)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		hashes = append(hashes, crypto.Keccak256Hash(header))
+		tds = append(tds, td)
+	}
+	want, err := e.Accumulator()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	have, err := ComputeAccumulator(hashes, tds)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if have != want {
+		return common.Hash{}, fmt.Errorf("accumulator mismatch: have %x, want %x", have, want)
+	}
+	return have, nil
+}
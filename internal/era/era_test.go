@@ -0,0 +1,201 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type testBlock struct {
+	header, body, receipts []byte
+	td                     *big.Int
+}
+
+// writeTestEra writes a history archive of the given blocks, returning its path and
+// accumulator root.
+func writeTestEra(t *testing.T, dir string, start uint64, blocks []testBlock) (string, common.Hash) {
+	f, err := ioutil.TempFile(dir, "era")
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	defer f.Close()
+
+	builder := NewBuilder(f)
+	for i, block := range blocks {
+		if err := builder.Add(start+uint64(i), crypto.Keccak256Hash(block.header), block.header, block.body, block.receipts, block.td); err != nil {
+			t.Fatalf("failed to add block %d: %v", i, err)
+		}
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("failed to finalize: %v", err)
+	}
+	return f.Name(), root
+}
+
+func makeTestBlocks(n int) []testBlock {
+	blocks := make([]testBlock, n)
+	for i := range blocks {
+		blocks[i] = testBlock{
+			header:   bytes.Repeat([]byte{byte(i)}, 500),
+			body:     []byte{byte(i), 0x01},
+			receipts: bytes.Repeat([]byte{byte(i), 0x02}, i),
+			td:       big.NewInt(int64(i) * 1000),
+		}
+	}
+	return blocks
+}
+
+func TestEraRoundtrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "era")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	blocks := makeTestBlocks(100)
+	path, root := writeTestEra(t, dir, 8192, blocks)
+
+	e, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open era: %v", err)
+	}
+	defer e.Close()
+
+	if e.Start() != 8192 || e.Count() != 100 {
+		t.Fatalf("range mismatch: have [%d, +%d), want [8192, +100)", e.Start(), e.Count())
+	}
+	if have, err := e.Accumulator(); err != nil || have != root {
+		t.Fatalf("accumulator mismatch: have %x (%v), want %x", have, err, root)
+	}
+	if have, err := e.Verify(); err != nil || have != root {
+		t.Fatalf("verification failed: have %x (%v), want %x", have, err, root)
+	}
+	// Read the blocks backwards, to not rely on sequential access
+	for i := len(blocks) - 1; i >= 0; i-- {
+		number := uint64(8192 + i)
+		header, err := e.GetRawHeaderByNumber(number)
+		if err != nil || !bytes.Equal(header, blocks[i].header) {
+			t.Errorf("block %d: header mismatch: have %x (%v), want %x", number, header, err, blocks[i].header)
+		}
+		body, err := e.GetRawBodyByNumber(number)
+		if err != nil || !bytes.Equal(body, blocks[i].body) {
+			t.Errorf("block %d: body mismatch: have %x (%v), want %x", number, body, err, blocks[i].body)
+		}
+		receipts, err := e.GetRawReceiptsByNumber(number)
+		if err != nil || !bytes.Equal(receipts, blocks[i].receipts) {
+			t.Errorf("block %d: receipts mismatch: have %x (%v), want %x", number, receipts, err, blocks[i].receipts)
+		}
+		td, err := e.GetTdByNumber(number)
+		if err != nil || td.Cmp(blocks[i].td) != 0 {
+			t.Errorf("block %d: td mismatch: have %v (%v), want %v", number, td, err, blocks[i].td)
+		}
+	}
+	for _, number := range []uint64{8191, 8292} {
+		if _, err := e.GetRawHeaderByNumber(number); err == nil {
+			t.Errorf("block %d: read out of range", number)
+		}
+	}
+}
+
+// Tests that tampering with the blocks of a file is caught by the accumulator.
+func TestEraCorruption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "era")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	blocks := makeTestBlocks(10)
+	path, _ := writeTestEra(t, dir, 0, blocks)
+
+	// Replace the total difficulty of the last block, without changing the layout
+	blob, _ := ioutil.ReadFile(path)
+	td := common.BigToHash(blocks[9].td).Bytes()
+	i := bytes.LastIndex(blob, td)
+	copy(blob[i:], common.BigToHash(big.NewInt(1)).Bytes())
+	if err := ioutil.WriteFile(path, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	e, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open era: %v", err)
+	}
+	defer e.Close()
+
+	if _, err := e.Verify(); err == nil {
+		t.Fatal("corrupted era verified")
+	}
+	// Truncated files are rejected outright
+	if err := ioutil.WriteFile(path, blob[:len(blob)-1], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Fatal("truncated era opened")
+	}
+}
+
+func TestBuilderContiguity(t *testing.T) {
+	builder := NewBuilder(new(bytes.Buffer))
+	if _, err := builder.Finalize(); err == nil {
+		t.Fatal("finalized empty builder")
+	}
+	if err := builder.Add(5, common.Hash{}, nil, nil, nil, new(big.Int)); err != nil {
+		t.Fatalf("failed to add block: %v", err)
+	}
+	if err := builder.Add(7, common.Hash{}, nil, nil, nil, new(big.Int)); err == nil {
+		t.Fatal("added non-contiguous block")
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "era")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{
+		Filename("classic", 1, common.Hash{0x01}),
+		Filename("classic", 0, common.Hash{0x02}),
+		Filename("mordor", 5, common.Hash{0x03}),
+		"classic-00002-04000000.era1", // Not a history archive
+		ChecksumsFile,
+	} {
+		ioutil.WriteFile(filepath.Join(dir, name), nil, 0644)
+	}
+	files, err := ReadDir(dir, "classic")
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if want := []string{"classic-00000-02000000.erh", "classic-00001-01000000.erh"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files mismatch: have %v, want %v", files, want)
+	}
+	ioutil.WriteFile(filepath.Join(dir, Filename("classic", 3, common.Hash{})), nil, 0644)
+	if _, err := ReadDir(dir, "classic"); err == nil {
+		t.Error("read dir with missing epoch")
+	}
+}
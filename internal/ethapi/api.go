@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,12 +37,18 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/parity"
 	"github.com/ethereum/go-ethereum/params/vars"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -394,7 +401,7 @@ func (s *PrivateAccountAPI) SignTransaction(ctx context.Context, args SendTxArgs
 		log.Warn("Failed transaction sign attempt", "from", args.From, "to", args.To, "value", args.Value.ToInt(), "err", err)
 		return nil, err
 	}
-	data, err := rlp.EncodeToBytes(signed)
+	data, err := signed.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
@@ -518,6 +525,135 @@ func (s *PublicBlockChainAPI) ChainId() *hexutil.Big {
 	return (*hexutil.Big)(s.b.ChainConfig().GetChainID())
 }
 
+// chainConfigFormats are the formats in which ChainConfig serializes the chain configuration.
+var chainConfigFormats = map[string]func() ctypes.ChainConfigurator{
+	"coregeth": func() ctypes.ChainConfigurator { return &coregeth.CoreGethChainConfig{} },
+	"geth":     func() ctypes.ChainConfigurator { return &goethereum.ChainConfig{} },
+	"parity":   func() ctypes.ChainConfigurator { return &parity.ParityChainSpec{} },
+}
+
+// RPCForkID is the JSON representation of an EIP-2124 fork ID.
+type RPCForkID struct {
+	Hash hexutil.Bytes  `json:"hash"`
+	Next hexutil.Uint64 `json:"next"`
+}
+
+func newRPCForkID(id forkid.ID) *RPCForkID {
+	return &RPCForkID{Hash: id.Hash[:], Next: hexutil.Uint64(id.Next)}
+}
+
+// errMissingGenesis is returned if the genesis header isn't available, eg. to
+// a light client before its first sync.
+var errMissingGenesis = errors.New("genesis header not available")
+
+// genesisHash returns the hash of the genesis header of the chain.
+func (s *PublicBlockChainAPI) genesisHash(ctx context.Context) (common.Hash, error) {
+	genesis, err := s.b.HeaderByNumber(ctx, 0)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if genesis == nil {
+		return common.Hash{}, errMissingGenesis
+	}
+	return genesis.Hash(), nil
+}
+
+// ChainConfigResult is the chain configuration of the node, with its forks and fork IDs.
+type ChainConfigResult struct {
+	Format     string           `json:"format"`
+	Config     interface{}      `json:"config"`
+	Forks      []hexutil.Uint64 `json:"forks"`
+	ForkID     *RPCForkID       `json:"forkId"`
+	NextForkID *RPCForkID       `json:"nextForkId"` // nil if no further forks are configured
+}
+
+// ChainConfig returns the chain configuration of the node, serialized in the given
+// format (coregeth, geth or parity; coregeth by default), along with its forks and
+// the fork IDs at the current head and at the next fork.
+func (s *PublicBlockChainAPI) ChainConfig(ctx context.Context, format *string) (*ChainConfigResult, error) {
+	name := "coregeth"
+	if format != nil && *format != "" {
+		name = *format
+	}
+	newConfig, ok := chainConfigFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported chain configuration format: %s", name)
+	}
+	config := newConfig()
+	if err := confp.Convert(s.b.ChainConfig(), config); err != nil {
+		return nil, err
+	}
+	genesis, err := s.genesisHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head := s.b.CurrentBlock().NumberU64()
+	result := &ChainConfigResult{
+		Format: name,
+		Config: config,
+		Forks:  []hexutil.Uint64{},
+		ForkID: newRPCForkID(forkid.NewIDFromConfig(s.b.ChainConfig(), genesis, head)),
+	}
+	for _, fork := range confp.Forks(s.b.ChainConfig()) {
+		result.Forks = append(result.Forks, hexutil.Uint64(fork))
+	}
+	if next := result.ForkID.Next; next != 0 {
+		result.NextForkID = newRPCForkID(forkid.NewIDFromConfig(s.b.ChainConfig(), genesis, uint64(next)))
+	}
+	return result, nil
+}
+
+// FeaturesResult is the set of protocol features active at a block.
+type FeaturesResult struct {
+	Number          hexutil.Uint64            `json:"number"`
+	ConsensusEngine string                    `json:"consensusEngine"`
+	Features        map[string]hexutil.Uint64 `json:"features"` // activation blocks of the active EIPs and ECIPs
+	Precompiles     []common.Address          `json:"precompiles"`
+	ForkID          *RPCForkID                `json:"forkId"`
+}
+
+// FeaturesAt returns the EIPs, ECIPs and precompiled contracts active at the given block,
+// which may be in the future.
+func (s *PublicBlockChainAPI) FeaturesAt(ctx context.Context, blockNr rpc.BlockNumber) (*FeaturesResult, error) {
+	var number uint64
+	if blockNr >= 0 {
+		number = uint64(blockNr)
+	} else {
+		header, err := s.b.HeaderByNumber(ctx, blockNr)
+		if header == nil || err != nil {
+			return nil, err
+		}
+		number = header.Number.Uint64()
+	}
+	genesis, err := s.genesisHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config = s.b.ChainConfig()
+		bn     = new(big.Int).SetUint64(number)
+	)
+	result := &FeaturesResult{
+		Number:          hexutil.Uint64(number),
+		ConsensusEngine: config.GetConsensusEngineType().String(),
+		Features:        make(map[string]hexutil.Uint64),
+		Precompiles:     vm.ActivePrecompiles(config, bn),
+		ForkID:          newRPCForkID(forkid.NewIDFromConfig(config, genesis, number)),
+	}
+	fns, names := confp.Transitions(config)
+	for i, fn := range fns {
+		if !config.IsEnabled(fn, bn) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(names[i], "Get"), "Transition")
+		result.Features[name] = hexutil.Uint64(*fn())
+	}
+	sort.Slice(result.Precompiles, func(i, j int) bool {
+		return bytes.Compare(result.Precompiles[i][:], result.Precompiles[j][:]) < 0
+	})
+	return result, nil
+}
+
 // BlockNumber returns the block number of the chain head.
 func (s *PublicBlockChainAPI) BlockNumber() hexutil.Uint64 {
 	header, _ := s.b.HeaderByNumber(context.Background(), rpc.LatestBlockNumber) // latest header should always be available
@@ -732,12 +868,13 @@ func (s *PublicBlockChainAPI) GetStorageAt(ctx context.Context, address common.A
 
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
-	From     *common.Address `json:"from"`
-	To       *common.Address `json:"to"`
-	Gas      *hexutil.Uint64 `json:"gas"`
-	GasPrice *hexutil.Big    `json:"gasPrice"`
-	Value    *hexutil.Big    `json:"value"`
-	Data     *hexutil.Bytes  `json:"data"`
+	From       *common.Address   `json:"from"`
+	To         *common.Address   `json:"to"`
+	Gas        *hexutil.Uint64   `json:"gas"`
+	GasPrice   *hexutil.Big      `json:"gasPrice"`
+	Value      *hexutil.Big      `json:"value"`
+	Data       *hexutil.Bytes    `json:"data"`
+	AccessList *types.AccessList `json:"accessList"`
 }
 
 // ToMessage converts CallArgs to the Message type used by the core evm
@@ -772,7 +909,12 @@ func (args *CallArgs) ToMessage(globalGasCap *big.Int) types.Message {
 		data = []byte(*args.Data)
 	}
 
-	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false)
+	var accessList types.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+
+	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, accessList, false)
 	return msg
 }
 
@@ -1163,20 +1305,23 @@ func (s *PublicBlockChainAPI) rpcMarshalBlock(b *types.Block, inclTx bool, fullT
 
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        *common.Hash      `json:"blockHash"`
+	BlockNumber      *hexutil.Big      `json:"blockNumber"`
+	From             common.Address    `json:"from"`
+	Gas              hexutil.Uint64    `json:"gas"`
+	GasPrice         *hexutil.Big      `json:"gasPrice"`
+	Hash             common.Hash       `json:"hash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            hexutil.Uint64    `json:"nonce"`
+	To               *common.Address   `json:"to"`
+	TransactionIndex *hexutil.Uint64   `json:"transactionIndex"`
+	Value            *hexutil.Big      `json:"value"`
+	Type             hexutil.Uint64    `json:"type"`
+	Accesses         *types.AccessList `json:"accessList,omitempty"`
+	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1184,7 +1329,7 @@ type RPCTransaction struct {
 func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, index uint64) *RPCTransaction {
 	var signer types.Signer = types.FrontierSigner{}
 	if tx.Protected() {
-		signer = types.NewEIP155Signer(tx.ChainId())
+		signer = types.LatestSignerForChainID(tx.ChainId())
 	}
 	from, _ := types.Sender(signer, tx)
 	v, r, s := tx.RawSignatureValues()
@@ -1198,10 +1343,16 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		Nonce:    hexutil.Uint64(tx.Nonce()),
 		To:       tx.To(),
 		Value:    (*hexutil.Big)(tx.Value()),
+		Type:     hexutil.Uint64(tx.Type()),
 		V:        (*hexutil.Big)(v),
 		R:        (*hexutil.Big)(r),
 		S:        (*hexutil.Big)(s),
 	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainId())
+	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
@@ -1230,7 +1381,7 @@ func newRPCRawTransactionFromBlockIndex(b *types.Block, index uint64) hexutil.By
 	if index >= uint64(len(txs)) {
 		return nil
 	}
-	blob, _ := rlp.EncodeToBytes(txs[index])
+	blob, _ := txs[index].MarshalBinary()
 	return blob
 }
 
@@ -1357,7 +1508,7 @@ func (s *PublicTransactionPoolAPI) GetRawTransactionByHash(ctx context.Context,
 		}
 	}
 	// Serialize to RLP and return
-	return rlp.EncodeToBytes(tx)
+	return tx.MarshalBinary()
 }
 
 // GetTransactionReceipt returns the transaction receipt for the given transaction hash.
@@ -1377,7 +1528,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 
 	var signer types.Signer = types.FrontierSigner{}
 	if tx.Protected() {
-		signer = types.NewEIP155Signer(tx.ChainId())
+		signer = types.LatestSignerForChainID(tx.ChainId())
 	}
 	from, _ := types.Sender(signer, tx)
 
@@ -1388,6 +1539,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 		"transactionIndex":  hexutil.Uint64(index),
 		"from":              from,
 		"to":                tx.To(),
+		"type":              hexutil.Uint(tx.Type()),
 		"gasUsed":           hexutil.Uint64(receipt.GasUsed),
 		"cumulativeGasUsed": hexutil.Uint64(receipt.CumulativeGasUsed),
 		"contractAddress":   nil,
@@ -1436,6 +1588,10 @@ type SendTxArgs struct {
 	// newer name and should be preferred by clients.
 	Data  *hexutil.Bytes `json:"data"`
 	Input *hexutil.Bytes `json:"input"`
+
+	// For EIP-2718 typed transactions.
+	AccessList *types.AccessList `json:"accessList,omitempty"`
+	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
 }
 
 // setDefaults is a helper function that fills in default values for unspecified tx fields.
@@ -1481,11 +1637,12 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 			input = args.Data
 		}
 		callArgs := CallArgs{
-			From:     &args.From, // From shouldn't be nil
-			To:       args.To,
-			GasPrice: args.GasPrice,
-			Value:    args.Value,
-			Data:     input,
+			From:       &args.From, // From shouldn't be nil
+			To:         args.To,
+			GasPrice:   args.GasPrice,
+			Value:      args.Value,
+			Data:       input,
+			AccessList: args.AccessList,
 		}
 		pendingBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
 		estimated, err := DoEstimateGas(ctx, b, callArgs, pendingBlockNr, b.RPCGasCap())
@@ -1495,6 +1652,9 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 		args.Gas = &estimated
 		log.Trace("Estimate gas usage automatically", "gas", args.Gas)
 	}
+	if args.ChainID == nil {
+		args.ChainID = (*hexutil.Big)(b.ChainConfig().GetChainID())
+	}
 	return nil
 }
 
@@ -1505,10 +1665,29 @@ func (args *SendTxArgs) toTransaction() *types.Transaction {
 	} else if args.Data != nil {
 		input = *args.Data
 	}
-	if args.To == nil {
-		return types.NewContractCreation(uint64(*args.Nonce), (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+	var data types.TxData
+	if args.AccessList == nil {
+		data = &types.LegacyTx{
+			To:       args.To,
+			Nonce:    uint64(*args.Nonce),
+			Gas:      uint64(*args.Gas),
+			GasPrice: (*big.Int)(args.GasPrice),
+			Value:    (*big.Int)(args.Value),
+			Data:     input,
+		}
+	} else {
+		data = &types.AccessListTx{
+			To:         args.To,
+			ChainID:    (*big.Int)(args.ChainID),
+			Nonce:      uint64(*args.Nonce),
+			Gas:        uint64(*args.Gas),
+			GasPrice:   (*big.Int)(args.GasPrice),
+			Value:      (*big.Int)(args.Value),
+			Data:       input,
+			AccessList: *args.AccessList,
+		}
 	}
-	return types.NewTransaction(uint64(*args.Nonce), *args.To, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+	return types.NewTx(data)
 }
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
@@ -1571,7 +1750,7 @@ func (s *PublicTransactionPoolAPI) FillTransaction(ctx context.Context, args Sen
 	}
 	// Assemble the transaction and obtain rlp
 	tx := args.toTransaction()
-	data, err := rlp.EncodeToBytes(tx)
+	data, err := tx.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
@@ -1582,7 +1761,7 @@ func (s *PublicTransactionPoolAPI) FillTransaction(ctx context.Context, args Sen
 // The sender is responsible for signing the transaction and using the correct nonce.
 func (s *PublicTransactionPoolAPI) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
 	tx := new(types.Transaction)
-	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+	if err := tx.UnmarshalBinary(encodedTx); err != nil {
 		return common.Hash{}, err
 	}
 	return SubmitTransaction(ctx, s.b, tx)
@@ -1639,7 +1818,7 @@ func (s *PublicTransactionPoolAPI) SignTransaction(ctx context.Context, args Sen
 	if err != nil {
 		return nil, err
 	}
-	data, err := rlp.EncodeToBytes(tx)
+	data, err := tx.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
@@ -1663,7 +1842,7 @@ func (s *PublicTransactionPoolAPI) PendingTransactions() ([]*RPCTransaction, err
 	for _, tx := range pending {
 		var signer types.Signer = types.HomesteadSigner{}
 		if tx.Protected() {
-			signer = types.NewEIP155Signer(tx.ChainId())
+			signer = types.LatestSignerForChainID(tx.ChainId())
 		}
 		from, _ := types.Sender(signer, tx)
 		if _, exists := accounts[from]; exists {
@@ -1691,7 +1870,7 @@ func (s *PublicTransactionPoolAPI) Resend(ctx context.Context, sendArgs SendTxAr
 	for _, p := range pending {
 		var signer types.Signer = types.HomesteadSigner{}
 		if p.Protected() {
-			signer = types.NewEIP155Signer(p.ChainId())
+			signer = types.LatestSignerForChainID(p.ChainId())
 		}
 		wantSigHash := signer.Hash(matchTx)
 
@@ -1796,7 +1975,9 @@ func (api *PublicDebugAPI) SeedHash(ctx context.Context, number uint64) (string,
 	if block == nil {
 		return "", fmt.Errorf("block #%d not found", number)
 	}
-	return fmt.Sprintf("0x%x", ethash.SeedHash(number)), nil
+	epochLength := ethash.CalcEpochLength(number, api.b.ChainConfig().GetEthashECIP1099Transition())
+	epoch := ethash.CalcEpoch(number, epochLength)
+	return fmt.Sprintf("0x%x", ethash.SeedHash(epoch, epochLength)), nil
 }
 
 // PrivateDebugAPI is the collection of Ethereum APIs exposed over the private
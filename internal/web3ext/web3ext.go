@@ -22,6 +22,7 @@ var Modules = map[string]string{
 	"admin":      AdminJs,
 	"chequebook": ChequebookJs,
 	"clique":     CliqueJs,
+	"dev":        DevJs,
 	"ethash":     EthashJs,
 	"debug":      DebugJs,
 	"eth":        EthJs,
@@ -106,7 +107,8 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'status',
 			call: 'clique_status',
-			params: 0
+			params: 1,
+			inputFormatter: [null]
 		}),
 	],
 	properties: [
@@ -118,6 +120,25 @@ web3._extend({
 });
 `
 
+const DevJs = `
+web3._extend({
+	property: 'dev',
+	methods: [
+		new web3._extend.Method({
+			name: 'mine',
+			call: 'dev_mine',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'setTime',
+			call: 'dev_setTime',
+			params: 1
+		}),
+	]
+});
+`
+
 const EthashJs = `
 web3._extend({
 	property: 'ethash',
@@ -475,6 +496,18 @@ web3._extend({
 			call: 'eth_chainId',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'chainConfig',
+			call: 'eth_chainConfig',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'featuresAt',
+			call: 'eth_featuresAt',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'sign',
 			call: 'eth_sign',
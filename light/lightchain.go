@@ -551,6 +551,12 @@ func (lc *LightChain) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent)
 	return lc.scope.Track(new(event.Feed).Subscribe(ch))
 }
 
+// SubscribeChainReorgEvent implements the interface of filters.Backend
+// LightChain does not send core.ChainReorgEvent, so return an empty subscription.
+func (lc *LightChain) SubscribeChainReorgEvent(ch chan<- core.ChainReorgEvent) event.Subscription {
+	return lc.scope.Track(new(event.Feed).Subscribe(ch))
+}
+
 // DisableCheckFreq disables header validation. This is used for ultralight mode.
 func (lc *LightChain) DisableCheckFreq() {
 	atomic.StoreInt32(&lc.disableCheckFreq, 1)
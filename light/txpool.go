@@ -70,6 +70,7 @@ type TxPool struct {
 
 	eip2f    bool
 	eip2028f bool
+	eip2718  bool // Fork indicator whether we are in the EIP-2718 stage.
 }
 
 // TxRelayBackend provides an interface to the mechanism that forwards transacions
@@ -91,7 +92,7 @@ type TxRelayBackend interface {
 func NewTxPool(config ctypes.ChainConfigurator, chain *LightChain, relay TxRelayBackend) *TxPool {
 	pool := &TxPool{
 		config:      config,
-		signer:      types.NewEIP155Signer(config.GetChainID()),
+		signer:      types.LatestSigner(config),
 		nonce:       make(map[common.Address]uint64),
 		pending:     make(map[common.Hash]*types.Transaction),
 		mined:       make(map[common.Hash][]*types.Transaction),
@@ -333,6 +334,7 @@ func (pool *TxPool) setNewHead(head *types.Header) {
 	// Update fork indicator by next pending block number
 	next := new(big.Int).Add(head.Number, big.NewInt(1))
 	pool.eip2028f = pool.config.IsEnabled(pool.config.GetEIP2028Transition, next)
+	pool.eip2718 = pool.config.IsEnabled(pool.config.GetEIP2718Transition, next)
 }
 
 // Stop stops the light transaction pool
@@ -362,6 +364,10 @@ func (pool *TxPool) Stats() (pending int) {
 
 // validateTx checks whether a transaction is valid according to the consensus rules.
 func (pool *TxPool) validateTx(ctx context.Context, tx *types.Transaction) error {
+	// Reject typed transactions before the EIP-2718 fork activates.
+	if !pool.eip2718 && tx.Type() != types.LegacyTxType {
+		return core.ErrTxTypeNotSupported
+	}
 	// Validate sender
 	var (
 		from common.Address
@@ -400,7 +406,7 @@ func (pool *TxPool) validateTx(ctx context.Context, tx *types.Transaction) error
 	}
 
 	// Should supply enough intrinsic gas
-	gas, err := core.IntrinsicGas(tx.Data(), tx.To() == nil, pool.eip2f, pool.eip2028f)
+	gas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, pool.eip2f, pool.eip2028f)
 	if err != nil {
 		return err
 	}
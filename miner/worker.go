@@ -27,6 +27,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/dev"
 	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -140,6 +141,8 @@ type worker struct {
 	chainHeadSub event.Subscription
 	chainSideCh  chan core.ChainSideEvent
 	chainSideSub event.Subscription
+	warpCh       chan struct{}
+	warpSub      event.Subscription
 
 	// Channels
 	newWorkCh          chan *newWorkReq
@@ -216,6 +219,11 @@ func newWorker(config *Config, chainConfig ctypes.ChainConfigurator, engine cons
 	// Subscribe events for blockchain
 	worker.chainHeadSub = eth.BlockChain().SubscribeChainHeadEvent(worker.chainHeadCh)
 	worker.chainSideSub = eth.BlockChain().SubscribeChainSideEvent(worker.chainSideCh)
+	// Subscribe clock warps of the developer engine, outdating the pending work
+	if engine, ok := engine.(*dev.Dev); ok {
+		worker.warpCh = make(chan struct{})
+		worker.warpSub = engine.SubscribeWarp(worker.warpCh)
+	}
 
 	// Sanitize recommit interval if the user-specified one is too short.
 	recommit := worker.config.Recommit
@@ -308,6 +316,9 @@ func (w *worker) close() {
 
 // newWorkLoop is a standalone goroutine to submit new mining work upon received events.
 func (w *worker) newWorkLoop(recommit time.Duration) {
+	if w.warpSub != nil {
+		defer w.warpSub.Unsubscribe()
+	}
 	var (
 		interrupt   *int32
 		minRecommit = recommit // minimal resubmit interval specified by user.
@@ -318,6 +329,10 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 	defer timer.Stop()
 	<-timer.C // discard the initial tick
 
+	idle := time.NewTimer(0)
+	defer idle.Stop()
+	<-idle.C // discard the initial tick
+
 	// commit aborts in-flight transaction execution with given signal and resubmits a new one.
 	commit := func(noempty bool, s int32) {
 		if interrupt != nil {
@@ -349,6 +364,21 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		}
 		recommit = time.Duration(int64(next))
 	}
+	// resetIdle schedules the submission of work once the maximum idle interval
+	// of an on-demand clique chain elapsed since the given head.
+	resetIdle := func(head *types.Header) {
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		maxIdle := w.chainConfig.GetCliqueMaxIdle()
+		if !w.cliqueOnDemand(head.Number.Uint64()+1) || maxIdle == 0 {
+			return
+		}
+		idle.Reset(time.Until(time.Unix(int64(head.Time+maxIdle), 0)))
+	}
 	// clearPending cleans the stale pending tasks.
 	clearPending := func(number uint64) {
 		w.pendingMu.Lock()
@@ -364,11 +394,13 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		select {
 		case <-w.startCh:
 			clearPending(w.chain.CurrentBlock().NumberU64())
+			resetIdle(w.chain.CurrentHeader())
 			timestamp = time.Now().Unix()
 			commit(false, commitInterruptNewHead)
 
 		case head := <-w.chainHeadCh:
 			clearPending(head.Block.NumberU64())
+			resetIdle(head.Block.Header())
 			timestamp = time.Now().Unix()
 			commit(false, commitInterruptNewHead)
 
@@ -397,6 +429,20 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 				w.resubmitHook(minRecommit, recommit)
 			}
 
+		case <-idle.C:
+			// The on-demand clique chain was idle for long enough, submit work
+			// which may be sealed without transactions to keep it alive.
+			if w.isRunning() {
+				timestamp = time.Now().Unix()
+				commit(false, commitInterruptResubmit)
+			}
+
+		case <-w.warpCh:
+			// The clock of the developer engine was warped, submit work with the
+			// new timestamps.
+			timestamp = time.Now().Unix()
+			commit(false, commitInterruptResubmit)
+
 		case adjust := <-w.resubmitAdjustCh:
 			// Adjust resubmit interval by feedback.
 			if adjust.inc {
@@ -501,8 +547,16 @@ func (w *worker) mainLoop() {
 				// Special case, if the consensus engine is 0 period clique(dev mode),
 				// submit mining work here since all empty submission will be rejected
 				// by clique. Of course the advance sealing(empty submission) is disabled.
-				if w.chainConfig.GetConsensusEngineType().IsClique() && w.chainConfig.GetCliquePeriod() == 0 {
-					w.commitNewWork(nil, true, time.Now().Unix())
+				// The same goes for on-demand clique, and for the developer engine
+				// without period, whatever the chain's engine.
+				if engine, ok := w.engine.(*dev.Dev); ok {
+					if engine.Period() == 0 {
+						w.commitNewWork(nil, true, time.Now().Unix())
+					}
+				} else if w.chainConfig.GetConsensusEngineType().IsClique() {
+					if w.chainConfig.GetCliquePeriod() == 0 || w.cliqueOnDemand(w.chain.CurrentBlock().NumberU64()+1) {
+						w.commitNewWork(nil, true, time.Now().Unix())
+					}
 				}
 			}
 			atomic.AddInt32(&w.newTxs, int32(len(ev.Txs)))
@@ -842,6 +896,16 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 	return false
 }
 
+// cliqueOnDemand returns whether the chain is a clique chain sealing the block with
+// the given number only if it has transactions, or once the chain was idle.
+func (w *worker) cliqueOnDemand(number uint64) bool {
+	if !w.chainConfig.GetConsensusEngineType().IsClique() {
+		return false
+	}
+	transition := w.chainConfig.GetCliqueOnDemandTransition()
+	return transition != nil && *transition <= number
+}
+
 // commitNewWork generates several new sealing tasks based on the parent block.
 func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64) {
 	w.mu.RLock()
@@ -854,7 +918,11 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		timestamp = int64(parent.Time() + 1)
 	}
 	// this will ensure we're not going off too far in the future
-	if now := time.Now().Unix(); timestamp > now+1 {
+	now := time.Now().Unix()
+	if engine, ok := w.engine.(*dev.Dev); ok {
+		now = engine.Now() // the developer engine may warp its clock
+	}
+	if timestamp > now+1 {
 		wait := time.Duration(timestamp-now) * time.Second
 		log.Info("Mining too far in the future", "wait", common.PrettyDuration(wait))
 		time.Sleep(wait)
@@ -932,7 +1000,10 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 
 	// Create an empty block based on temporary copied state for
 	// sealing in advance without waiting block execution finished.
-	if !noempty && atomic.LoadUint32(&w.noempty) == 0 {
+	// On-demand clique chains don't race empty blocks against full
+	// ones, they only seal them once idle.
+	onDemand := w.cliqueOnDemand(header.Number.Uint64())
+	if !noempty && atomic.LoadUint32(&w.noempty) == 0 && !onDemand {
 		w.commit(uncles, nil, false, tstart)
 	}
 
@@ -945,7 +1016,7 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	// Short circuit if there is no available pending transactions.
 	// But if we disable empty precommit already, ignore it. Since
 	// empty block is necessary to keep the liveness of the network.
-	if len(pending) == 0 && atomic.LoadUint32(&w.noempty) == 0 {
+	if len(pending) == 0 && atomic.LoadUint32(&w.noempty) == 0 && !onDemand {
 		w.updateSnapshot()
 		return
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/dev"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -114,6 +115,7 @@ func newTestWorkerBackend(t *testing.T, chainConfig ctypes.ChainConfigurator, en
 			return crypto.Sign(crypto.Keccak256(data), testBankKey)
 		})
 	case *ethash.Ethash:
+	case *dev.Dev:
 	default:
 		t.Fatalf("unexpected consensus engine type: %T", engine)
 	}
@@ -251,6 +253,122 @@ func testGenerateBlockAndImport(t *testing.T, isClique bool) {
 	}
 }
 
+func TestInstantSeal(t *testing.T) {
+	engine := dev.New(params.AllEthashProtocolChanges, 0)
+	defer engine.Close()
+
+	w, b := newTestWorker(t, params.AllEthashProtocolChanges, engine, rawdb.NewMemoryDatabase(), 0)
+	defer w.close()
+	w.disablePreseal()
+
+	// Wait for mined blocks.
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	waitBlock := func(txs int) *types.Block {
+		select {
+		case ev := <-sub.Chan():
+			block := ev.Data.(core.NewMinedBlockEvent).Block
+			if len(block.Transactions()) != txs {
+				t.Fatalf("block %d transactions mismatch: have %d, want %d", block.NumberU64(), len(block.Transactions()), txs)
+			}
+			return block
+		case <-time.After(3 * time.Second):
+			t.Fatalf("timeout")
+		}
+		return nil
+	}
+	w.start()
+
+	// Pending and new transactions are sealed instantly
+	waitBlock(1)
+	b.txPool.AddLocal(b.newRandomTx(false))
+	waitBlock(1)
+
+	// Empty blocks are only sealed on request
+	select {
+	case <-sub.Chan():
+		t.Fatalf("empty block sealed without request")
+	case <-time.After(200 * time.Millisecond):
+	}
+	engine.Mine(2)
+	waitBlock(0)
+	waitBlock(0)
+
+	// Blocks sealed after a clock warp have the new timestamps
+	future := uint64(time.Now().Add(24 * time.Hour).Unix())
+	if err := engine.SetTime(b.chain.CurrentHeader(), future); err != nil {
+		t.Fatalf("failed to warp clock: %v", err)
+	}
+	engine.Mine(1)
+	if block := waitBlock(0); block.Time() < future {
+		t.Errorf("block time mismatch: have %d, want >= %d", block.Time(), future)
+	}
+	engine.Mine(1)
+	if block := waitBlock(0); block.Time() <= future {
+		t.Errorf("block time mismatch: have %d, want > %d", block.Time(), future)
+	}
+}
+
+func TestOnDemandClique(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		config   = *params.KottiChainConfig
+		onDemand = uint64(1)
+	)
+	config.Clique = &ctypes.CliqueConfig{Period: 1, Epoch: 30000, OnDemandBlock: &onDemand, MaxIdle: 2}
+	engine := clique.New(config.Clique, db)
+
+	w, b := newTestWorker(t, &config, engine, db, 0)
+	defer w.close()
+
+	// Wait for mined blocks.
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	// Wait for mined blocks on top of the last one, skipping competing siblings.
+	var last uint64
+	nextBlock := func(timeout time.Duration) *types.Block {
+		deadline := time.After(timeout)
+		for {
+			select {
+			case ev := <-sub.Chan():
+				if block := ev.Data.(core.NewMinedBlockEvent).Block; block.NumberU64() > last {
+					last = block.NumberU64()
+					return block
+				}
+			case <-deadline:
+				return nil
+			}
+		}
+	}
+	w.start()
+
+	// The chain was idle since genesis, so the pending transaction may follow
+	// empty blocks
+	for block := nextBlock(4 * time.Second); len(block.Transactions()) == 0; block = nextBlock(4 * time.Second) {
+	}
+	// New transactions are sealed as soon as the period allows
+	tx := b.newRandomTx(false)
+	b.txPool.AddLocal(tx)
+	parent := nextBlock(4 * time.Second)
+	if parent == nil || parent.Transaction(tx.Hash()) == nil {
+		t.Fatalf("transaction not sealed")
+	}
+
+	// Empty blocks are only sealed once the chain was idle for long enough
+	if block := nextBlock(time.Second); block != nil {
+		t.Fatalf("empty block %d sealed before idle interval", block.NumberU64())
+	}
+	block := nextBlock(4 * time.Second)
+	if block == nil || len(block.Transactions()) != 0 {
+		t.Fatalf("empty block not sealed once idle")
+	}
+	if block.Time() < parent.Time()+2 {
+		t.Errorf("block time mismatch: have %d, want >= %d", block.Time(), parent.Time()+2)
+	}
+}
+
 func TestEmptyWorkEthash(t *testing.T) {
 	testEmptyWork(t, ethashChainConfig, ethash.NewFaker())
 }
@@ -68,6 +68,8 @@ var (
 		EIP2028FBlock: big.NewInt(10_500_839),
 		EIP2200FBlock: big.NewInt(10_500_839), // RePetersburg (=~ re-1283)
 
+		ECBP1100FBlock: big.NewInt(11_380_000),
+
 		DisposalBlock:      big.NewInt(5900000),
 		ECIP1017FBlock:     big.NewInt(5000000),
 		ECIP1017EraRounds:  big.NewInt(5000000),
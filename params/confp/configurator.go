@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 )
 
@@ -133,6 +134,11 @@ func compatible(head *uint64, a, b ctypes.ChainConfigurator) *ConfigCompatError
 	aFns, aNames := Transitions(a)
 	bFns, _ := Transitions(b)
 	for i, afn := range aFns {
+		// Skip transitions which don't change consensus rules;
+		// historical blocks remain valid irrespective of their values.
+		if !IsConsensusTransition(aNames[i]) {
+			continue
+		}
 		if err := func(c1, c2, head *uint64) *ConfigCompatError {
 			if isForkIncompatible(c1, c2, head) {
 				return NewCompatError("incompatible fork value: "+aNames[i], c1, c2)
@@ -142,6 +148,9 @@ func compatible(head *uint64, a, b ctypes.ChainConfigurator) *ConfigCompatError
 			return err
 		}
 	}
+	if err := compatibleCustomPrecompiles(head, a.GetCustomPrecompiles(), b.GetCustomPrecompiles()); err != nil {
+		return err
+	}
 	if head == nil {
 		return nil
 	}
@@ -154,6 +163,40 @@ func compatible(head *uint64, a, b ctypes.ChainConfigurator) *ConfigCompatError
 	return nil
 }
 
+// compatibleCustomPrecompiles checks that the custom precompiles of two configurations
+// do not differ at or before the head; a changed precompile is treated as a fork
+// at the lower of its activation blocks.
+func compatibleCustomPrecompiles(head *uint64, a, b ctypes.CustomPrecompiles) *ConfigCompatError {
+	var lowest *ConfigCompatError
+	check := func(addr common.Address) {
+		pa, aok := a[addr]
+		pb, bok := b[addr]
+		var fa, fb *uint64
+		if aok {
+			fa = pa.ActivationBlock
+		}
+		if bok {
+			fb = pb.ActivationBlock
+		}
+		if !isForked(fa, head) && !isForked(fb, head) || reflect.DeepEqual(pa, pb) {
+			return
+		}
+		err := NewCompatError("incompatible custom precompile: "+addr.Hex(), fa, fb)
+		if lowest == nil || err.RewindTo < lowest.RewindTo {
+			lowest = err
+		}
+	}
+	for addr := range a {
+		check(addr)
+	}
+	for addr := range b {
+		if _, ok := a[addr]; !ok {
+			check(addr)
+		}
+	}
+	return lowest
+}
+
 func Equivalent(a, b ctypes.ChainConfigurator) error {
 	if a.GetConsensusEngineType() != b.GetConsensusEngineType() {
 		return fmt.Errorf("mismatch consensus engine types, A: %s, B: %s", a.GetConsensusEngineType(), b.GetConsensusEngineType())
@@ -247,13 +290,67 @@ func Transitions(conf ctypes.ChainConfigurator) (fns []func() *uint64, names []s
 	return fns, names
 }
 
+// TransitionNames returns the names of all transition (fork) methods of the ChainConfigurator
+// interface, stripped of their Get prefix and Transition suffix, eg. "EIP155" or "EthashECIP1099".
+func TransitionNames() []string {
+	names := []string{}
+	k := reflect.TypeOf((*ctypes.ChainConfigurator)(nil)).Elem()
+	for i := 0; i < k.NumMethod(); i++ {
+		method := k.Method(i)
+		if !strings.HasPrefix(method.Name, "Get") || !strings.HasSuffix(method.Name, "Transition") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(method.Name, "Get"), "Transition")
+		if _, ok := k.MethodByName("Set" + name + "Transition"); !ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// SetTransition sets the named transition (as returned by TransitionNames) of the configurator
+// to the given block number.
+func SetTransition(conf ctypes.ChainConfigurator, name string, n *uint64) error {
+	method := reflect.ValueOf(conf).MethodByName("Set" + name + "Transition")
+	if !method.IsValid() {
+		return fmt.Errorf("unknown transition: %s", name)
+	}
+	res := method.Call([]reflect.Value{reflect.ValueOf(n)})
+	if !res[0].IsNil() {
+		var v interface{} = n
+		if n != nil {
+			v = *n
+		}
+		return ctypes.UnsupportedConfigError(res[0].Interface().(error), name, v)
+	}
+	return nil
+}
+
+// nonConsensusTransitions are Transition methods which do not modify the consensus
+// rules of the protocol, and therefore are not considered to be forks.
+var nonConsensusTransitions = map[string]struct{}{
+	// ECBP1100 (MESS) modifies only the subjective chain selection (reorg) preferences.
+	"GetECBP1100Transition": {},
+}
+
+// IsConsensusTransition returns false if the named transition method does not
+// affect consensus rules, ie. it should not be considered a fork (eg. in forkid calculation).
+func IsConsensusTransition(name string) bool {
+	_, ok := nonConsensusTransitions[name]
+	return !ok
+}
+
 // Forks returns non-nil, non <maxUin64>, unique sorted forks for a ChainConfigurator.
 func Forks(conf ctypes.ChainConfigurator) []uint64 {
 	var forks []uint64
 	var forksM = make(map[uint64]struct{}) // Will key for uniqueness as fork numbers are appended to slice.
 
-	transitions, _ := Transitions(conf)
-	for _, tr := range transitions {
+	transitions, names := Transitions(conf)
+	for i, tr := range transitions {
+		if !IsConsensusTransition(names[i]) {
+			continue
+		}
 		// Extract the fork rule block number and aggregate it
 		response := tr()
 		if response == nil ||
@@ -269,6 +366,16 @@ func Forks(conf ctypes.ChainConfigurator) []uint64 {
 			forksM[*response] = struct{}{}
 		}
 	}
+	// Custom precompile activations are forks too.
+	for _, p := range conf.GetCustomPrecompiles() {
+		if p.ActivationBlock == nil || *p.ActivationBlock == 0 {
+			continue
+		}
+		if _, ok := forksM[*p.ActivationBlock]; !ok {
+			forks = append(forks, *p.ActivationBlock)
+			forksM[*p.ActivationBlock] = struct{}{}
+		}
+	}
 	sort.Slice(forks, func(i, j int) bool {
 		return forks[i] < forks[j]
 	})
@@ -0,0 +1,214 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package confp
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// Diff returns every difference between two configurations: their protocol parameters
+// and transitions, custom precompiles, fork canon hashes, consensus engine parameters
+// (including the block reward and difficulty bomb delay schedules) and, if both are
+// genesis configurators, their genesis fields and accounts.
+// Unlike Equivalent, it does not stop at the first difference.
+func Diff(a, b interface{}) []DiffT {
+	diffs := []DiffT{}
+
+	ac, aok := a.(ctypes.ChainConfigurator)
+	bc, bok := b.(ctypes.ChainConfigurator)
+	if aok && bok {
+		diffs = append(diffs, diffGetters(reflect.TypeOf((*ctypes.ProtocolSpecifier)(nil)).Elem(), ac, bc)...)
+		diffs = append(diffs, diffValues("ForkCanonHashes", ac.GetForkCanonHashes(), bc.GetForkCanonHashes())...)
+		diffs = append(diffs, diffValues("ConsensusEngineType", ac.GetConsensusEngineType(), bc.GetConsensusEngineType())...)
+		if ac.GetConsensusEngineType().IsEthash() || bc.GetConsensusEngineType().IsEthash() {
+			diffs = append(diffs, diffGetters(reflect.TypeOf((*ctypes.EthashConfigurator)(nil)).Elem(), ac, bc)...)
+		}
+		if ac.GetConsensusEngineType().IsClique() || bc.GetConsensusEngineType().IsClique() {
+			diffs = append(diffs, diffGetters(reflect.TypeOf((*ctypes.CliqueConfigurator)(nil)).Elem(), ac, bc)...)
+		}
+	}
+
+	ag, aok := a.(ctypes.GenesisBlocker)
+	bg, bok := b.(ctypes.GenesisBlocker)
+	if aok && bok {
+		diffs = append(diffs, diffGetters(reflect.TypeOf((*ctypes.GenesisBlocker)(nil)).Elem(), ag, bg)...)
+		diffs = append(diffs, diffAccounts(ag, bg)...)
+	}
+	return diffs
+}
+
+// diffGetters compares the results of all parameterless Get methods of the interface type k.
+func diffGetters(k reflect.Type, a, b interface{}) []DiffT {
+	diffs := []DiffT{}
+	for i := 0; i < k.NumMethod(); i++ {
+		method := k.Method(i)
+		if !strings.HasPrefix(method.Name, "Get") || method.Type.NumIn() > 0 || method.Type.NumOut() != 1 {
+			continue
+		}
+		av := reflect.ValueOf(a).MethodByName(method.Name).Call(nil)[0].Interface()
+		bv := reflect.ValueOf(b).MethodByName(method.Name).Call(nil)[0].Interface()
+		diffs = append(diffs, diffValues(strings.TrimPrefix(method.Name, "Get"), av, bv)...)
+	}
+	return diffs
+}
+
+// diffValues compares two values of the same type, descending into maps
+// so that each differing entry is reported on its own.
+func diffValues(field string, a, b interface{}) []DiffT {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() == reflect.Map && bv.Kind() == reflect.Map {
+		keys := make(map[interface{}]reflect.Value)
+		for _, k := range append(av.MapKeys(), bv.MapKeys()...) {
+			keys[k.Interface()] = k
+		}
+		sorted := make([]reflect.Value, 0, len(keys))
+		for _, k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return diffKeyLess(sorted[i], sorted[j])
+		})
+		diffs := []DiffT{}
+		for _, k := range sorted {
+			var ae, be interface{}
+			if e := av.MapIndex(k); e.IsValid() {
+				ae = e.Interface()
+			}
+			if e := bv.MapIndex(k); e.IsValid() {
+				be = e.Interface()
+			}
+			diffs = append(diffs, diffValues(fmt.Sprintf("%s[%v]", field, k.Interface()), ae, be)...)
+		}
+		return diffs
+	}
+	a, b = diffDisplay(a), diffDisplay(b)
+	if diffEqual(a, b) {
+		return nil
+	}
+	return []DiffT{{Field: field, A: a, B: b}}
+}
+
+// diffKeyLess orders map keys, numerically if they are unsigned integers.
+func diffKeyLess(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Uint64 && b.Kind() == reflect.Uint64 {
+		return a.Uint() < b.Uint()
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+// diffDisplay dereferences pointers to numbers, and formats byte slices as hex,
+// so that differences are reported by value.
+func diffDisplay(v interface{}) interface{} {
+	switch x := v.(type) {
+	case *uint64:
+		if x == nil {
+			return nil
+		}
+		return *x
+	case *big.Int:
+		if x == nil {
+			return nil
+		}
+		return x
+	case []byte:
+		return hexutil.Bytes(x)
+	case common.Hash:
+		return x.Hex()
+	case common.Address:
+		return x.Hex()
+	}
+	return v
+}
+
+func diffEqual(a, b interface{}) bool {
+	ab, aok := a.(*big.Int)
+	bb, bok := b.(*big.Int)
+	if aok && bok {
+		return ab.Cmp(bb) == 0
+	}
+	ah, aok := a.(hexutil.Bytes)
+	bh, bok := b.(hexutil.Bytes)
+	if aok && bok {
+		return bytes.Equal(ah, bh)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// diffAccount is the comparable state of a genesis account.
+type diffAccount struct {
+	Balance *big.Int
+	Nonce   uint64
+	Code    []byte
+	Storage map[common.Hash]common.Hash
+}
+
+func collectAccounts(g ctypes.GenesisBlocker) map[common.Address]diffAccount {
+	accounts := make(map[common.Address]diffAccount)
+	g.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		accounts[address] = diffAccount{Balance: bal, Nonce: nonce, Code: code, Storage: storage}
+		return nil
+	})
+	return accounts
+}
+
+// diffAccounts compares the genesis accounts.
+func diffAccounts(a, b ctypes.GenesisBlocker) []DiffT {
+	aa, ba := collectAccounts(a), collectAccounts(b)
+	addrs := make([]common.Address, 0, len(aa)+len(ba))
+	for addr := range aa {
+		addrs = append(addrs, addr)
+	}
+	for addr := range ba {
+		if _, ok := aa[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	diffs := []DiffT{}
+	for _, addr := range addrs {
+		field := fmt.Sprintf("Alloc[%s]", addr.Hex())
+		acc, aok := aa[addr]
+		bcc, bok := ba[addr]
+		if !aok || !bok {
+			var av, bv interface{}
+			if aok {
+				av = "present"
+			}
+			if bok {
+				bv = "present"
+			}
+			diffs = append(diffs, DiffT{Field: field, A: av, B: bv})
+			continue
+		}
+		diffs = append(diffs, diffValues(field+".Balance", acc.Balance, bcc.Balance)...)
+		diffs = append(diffs, diffValues(field+".Nonce", acc.Nonce, bcc.Nonce)...)
+		diffs = append(diffs, diffValues(field+".Code", acc.Code, bcc.Code)...)
+		diffs = append(diffs, diffValues(field+".Storage", acc.Storage, bcc.Storage)...)
+	}
+	return diffs
+}
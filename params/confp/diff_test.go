@@ -0,0 +1,89 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package confp_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+func TestDiffDefaults(t *testing.T) {
+	var (
+		classic = params.DefaultClassicGenesisBlock()
+		mordor  = params.DefaultMordorGenesisBlock()
+	)
+	if diffs := confp.Diff(classic, classic); len(diffs) != 0 {
+		t.Errorf("differences found comparing with itself: %v", diffs)
+	}
+	var chainID bool
+	for _, d := range confp.Diff(classic, mordor) {
+		chainID = chainID || d.Field == "ChainID"
+	}
+	if !chainID {
+		t.Error("chain ID difference not found")
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	var (
+		addr1 = common.BytesToAddress([]byte{1})
+		addr2 = common.BytesToAddress([]byte{2})
+	)
+	genesis := func() *genesisT.Genesis {
+		return &genesisT.Genesis{
+			Config: &coregeth.CoreGethChainConfig{
+				Ethash:              new(ctypes.EthashConfig),
+				BlockRewardSchedule: ctypes.Uint64BigMapEncodesHex{0: big.NewInt(5e18)},
+			},
+			GasLimit:   5000,
+			Difficulty: big.NewInt(0x20000),
+			Alloc:      genesisT.GenesisAlloc{addr1: {Balance: big.NewInt(1)}},
+		}
+	}
+	a, b := genesis(), genesis()
+
+	n := uint64(10)
+	b.Config.SetEIP155Transition(&n)
+	b.Config.GetEthashBlockRewardSchedule()[100] = big.NewInt(3e18)
+	b.GasLimit = 6000
+	b.Alloc[addr1] = genesisT.GenesisAccount{Balance: big.NewInt(2)}
+	b.Alloc[addr2] = genesisT.GenesisAccount{Balance: big.NewInt(1)}
+
+	want := map[string]bool{
+		"EIP155Transition":                   true,
+		"EthashBlockRewardSchedule[100]":     true,
+		"GenesisGasLimit":                    true,
+		"Alloc[" + addr1.Hex() + "].Balance": true,
+		"Alloc[" + addr2.Hex() + "]":         true,
+	}
+	for _, d := range confp.Diff(a, b) {
+		if !want[d.Field] {
+			t.Errorf("unexpected difference: %v", d)
+		}
+		delete(want, d.Field)
+	}
+	for field := range want {
+		t.Errorf("missing difference: %s", field)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
 	"github.com/ethereum/go-ethereum/params/types/multigethv0"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
 	"github.com/ethereum/go-ethereum/params/types/parity"
 	"github.com/ethereum/go-ethereum/params/vars"
 	"github.com/tidwall/gjson"
@@ -53,6 +55,12 @@ func (c GenericCC) DAOSupport() bool {
 	if mg, ok := c.ChainConfigurator.(*coregeth.CoreGethChainConfig); ok {
 		return mg.GetEthashEIP779Transition() != nil
 	}
+	if bc, ok := c.ChainConfigurator.(*besu.BesuGenesis); ok {
+		return bc.DAOForkBlock != nil
+	}
+	if nc, ok := c.ChainConfigurator.(*nethermind.NethermindChainSpec); ok {
+		return AsGenericCC(&nc.ParityChainSpec).DAOSupport()
+	}
 	if pc, ok := c.ChainConfigurator.(*parity.ParityChainSpec); ok {
 		return pc.Engine.Ethash.Params.DaoHardforkTransition != nil &&
 			pc.Engine.Ethash.Params.DaoHardforkBeneficiary != nil &&
@@ -70,6 +78,16 @@ var (
 	}
 	paritySchemaKeysMustNot = []string{}
 
+	// Nethermind reads Parity's chain specification format, with some additional parameters.
+	// Specifications without any of these are read as Parity's, which is equivalent.
+	nethermindSchemaKeysSuffice = []string{
+		"params.eip152Transition",
+		"params.eip1108Transition",
+		"params.eip1706Transition",
+		"params.eip2200Transition",
+	}
+	nethermindSchemaKeysMustNot = []string{}
+
 	// These are fields which must differentiate "new" multigeth from "old" multigeth.
 	multigethSchemaSuffice = []string{
 		"networkId", "config.networkId",
@@ -93,6 +111,26 @@ var (
 		"requireBlockHashes", "config.requireBlockHashes",
 	}
 
+	// These are fields which differentiate a Besu genesis from a goethereum one.
+	// A Besu genesis without any of these is read as goethereum's, which is equivalent.
+	besuSchemaSuffice = []string{
+		"config.classicForkBlock",
+		"config.ecip1015Block",
+		"config.diehardBlock",
+		"config.gothamBlock",
+		"config.ecip1041Block",
+		"config.atlantisBlock",
+		"config.aghartaBlock",
+		"config.phoenixBlock",
+		"config.thanosBlock",
+		"config.ecip1049Block",
+		"config.ecip1017EraRounds",
+		"config.ethash.fixeddifficulty",
+		"config.clique.blockperiodseconds",
+		"config.clique.epochlength",
+	}
+	besuSchemaMustNot = []string{}
+
 	goethereumSchemaSuffice = []string{
 		"difficulty",
 		"byzantiumBlock", "config.byzantiumBlock",
@@ -112,9 +150,11 @@ func UnmarshalChainConfigurator(input []byte) (ctypes.ChainConfigurator, error)
 		sufficient []string
 		negates    []string
 	}{
+		{&nethermind.NethermindChainSpec{}, nethermindSchemaKeysSuffice, nethermindSchemaKeysMustNot},
 		{&parity.ParityChainSpec{}, paritySchemaKeysSuffice, paritySchemaKeysMustNot},
 		{&coregeth.CoreGethChainConfig{}, multigethSchemaSuffice, multigethSchemaMustNot},
 		{&multigethv0.ChainConfig{}, oldmultigethSchemaSuffice, oldmultigethSchemaMustNot},
+		{&besu.BesuGenesis{}, besuSchemaSuffice, besuSchemaMustNot},
 		{&goethereum.ChainConfig{}, goethereumSchemaSuffice, goethereumSchemaMustNot},
 	}
 	for _, c := range cases {
@@ -10,10 +10,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
 	"github.com/ethereum/go-ethereum/params/types/multigethv0"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
 	"github.com/ethereum/go-ethereum/params/types/parity"
 )
 
@@ -36,6 +38,16 @@ func TestUnmarshalChainConfigurator(t *testing.T) {
 			filepath.Join("..", "testdata", "stureby_multigeth.json"),
 			&coregeth.CoreGethChainConfig{},
 		},
+		{
+			filepath.Join("..", "testdata", "stureby_nethermind.json"),
+			&nethermind.NethermindChainSpec{},
+		},
+		{
+			// Besu genesis files naming only the Ethereum Foundation hard forks
+			// are equivalent to go-ethereum's.
+			filepath.Join("..", "testdata", "stureby_besu.json"),
+			&goethereum.ChainConfig{},
+		},
 	}
 
 	for i, c := range cases {
@@ -224,6 +236,46 @@ var cc_v198_a = `{
   }
 }`
 
+func TestUnmarshalChainConfiguratorBesu(t *testing.T) {
+	raw := `{
+  "config": {
+    "chainId": 63,
+    "classicForkBlock": 0,
+    "ecip1015Block": 0,
+    "diehardBlock": 0,
+    "gothamBlock": 0,
+    "ecip1041Block": 0,
+    "atlantisBlock": 0,
+    "aghartaBlock": 301243,
+    "phoenixBlock": 999983,
+    "ecip1017EraRounds": 2000000,
+    "ethash": {}
+  },
+  "nonce": "0x0",
+  "timestamp": "0x5d9676db",
+  "extraData": "0x70686f656e697820636869636b656e206162737572642062616e616e61",
+  "gasLimit": "0x2fefd8",
+  "difficulty": "0x20000",
+  "alloc": {}
+}`
+	got, err := UnmarshalChainConfigurator([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.(*besu.BesuGenesis); !ok {
+		t.Fatalf("wrong type: %T", got)
+	}
+	if n := got.GetEIP1052Transition(); n == nil || *n != 301243 {
+		t.Errorf("EIP1052 transition: got %v, want 301243", n)
+	}
+	if n := got.GetEIP1283Transition(); n != nil {
+		t.Errorf("EIP1283 transition: got %v, want nil", *n)
+	}
+	if n := got.GetEthashECIP1017Transition(); n == nil || *n != 0 {
+		t.Errorf("ECIP1017 transition: got %v, want 0", n)
+	}
+}
+
 func TestUnmarshalChainConfigurator2(t *testing.T) {
 	cases := []struct {
 		versionid string
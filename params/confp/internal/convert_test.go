@@ -28,10 +28,12 @@ import (
 	"github.com/ethereum/go-ethereum/params/confp"
 	"github.com/ethereum/go-ethereum/params/confp/tconvert"
 	"github.com/ethereum/go-ethereum/params/types/aleth"
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
 	"github.com/ethereum/go-ethereum/params/types/parity"
 )
 
@@ -48,7 +50,7 @@ func mustOpenF(t *testing.T, fabbrev string, into interface{}) {
 
 func Test_UnmarshalJSON(t *testing.T) {
 	for _, f := range []string{
-		"geth", "parity", "aleth",
+		"geth", "parity", "aleth", "besu", "nethermind",
 	} {
 		switch f {
 		case "geth":
@@ -67,6 +69,20 @@ func Test_UnmarshalJSON(t *testing.T) {
 		case "aleth":
 			a := &aleth.AlethGenesisSpec{}
 			mustOpenF(t, f, a)
+		case "besu":
+			b := &besu.BesuGenesis{}
+			mustOpenF(t, f, b)
+			_, err := tconvert.BesuGenesisToCoreGethGenesis(b)
+			if err != nil {
+				t.Error(err)
+			}
+		case "nethermind":
+			n := &nethermind.NethermindChainSpec{}
+			mustOpenF(t, f, n)
+			_, err := tconvert.NethermindChainSpecToCoreGethGenesis(n)
+			if err != nil {
+				t.Error(err)
+			}
 		}
 	}
 }
@@ -124,6 +140,8 @@ func TestIdentical(t *testing.T) {
 func TestConfiguratorImplementationsSatisfied(t *testing.T) {
 	for _, ty := range []interface{}{
 		&parity.ParityChainSpec{},
+		&nethermind.NethermindChainSpec{},
+		&besu.BesuGenesis{},
 	} {
 		_ = ty.(ctypes.Configurator)
 	}
@@ -0,0 +1,153 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+func TestLint(t *testing.T) {
+	cases := []struct {
+		name  string
+		conf  *coregeth.CoreGethChainConfig
+		rules []string
+	}{
+		{
+			name: "clean",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				EIP155Block: big.NewInt(10),
+			},
+		},
+		{
+			name: "eip155 without chain id",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, Ethash: new(ctypes.EthashConfig),
+				EIP155Block: big.NewInt(10),
+			},
+			rules: []string{"eip155-chain-id"},
+		},
+		{
+			name: "eip1884 without eip2200",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				EIP1884FBlock: big.NewInt(10),
+			},
+			// EIP1884 alone is also an incomplete Istanbul.
+			rules: []string{"eip1884-eip2200", "istanbul-bundle"},
+		},
+		{
+			name: "ecip1010 pause without continue",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				ECIP1010PauseBlock: big.NewInt(10),
+			},
+			rules: []string{"ecip1010-pause-continue"},
+		},
+		{
+			name: "ecip1017 without era rounds",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				ECIP1017FBlock: big.NewInt(10),
+			},
+			rules: []string{"ecip1017-era-rounds"},
+		},
+		{
+			name: "fork order",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				EIP150Block: big.NewInt(20),
+				EIP155Block: big.NewInt(10),
+			},
+			rules: []string{"fork-order"},
+		},
+		{
+			name: "increasing block reward",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				BlockRewardSchedule: ctypes.Uint64BigMapEncodesHex{
+					0:  big.NewInt(2),
+					10: big.NewInt(3),
+				},
+			},
+			rules: []string{"block-reward-schedule"},
+		},
+		{
+			name: "invalid block reward split",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1), Ethash: new(ctypes.EthashConfig),
+				BlockRewardSplitSchedule: ctypes.RewardSplitSchedule{
+					0:  {Address: common.HexToAddress("0x1"), Percentage: 101},
+					10: {Percentage: 10},
+					20: {}, // ends the split
+				},
+			},
+			rules: []string{"block-reward-split", "block-reward-split"},
+		},
+		{
+			name: "clique zero period",
+			conf: &coregeth.CoreGethChainConfig{
+				NetworkID: 1, ChainID: big.NewInt(1),
+				Clique: &ctypes.CliqueConfig{Epoch: 30000},
+			},
+			rules: []string{"clique-period"},
+		},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, f := range confp.Lint(c.conf) {
+			got = append(got, f.Rule)
+		}
+		sort.Strings(got)
+		sort.Strings(c.rules)
+		if len(got) != len(c.rules) {
+			t.Errorf("%s: got findings %v, want %v", c.name, got, c.rules)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.rules[i] {
+				t.Errorf("%s: got findings %v, want %v", c.name, got, c.rules)
+				break
+			}
+		}
+	}
+}
+
+func TestLintSeverityOrder(t *testing.T) {
+	conf := &coregeth.CoreGethChainConfig{
+		NetworkID: 1, Ethash: new(ctypes.EthashConfig),
+		EIP150Block: big.NewInt(20),
+		EIP155Block: big.NewInt(10),
+	}
+	findings := confp.Lint(conf)
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2: %v", len(findings), findings)
+	}
+	if findings[0].Severity != confp.LintError || findings[1].Severity != confp.LintWarning {
+		t.Errorf("findings not ordered by severity: %v", findings)
+	}
+	if errs := confp.LintErrors(findings); len(errs) != 1 || errs[0].Rule != "eip155-chain-id" {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
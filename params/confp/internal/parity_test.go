@@ -18,10 +18,13 @@ package convert_test
 
 import (
 	"math/big"
+	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/confp/tconvert"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
 )
@@ -42,3 +45,64 @@ func TestBlockConfig(t *testing.T) {
 		t.Errorf("nonnil parity homestead")
 	}
 }
+
+func TestECIP1099RoundTrip(t *testing.T) {
+	genesis := params.DefaultClassicGenesisBlock()
+	genesis.Config = &coregeth.CoreGethChainConfig{
+		NetworkID: 1,
+		ChainID:   big.NewInt(61),
+		Ethash:    new(ctypes.EthashConfig),
+	}
+	ecip1099 := uint64(11_700_000)
+	if err := genesis.Config.SetEthashECIP1099Transition(&ecip1099); err != nil {
+		t.Fatal(err)
+	}
+	paritySpec, err := tconvert.NewParityChainSpec("classic", genesis, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := paritySpec.GetEthashECIP1099Transition(); v == nil || *v != ecip1099 {
+		t.Fatalf("mismatch parity ecip1099Transition: got %v, want %d", v, ecip1099)
+	}
+	back, err := tconvert.ParityConfigToCoreGethGenesis(paritySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := back.Config.GetEthashECIP1099Transition(); v == nil || *v != ecip1099 {
+		t.Errorf("mismatch core-geth ecip1099FBlock: got %v, want %d", v, ecip1099)
+	}
+}
+
+func TestCustomPrecompilesRoundTrip(t *testing.T) {
+	genesis := params.DefaultClassicGenesisBlock()
+	genesis.Config = &coregeth.CoreGethChainConfig{
+		NetworkID: 1,
+		ChainID:   big.NewInt(61),
+		Ethash:    new(ctypes.EthashConfig),
+	}
+	activation := uint64(42)
+	want := ctypes.CustomPrecompiles{
+		common.HexToAddress("0x0100"): {
+			Builtin:         "sha3_512",
+			Pricing:         ctypes.CustomPrecompilePricing{Linear: &ctypes.LinearPricing{Base: 60, Word: 12}},
+			ActivationBlock: &activation,
+		},
+	}
+	if err := genesis.Config.SetCustomPrecompiles(want); err != nil {
+		t.Fatal(err)
+	}
+	paritySpec, err := tconvert.NewParityChainSpec("classic", genesis, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := paritySpec.GetCustomPrecompiles(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatch parity custom precompiles: got %v, want %v", got, want)
+	}
+	back, err := tconvert.ParityConfigToCoreGethGenesis(paritySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Config.GetCustomPrecompiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mismatch core-geth custom precompiles: got %v, want %v", got, want)
+	}
+}
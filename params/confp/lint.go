@@ -0,0 +1,420 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package confp
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// LintSeverity is the severity of a lint finding.
+type LintSeverity int
+
+const (
+	// LintInfo findings are noteworthy, but harmless.
+	LintInfo LintSeverity = iota
+	// LintWarning findings are likely, but not necessarily, mistakes.
+	LintWarning
+	// LintError findings are misconfigurations which cause a node to fork off,
+	// or to fail, once the concerned block is reached.
+	LintError
+)
+
+func (s LintSeverity) String() string {
+	switch s {
+	case LintInfo:
+		return "info"
+	case LintWarning:
+		return "warning"
+	case LintError:
+		return "error"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// LintFinding is a problem found in a chain configuration by a lint rule.
+type LintFinding struct {
+	Rule     string
+	Severity LintSeverity
+	Message  string
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Rule, f.Message)
+}
+
+// LintRule checks a chain configuration for one class of misconfiguration.
+// Check returns a message for each problem found; problems are reported with the
+// severity of the rule.
+type LintRule struct {
+	Name        string
+	Description string
+	Severity    LintSeverity
+	Check       func(conf ctypes.ChainConfigurator) []string
+}
+
+// LintRules is the catalogue of rules run by Lint.
+// Additional rules can be installed with RegisterLintRule.
+var LintRules = []LintRule{
+	{
+		Name:        "network-id",
+		Description: "The network ID must be set and non-zero.",
+		Severity:    LintError,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if n := conf.GetNetworkID(); n == nil || *n == 0 {
+				return []string{"network ID is empty or zero"}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "eip155-chain-id",
+		Description: "EIP155 replay protection requires a chain ID.",
+		Severity:    LintError,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if conf.GetEIP155Transition() != nil && conf.GetChainID() == nil {
+				return []string{fmt.Sprintf("EIP155 is activated at block %d, but the chain ID is empty", *conf.GetEIP155Transition())}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "eip1884-eip2200",
+		Description: "EIP1884 raises the cost of SLOAD, which EIP2200 net gas metering offsets; EIP1884 must not activate before EIP2200.",
+		Severity:    LintError,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			return lintRequires(conf.GetEIP1884Transition(), conf.GetEIP2200Transition(), "EIP1884", "EIP2200")
+		},
+	},
+	{
+		Name:        "eip1283-disable",
+		Description: "EIP1283 may only be disabled after it has been activated.",
+		Severity:    LintWarning,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			return lintRequires(conf.GetEIP1283DisableTransition(), conf.GetEIP1283Transition(), "EIP1283 disable", "EIP1283")
+		},
+	},
+	lintBundleRule("spurious-dragon-bundle", "Spurious Dragon", []string{"EIP161abc", "EIP161d", "EIP170"}),
+	lintBundleRule("byzantium-bundle", "Byzantium", []string{"EIP140", "EIP198", "EIP211", "EIP212", "EIP213", "EIP214", "EIP658"}),
+	lintBundleRule("constantinople-bundle", "Constantinople", []string{"EIP145", "EIP1014", "EIP1052"}),
+	lintBundleRule("istanbul-bundle", "Istanbul", []string{"EIP152", "EIP1108", "EIP1344", "EIP1884", "EIP2028", "EIP2200"}),
+	lintBundleRule("berlin-bundle", "Berlin", []string{"EIP2718", "EIP2929"}),
+	{
+		Name:        "fork-order",
+		Description: "Hard forks should activate in the order they were specified, since later forks build on earlier ones.",
+		Severity:    LintWarning,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			// Each feature represents the hard fork in which it was specified.
+			order := []string{"EIP7", "EIP150", "EIP155", "EIP161abc", "EIP140", "EIP145", "EIP1884", "EIP2929"}
+			var msgs []string
+			var prev string
+			var prevN *uint64
+			for _, name := range order {
+				n := lintTransition(conf, name)
+				if n == nil {
+					continue
+				}
+				if prevN != nil && *n < *prevN {
+					msgs = append(msgs, fmt.Sprintf("%s activates at block %d, before %s at block %d", name, *n, prev, *prevN))
+				}
+				prev, prevN = name, n
+			}
+			return msgs
+		},
+	},
+	{
+		Name:        "ecip1010-pause-continue",
+		Description: "The ECIP1010 difficulty bomb pause must be ended by a later continuation, and vice versa.",
+		Severity:    LintError,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if !conf.GetConsensusEngineType().IsEthash() {
+				return nil
+			}
+			pause, cont := conf.GetEthashECIP1010PauseTransition(), conf.GetEthashECIP1010ContinueTransition()
+			switch {
+			case pause != nil && cont == nil:
+				return []string{fmt.Sprintf("ECIP1010 pause at block %d has no continuation", *pause)}
+			case pause == nil && cont != nil:
+				return []string{fmt.Sprintf("ECIP1010 continuation at block %d has no pause", *cont)}
+			case pause != nil && *cont < *pause:
+				return []string{fmt.Sprintf("ECIP1010 continuation at block %d precedes the pause at block %d", *cont, *pause)}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "ecip1017-era-rounds",
+		Description: "The ECIP1017 monetary policy requires a non-zero era length.",
+		Severity:    LintError,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if !conf.GetConsensusEngineType().IsEthash() || conf.GetEthashECIP1017Transition() == nil {
+				return nil
+			}
+			if n := conf.GetEthashECIP1017EraRounds(); n == nil || *n == 0 {
+				return []string{fmt.Sprintf("ECIP1017 is activated at block %d, but the era rounds are empty or zero", *conf.GetEthashECIP1017Transition())}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "block-reward-schedule",
+		Description: "Block rewards should not increase over time.",
+		Severity:    LintWarning,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if !conf.GetConsensusEngineType().IsEthash() {
+				return nil
+			}
+			schedule := conf.GetEthashBlockRewardSchedule()
+			var msgs []string
+			blocks := lintScheduleBlocks(schedule)
+			for i := 1; i < len(blocks); i++ {
+				prev, cur := schedule[blocks[i-1]], schedule[blocks[i]]
+				if prev != nil && cur != nil && cur.Cmp(prev) > 0 {
+					msgs = append(msgs, fmt.Sprintf("block reward increases from %v to %v at block %d", prev, cur, blocks[i]))
+				}
+			}
+			return msgs
+		},
+	},
+	{
+		Name:        "block-reward-split",
+		Description: "Block reward splits must pay at most 100% of the reward to a non-zero treasury address.",
+		Severity:    LintError,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if !conf.GetConsensusEngineType().IsEthash() {
+				return nil
+			}
+			schedule := conf.GetEthashBlockRewardSplitSchedule()
+			blocks := make([]uint64, 0, len(schedule))
+			for n := range schedule {
+				blocks = append(blocks, n)
+			}
+			sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+			var msgs []string
+			for _, n := range blocks {
+				split := schedule[n]
+				if split.Percentage > 100 {
+					msgs = append(msgs, fmt.Sprintf("block reward split of %d%% at block %d exceeds 100%%", split.Percentage, n))
+				}
+				if split.Percentage > 0 && split.Address == (common.Address{}) {
+					msgs = append(msgs, fmt.Sprintf("block reward split at block %d pays the zero address", n))
+				}
+			}
+			return msgs
+		},
+	},
+	{
+		Name:        "difficulty-bomb-delay-schedule",
+		Description: "Difficulty bomb delays should not decrease over time.",
+		Severity:    LintWarning,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if !conf.GetConsensusEngineType().IsEthash() {
+				return nil
+			}
+			// The schedule holds the delay added at each block.
+			schedule := conf.GetEthashDifficultyBombDelaySchedule()
+			var msgs []string
+			for _, n := range lintScheduleBlocks(schedule) {
+				if d := schedule[n]; d != nil && d.Sign() < 0 {
+					msgs = append(msgs, fmt.Sprintf("difficulty bomb delay decreases by %v at block %d", new(big.Int).Neg(d), n))
+				}
+			}
+			return msgs
+		},
+	},
+	{
+		Name:        "clique-epoch",
+		Description: "The Clique epoch length defaults to 30000 blocks if unset.",
+		Severity:    LintInfo,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if conf.GetConsensusEngineType().IsClique() && conf.GetCliqueEpoch() == 0 {
+				return []string{"Clique epoch is zero, and defaults to 30000"}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "clique-period",
+		Description: "With a zero Clique period, blocks are only sealed when transactions are pending.",
+		Severity:    LintWarning,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			if conf.GetConsensusEngineType().IsClique() && conf.GetCliquePeriod() == 0 {
+				return []string{"Clique period is zero, blocks are only sealed when transactions are pending"}
+			}
+			return nil
+		},
+	},
+}
+
+// RegisterLintRule adds a rule to the catalogue run by Lint.
+func RegisterLintRule(rule LintRule) {
+	LintRules = append(LintRules, rule)
+}
+
+// Lint checks a chain configuration against all rules in the LintRules catalogue,
+// returning all findings, ordered by descending severity.
+func Lint(conf ctypes.ChainConfigurator) []LintFinding {
+	return LintWith(conf, LintRules...)
+}
+
+// LintWith checks a chain configuration against the given rules,
+// returning all findings, ordered by descending severity.
+func LintWith(conf ctypes.ChainConfigurator, rules ...LintRule) []LintFinding {
+	findings := []LintFinding{}
+	for _, rule := range rules {
+		for _, msg := range rule.Check(conf) {
+			findings = append(findings, LintFinding{Rule: rule.Name, Severity: rule.Severity, Message: msg})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings
+}
+
+// LintErrors returns the findings with LintError severity.
+func LintErrors(findings []LintFinding) []LintFinding {
+	errs := []LintFinding{}
+	for _, f := range findings {
+		if f.Severity == LintError {
+			errs = append(errs, f)
+		}
+	}
+	return errs
+}
+
+// lintRequires reports a feature which activates without, or before, the feature it requires.
+func lintRequires(feature, required *uint64, featureName, requiredName string) []string {
+	if feature == nil {
+		return nil
+	}
+	if required == nil {
+		return []string{fmt.Sprintf("%s activates at block %d, but %s is not activated", featureName, *feature, requiredName)}
+	}
+	if *feature < *required {
+		return []string{fmt.Sprintf("%s activates at block %d, before %s at block %d", featureName, *feature, requiredName, *required)}
+	}
+	return nil
+}
+
+// lintBundleRule returns a rule requiring the features of a hard fork
+// to be activated together.
+func lintBundleRule(name, fork string, features []string) LintRule {
+	return LintRule{
+		Name:        name,
+		Description: fmt.Sprintf("The %s features (%s) should activate at the same block.", fork, strings.Join(features, ", ")),
+		Severity:    LintWarning,
+		Check: func(conf ctypes.ChainConfigurator) []string {
+			blocks := make(map[string]*uint64)
+			var set, unset []string
+			for _, feature := range features {
+				n := lintTransition(conf, feature)
+				if n == nil {
+					unset = append(unset, feature)
+					continue
+				}
+				blocks[feature] = n
+				set = append(set, feature)
+			}
+			if len(set) == 0 {
+				return nil
+			}
+			var msgs []string
+			if len(unset) > 0 {
+				msgs = append(msgs, fmt.Sprintf("%s is partially activated, missing %s", fork, strings.Join(unset, ", ")))
+			}
+			for _, feature := range set[1:] {
+				if *blocks[feature] != *blocks[set[0]] {
+					msgs = append(msgs, fmt.Sprintf("%s activates at block %d, but %s at block %d", feature, *blocks[feature], set[0], *blocks[set[0]]))
+				}
+			}
+			return msgs
+		},
+	}
+}
+
+// lintTransition returns the transition of a protocol feature by name, eg. "EIP155".
+func lintTransition(conf ctypes.ChainConfigurator, feature string) *uint64 {
+	switch feature {
+	case "EIP7":
+		return conf.GetEIP7Transition()
+	case "EIP140":
+		return conf.GetEIP140Transition()
+	case "EIP145":
+		return conf.GetEIP145Transition()
+	case "EIP150":
+		return conf.GetEIP150Transition()
+	case "EIP152":
+		return conf.GetEIP152Transition()
+	case "EIP155":
+		return conf.GetEIP155Transition()
+	case "EIP161abc":
+		return conf.GetEIP161abcTransition()
+	case "EIP161d":
+		return conf.GetEIP161dTransition()
+	case "EIP170":
+		return conf.GetEIP170Transition()
+	case "EIP198":
+		return conf.GetEIP198Transition()
+	case "EIP211":
+		return conf.GetEIP211Transition()
+	case "EIP212":
+		return conf.GetEIP212Transition()
+	case "EIP213":
+		return conf.GetEIP213Transition()
+	case "EIP214":
+		return conf.GetEIP214Transition()
+	case "EIP658":
+		return conf.GetEIP658Transition()
+	case "EIP1014":
+		return conf.GetEIP1014Transition()
+	case "EIP1052":
+		return conf.GetEIP1052Transition()
+	case "EIP1108":
+		return conf.GetEIP1108Transition()
+	case "EIP1344":
+		return conf.GetEIP1344Transition()
+	case "EIP1884":
+		return conf.GetEIP1884Transition()
+	case "EIP2028":
+		return conf.GetEIP2028Transition()
+	case "EIP2200":
+		return conf.GetEIP2200Transition()
+	case "EIP2718":
+		return conf.GetEIP2718Transition()
+	case "EIP2929":
+		return conf.GetEIP2929Transition()
+	}
+	panic(fmt.Sprintf("unknown lint feature %q", feature))
+}
+
+func lintScheduleBlocks(schedule ctypes.Uint64BigMapEncodesHex) []uint64 {
+	blocks := make([]uint64, 0, len(schedule))
+	for n := range schedule {
+		blocks = append(blocks, n)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i] < blocks[j]
+	})
+	return blocks
+}
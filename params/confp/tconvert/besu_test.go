@@ -0,0 +1,118 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package tconvert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/go-test/deep"
+)
+
+// Tests the go-ethereum to Besu genesis conversion for the Stureby testnet.
+func TestBesuSturebyConverter(t *testing.T) {
+	blob, err := ioutil.ReadFile(filepath.Join("..", "testdata", "stureby_geth.json"))
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+	var genesis genesisT.Genesis
+	if err := json.Unmarshal(blob, &genesis); err != nil {
+		t.Fatalf("failed parsing genesis: %v", err)
+	}
+	convertedSpec, err := NewBesuGenesis(&genesis)
+	if err != nil {
+		t.Fatalf("failed creating genesis: %v", err)
+	}
+
+	expBlob, err := ioutil.ReadFile(filepath.Join("..", "testdata", "stureby_besu.json"))
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+	readSpec := &besu.BesuGenesis{}
+	if err := json.Unmarshal(expBlob, readSpec); err != nil {
+		t.Fatalf("failed parsing genesis: %v", err)
+	}
+	// The network ID is not part of the Besu genesis format; it defaults to the chain ID.
+	convertedSpec.NetworkID = 0
+	if diffs := deep.Equal(convertedSpec, readSpec); len(diffs) != 0 {
+		t.Errorf("error: genesis mismatch")
+		for _, d := range diffs {
+			t.Log(d)
+		}
+	}
+
+	// Convert the read-in Besu genesis back, and compare it with the original.
+	back, err := BesuGenesisToCoreGethGenesis(readSpec)
+	if err != nil {
+		t.Fatalf("failed converting genesis: %v", err)
+	}
+	if diffs := confp.Equal(reflect.TypeOf((*ctypes.ChainConfigurator)(nil)), genesis.Config, back.Config); len(diffs) != 0 {
+		for _, d := range diffs {
+			t.Error(d)
+		}
+	}
+	if diffs := confp.Equal(reflect.TypeOf((*ctypes.GenesisBlocker)(nil)), &genesis, back); len(diffs) != 0 {
+		for _, d := range diffs {
+			t.Error(d)
+		}
+	}
+}
+
+// Tests that the Ethereum Classic configurations survive a round trip through
+// the Besu genesis format, which names the Ethereum Classic hard forks.
+func TestBesuClassicRoundTrip(t *testing.T) {
+	for _, name := range []string{"classic", "mordor", "kotti"} {
+		genesis := params.NetworkByName(name).Genesis()
+		spec, err := NewBesuGenesis(genesis)
+		if err != nil {
+			t.Fatalf("%s: failed creating genesis: %v", name, err)
+		}
+		if spec.AtlantisBlock == nil || spec.ByzantiumBlock != nil {
+			t.Errorf("%s: want Ethereum Classic hard forks, got atlantis=%v byzantium=%v", name, spec.AtlantisBlock, spec.ByzantiumBlock)
+		}
+		blob, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatalf("%s: failed marshaling genesis: %v", name, err)
+		}
+		readSpec := &besu.BesuGenesis{}
+		if err := json.Unmarshal(blob, readSpec); err != nil {
+			t.Fatalf("%s: failed parsing genesis: %v", name, err)
+		}
+		back, err := BesuGenesisToCoreGethGenesis(readSpec)
+		if err != nil {
+			t.Fatalf("%s: failed converting genesis: %v", name, err)
+		}
+		for _, d := range confp.Equal(reflect.TypeOf((*ctypes.ProtocolSpecifier)(nil)), genesis.Config, back.Config) {
+			// ECBP1100 (MESS) is not supported by Besu.
+			if d.Field == "ECBP1100Transition" {
+				continue
+			}
+			t.Errorf("%s: %v", name, d)
+		}
+		for _, d := range confp.Equal(reflect.TypeOf((*ctypes.GenesisBlocker)(nil)), genesis, back) {
+			t.Errorf("%s: %v", name, d)
+		}
+	}
+}
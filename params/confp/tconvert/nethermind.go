@@ -0,0 +1,61 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package tconvert
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// NewNethermindChainSpec converts a go-ethereum genesis block into a Nethermind
+// chain specification.
+func NewNethermindChainSpec(network string, genesis *genesisT.Genesis, bootnodes []string) (*nethermind.NethermindChainSpec, error) {
+	spec := &nethermind.NethermindChainSpec{
+		ParityChainSpec: parity.ParityChainSpec{
+			Name:    network,
+			Nodes:   bootnodes,
+			Datadir: strings.ToLower(network),
+		},
+	}
+	if err := confp.Convert(genesis, spec); err != nil {
+		return nil, err
+	}
+	if err := confp.Convert(genesis.Config, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// NethermindChainSpecToCoreGethGenesis converts a Nethermind chain specification
+// to the corresponding CoreGeth datastructure.
+func NethermindChainSpecToCoreGethGenesis(spec *nethermind.NethermindChainSpec) (*genesisT.Genesis, error) {
+	mg := &genesisT.Genesis{
+		Config: &coregeth.CoreGethChainConfig{},
+	}
+	if err := confp.Convert(spec, mg); err != nil {
+		return nil, err
+	}
+	if err := confp.Convert(spec, mg.Config); err != nil {
+		return nil, err
+	}
+	return mg, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package tconvert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+)
+
+// Tests the Nethermind chainspec round trip for the Stureby testnet.
+func TestNethermindSturebyConverter(t *testing.T) {
+	blob, err := ioutil.ReadFile(filepath.Join("..", "testdata", "stureby_nethermind.json"))
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+	spec := &nethermind.NethermindChainSpec{}
+	if err := json.Unmarshal(blob, spec); err != nil {
+		t.Fatalf("failed parsing chainspec: %v", err)
+	}
+	if spec.NethermindParams.EIP2200Transition == nil {
+		t.Fatal("missing eip2200Transition")
+	}
+
+	genesis, err := NethermindChainSpecToCoreGethGenesis(spec)
+	if err != nil {
+		t.Fatalf("failed converting chainspec: %v", err)
+	}
+	if got := genesis.GetEIP2200Transition(); got == nil || *got != 50000 {
+		t.Errorf("EIP2200 transition mismatch: got %v, want 50000", got)
+	}
+
+	// Convert back, and compare the chainspecs after a JSON round trip.
+	convertedSpec, err := NewNethermindChainSpec(spec.Name, genesis, nil)
+	if err != nil {
+		t.Fatalf("failed creating chainspec: %v", err)
+	}
+	convertedBlob, err := json.Marshal(convertedSpec)
+	if err != nil {
+		t.Fatalf("failed marshaling chainspec: %v", err)
+	}
+	readSpec := &nethermind.NethermindChainSpec{}
+	if err := json.Unmarshal(convertedBlob, readSpec); err != nil {
+		t.Fatalf("failed parsing chainspec: %v", err)
+	}
+	if !reflect.DeepEqual(readSpec.NethermindParams, convertedSpec.NethermindParams) {
+		t.Errorf("nethermind params mismatch: got %v, want %v", readSpec.NethermindParams, convertedSpec.NethermindParams)
+	}
+	if diffs := confp.Equal(reflect.TypeOf((*ctypes.ChainConfigurator)(nil)), spec, readSpec); len(diffs) != 0 {
+		for _, d := range diffs {
+			t.Error(d)
+		}
+	}
+	if diffs := confp.Equal(reflect.TypeOf((*ctypes.GenesisBlocker)(nil)), spec, readSpec); len(diffs) != 0 {
+		for _, d := range diffs {
+			t.Error(d)
+		}
+	}
+}
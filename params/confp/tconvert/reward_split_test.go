@@ -0,0 +1,74 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package tconvert
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// Tests that block reward splits survive a JSON round trip through the formats
+// supporting them, and fail conversions to those which don't.
+func TestRewardSplitConverter(t *testing.T) {
+	schedule := ctypes.RewardSplitSchedule{
+		0:   {Address: common.HexToAddress("0x1"), Percentage: 10},
+		100: {Address: common.HexToAddress("0x2"), Percentage: 0},
+	}
+	config := &coregeth.CoreGethChainConfig{
+		ChainID:                  big.NewInt(1),
+		Ethash:                   new(ctypes.EthashConfig),
+		BlockRewardSplitSchedule: schedule,
+	}
+	for _, spec := range []ctypes.ChainConfigurator{
+		&parity.ParityChainSpec{},
+		&nethermind.NethermindChainSpec{},
+		&besu.BesuConfig{},
+	} {
+		if err := confp.Convert(config, spec); err != nil {
+			t.Fatalf("%T: failed converting config: %v", spec, err)
+		}
+		blob, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatalf("%T: failed marshaling config: %v", spec, err)
+		}
+		readSpec := reflect.New(reflect.TypeOf(spec).Elem()).Interface().(ctypes.ChainConfigurator)
+		if err := json.Unmarshal(blob, readSpec); err != nil {
+			t.Fatalf("%T: failed parsing config: %v", spec, err)
+		}
+		converted := new(coregeth.CoreGethChainConfig)
+		if err := confp.Convert(readSpec, converted); err != nil {
+			t.Fatalf("%T: failed converting config back: %v", spec, err)
+		}
+		if got := converted.GetEthashBlockRewardSplitSchedule(); !reflect.DeepEqual(got, schedule) {
+			t.Errorf("%T: reward split mismatch: got %v, want %v", spec, got, schedule)
+		}
+	}
+	if err := confp.Convert(config, new(goethereum.ChainConfig)); err == nil {
+		t.Error("converted reward split to go-ethereum config")
+	}
+}
@@ -73,6 +73,9 @@ func DefaultGoerliGenesisBlock() *genesisT.Genesis {
 	}
 }
 
+// developerGasLimit is the genesis gas limit of developer chains.
+const developerGasLimit = 6283185
+
 // DeveloperGenesisBlock returns the 'geth --dev' genesis block. Note, this must
 // be seeded with the
 func DeveloperGenesisBlock(period uint64, faucet common.Address) *genesisT.Genesis {
@@ -84,7 +87,7 @@ func DeveloperGenesisBlock(period uint64, faucet common.Address) *genesisT.Genes
 	return &genesisT.Genesis{
 		Config:     &config,
 		ExtraData:  append(append(make([]byte, 32), faucet[:]...), make([]byte, crypto.SignatureLength)...),
-		GasLimit:   6283185,
+		GasLimit:   developerGasLimit,
 		Difficulty: big.NewInt(1),
 		Alloc: map[common.Address]genesisT.GenesisAccount{
 			common.BytesToAddress([]byte{1}): {Balance: big.NewInt(1)}, // ECRecover
@@ -99,3 +102,19 @@ func DeveloperGenesisBlock(period uint64, faucet common.Address) *genesisT.Genes
 		},
 	}
 }
+
+// DeveloperFundedGenesis returns a copy of the genesis with the faucet
+// pre-funded, for developer chains following the rules of an existing chain.
+// The gas limit is raised to that of the 'geth --dev' genesis if lower.
+func DeveloperFundedGenesis(genesis *genesisT.Genesis, faucet common.Address) *genesisT.Genesis {
+	funded := *genesis
+	if funded.GasLimit < developerGasLimit {
+		funded.GasLimit = developerGasLimit
+	}
+	funded.Alloc = make(genesisT.GenesisAlloc, len(genesis.Alloc)+1)
+	for addr, account := range genesis.Alloc {
+		funded.Alloc[addr] = account
+	}
+	funded.Alloc[faucet] = genesisT.GenesisAccount{Balance: new(big.Int).Lsh(big.NewInt(1), 128)}
+	return &funded
+}
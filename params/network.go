@@ -0,0 +1,274 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/confp/generic"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// Network is a complete definition of a named network.
+type Network struct {
+	// Name is the name of the network, as selected with --network.
+	Name string
+
+	// Genesis returns a new copy of the genesis block, including
+	// the chain configuration, of the network.
+	Genesis func() *genesisT.Genesis
+
+	Bootnodes    []string // enode URLs of the P2P bootstrap nodes
+	DNSDiscovery string   // enrtree:// URL of the DNS discovery tree, if any
+
+	Checkpoint       *ctypes.TrustedCheckpoint      // light client trusted checkpoint, if any
+	CheckpointOracle *ctypes.CheckpointOracleConfig // light client checkpoint oracle, if any
+}
+
+// MainnetNetworkName is the name of the default network.
+const MainnetNetworkName = "mainnet"
+
+// BuiltinNetworks are the networks bundled with the client.
+var BuiltinNetworks = []*Network{
+	{
+		Name:             MainnetNetworkName,
+		Genesis:          DefaultGenesisBlock,
+		Bootnodes:        MainnetBootnodes,
+		DNSDiscovery:     KnownDNSNetworks[MainnetGenesisHash],
+		Checkpoint:       MainnetTrustedCheckpoint,
+		CheckpointOracle: MainnetCheckpointOracle,
+	},
+	{
+		Name:         "classic",
+		Genesis:      DefaultClassicGenesisBlock,
+		Bootnodes:    ClassicBootnodes,
+		DNSDiscovery: ClassicDNSNetwork1,
+	},
+	{
+		Name:         "mordor",
+		Genesis:      DefaultMordorGenesisBlock,
+		Bootnodes:    MordorBootnodes,
+		DNSDiscovery: MordorDNSNetwork1,
+	},
+	{
+		Name:         "kotti",
+		Genesis:      DefaultKottiGenesisBlock,
+		Bootnodes:    KottiBootnodes,
+		DNSDiscovery: KottiDNSNetwork1,
+	},
+	{
+		Name:      "social",
+		Genesis:   DefaultSocialGenesisBlock,
+		Bootnodes: SocialBootnodes,
+	},
+	{
+		Name:      "mix",
+		Genesis:   DefaultMixGenesisBlock,
+		Bootnodes: MixBootnodes,
+	},
+	{
+		Name:      "ethersocial",
+		Genesis:   DefaultEthersocialGenesisBlock,
+		Bootnodes: EthersocialBootnodes,
+	},
+	{
+		Name:             "ropsten",
+		Genesis:          DefaultRopstenGenesisBlock,
+		Bootnodes:        RopstenBootnodes,
+		DNSDiscovery:     KnownDNSNetworks[RopstenGenesisHash],
+		Checkpoint:       RopstenTrustedCheckpoint,
+		CheckpointOracle: RopstenCheckpointOracle,
+	},
+	{
+		Name:             "rinkeby",
+		Genesis:          DefaultRinkebyGenesisBlock,
+		Bootnodes:        RinkebyBootnodes,
+		DNSDiscovery:     KnownDNSNetworks[RinkebyGenesisHash],
+		Checkpoint:       RinkebyTrustedCheckpoint,
+		CheckpointOracle: RinkebyCheckpointOracle,
+	},
+	{
+		Name:             "goerli",
+		Genesis:          DefaultGoerliGenesisBlock,
+		Bootnodes:        GoerliBootnodes,
+		DNSDiscovery:     KnownDNSNetworks[GoerliGenesisHash],
+		Checkpoint:       GoerliTrustedCheckpoint,
+		CheckpointOracle: GoerliCheckpointOracle,
+	},
+}
+
+// NetworkByName returns the built-in network with the given name, or nil if there is none.
+func NetworkByName(name string) *Network {
+	for _, n := range BuiltinNetworks {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// NetworkNames returns the names of the built-in networks.
+func NetworkNames() []string {
+	names := make([]string, len(BuiltinNetworks))
+	for i, n := range BuiltinNetworks {
+		names[i] = n.Name
+	}
+	return names
+}
+
+// Files making up a network definition directory.
+const (
+	// NetworkConfigFile holds the chain configuration, in any format understood
+	// by generic.UnmarshalChainConfigurator. Required.
+	NetworkConfigFile = "config.json"
+
+	// NetworkGenesisFile holds the genesis header fields and alloc. Required, unless
+	// the chain configuration is a chain specification including the genesis (eg. Parity's).
+	NetworkGenesisFile = "genesis.json"
+
+	// NetworkManifestFile holds the bootnodes, DNS discovery URL and checkpoints. Optional.
+	NetworkManifestFile = "network.json"
+)
+
+// networkManifest is the format of NetworkManifestFile.
+type networkManifest struct {
+	Bootnodes        []string                       `json:"bootnodes"`
+	DNSDiscovery     string                         `json:"dnsDiscovery"`
+	Checkpoint       *ctypes.TrustedCheckpoint      `json:"checkpoint"`
+	CheckpointOracle *ctypes.CheckpointOracleConfig `json:"checkpointOracle"`
+}
+
+// networkGenesis is the format of NetworkGenesisFile. It mirrors genesisT.Genesis,
+// without the chain configuration.
+type networkGenesis struct {
+	Nonce      math.HexOrDecimal64   `json:"nonce"`
+	Timestamp  math.HexOrDecimal64   `json:"timestamp"`
+	ExtraData  hexutil.Bytes         `json:"extraData"`
+	GasLimit   *math.HexOrDecimal64  `json:"gasLimit"`
+	Difficulty *math.HexOrDecimal256 `json:"difficulty"`
+	Mixhash    common.Hash           `json:"mixHash"`
+	Coinbase   common.Address        `json:"coinbase"`
+	Alloc      genesisT.GenesisAlloc `json:"alloc"`
+	Number     math.HexOrDecimal64   `json:"number"`
+	GasUsed    math.HexOrDecimal64   `json:"gasUsed"`
+	ParentHash common.Hash           `json:"parentHash"`
+}
+
+// LoadNetwork reads a network definition from a directory. The network is named
+// after the directory. See NetworkConfigFile, NetworkGenesisFile and NetworkManifestFile
+// for the files it consists of.
+func LoadNetwork(dir string) (*Network, error) {
+	configData, err := ioutil.ReadFile(filepath.Join(dir, NetworkConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	genesisData, err := ioutil.ReadFile(filepath.Join(dir, NetworkGenesisFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	decode := func() (*genesisT.Genesis, error) {
+		return decodeNetworkGenesis(configData, genesisData)
+	}
+	genesis, err := decode()
+	if err != nil {
+		return nil, err
+	}
+	if err := confp.IsValid(genesis.Config, nil); err != nil {
+		return nil, fmt.Errorf("invalid chain config: %v", err)
+	}
+	network := &Network{
+		Name: filepath.Base(dir),
+		Genesis: func() *genesisT.Genesis {
+			// The data have been decoded successfully before.
+			g, _ := decode()
+			return g
+		},
+	}
+	manifestData, err := ioutil.ReadFile(filepath.Join(dir, NetworkManifestFile))
+	switch {
+	case os.IsNotExist(err):
+		return network, nil
+	case err != nil:
+		return nil, err
+	}
+	var manifest networkManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", NetworkManifestFile, err)
+	}
+	network.Bootnodes = manifest.Bootnodes
+	network.DNSDiscovery = manifest.DNSDiscovery
+	network.Checkpoint = manifest.Checkpoint
+	network.CheckpointOracle = manifest.CheckpointOracle
+	return network, nil
+}
+
+// decodeNetworkGenesis builds a genesis from the contents of a network's
+// configuration and (possibly empty) genesis files.
+func decodeNetworkGenesis(configData, genesisData []byte) (*genesisT.Genesis, error) {
+	config, err := generic.UnmarshalChainConfigurator(configData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", NetworkConfigFile, err)
+	}
+	if len(genesisData) == 0 {
+		// The configuration may be a chain specification including the genesis.
+		spec, ok := config.(ctypes.GenesisBlocker)
+		if !ok {
+			return nil, fmt.Errorf("missing %s", NetworkGenesisFile)
+		}
+		genesis := &genesisT.Genesis{Config: &coregeth.CoreGethChainConfig{}}
+		if err := confp.Convert(spec, genesis); err != nil {
+			return nil, fmt.Errorf("convert %s: %v", NetworkConfigFile, err)
+		}
+		return genesis, nil
+	}
+	var dec networkGenesis
+	if err := json.Unmarshal(genesisData, &dec); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", NetworkGenesisFile, err)
+	}
+	if dec.GasLimit == nil {
+		return nil, errors.New("missing required field 'gasLimit' for Genesis")
+	}
+	if dec.Difficulty == nil {
+		return nil, errors.New("missing required field 'difficulty' for Genesis")
+	}
+	return &genesisT.Genesis{
+		Config:     config,
+		Nonce:      uint64(dec.Nonce),
+		Timestamp:  uint64(dec.Timestamp),
+		ExtraData:  dec.ExtraData,
+		GasLimit:   uint64(*dec.GasLimit),
+		Difficulty: (*big.Int)(dec.Difficulty),
+		Mixhash:    dec.Mixhash,
+		Coinbase:   dec.Coinbase,
+		Alloc:      dec.Alloc,
+		Number:     uint64(dec.Number),
+		GasUsed:    uint64(dec.GasUsed),
+		ParentHash: dec.ParentHash,
+	}, nil
+}
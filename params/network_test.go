@@ -0,0 +1,154 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
+)
+
+func TestBuiltinNetworks(t *testing.T) {
+	for _, name := range NetworkNames() {
+		n := NetworkByName(name)
+		if n == nil {
+			t.Fatalf("%s: not found", name)
+		}
+		g := n.Genesis()
+		if g == nil || g.Config == nil {
+			t.Fatalf("%s: missing genesis", name)
+		}
+		if err := confp.IsValid(g.Config, nil); err != nil {
+			t.Errorf("%s: invalid config: %v", name, err)
+		}
+		for _, f := range confp.LintErrors(confp.Lint(g.Config)) {
+			t.Errorf("%s: lint: %v", name, f)
+		}
+		if len(n.Bootnodes) == 0 {
+			t.Errorf("%s: missing bootnodes", name)
+		}
+	}
+	if NetworkByName("foo") != nil {
+		t.Error("unexpected network")
+	}
+}
+
+func writeNetworkFiles(t *testing.T, dir string, files map[string]string) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadNetwork(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "networks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "testy")
+	writeNetworkFiles(t, dir, map[string]string{
+		NetworkConfigFile: `{
+  "networkId": 1234,
+  "chainId": 5678,
+  "eip2FBlock": 0,
+  "eip155Block": 10,
+  "ethash": {}
+}`,
+		NetworkGenesisFile: `{
+  "nonce": "0x42",
+  "extraData": "0x1234",
+  "gasLimit": "0x1388",
+  "difficulty": "0x20000",
+  "alloc": {
+    "0x0000000000000000000000000000000000000001": {"balance": "0x1"}
+  }
+}`,
+		NetworkManifestFile: `{
+  "bootnodes": ["enode://06333009fc9ef3c9e174768e495722a7f98fe7afd4660542e983005f85e556028410fd03278944f44cfe5437b1750b5e6bd1738f700fe7da3626d52010d2954c@51.141.15.254:30303"],
+  "dnsDiscovery": "enrtree://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@all.testy.example.org",
+  "checkpoint": {
+    "sectionIndex": 3,
+    "sectionHead": "0x0000000000000000000000000000000000000000000000000000000000000001",
+    "chtRoot": "0x0000000000000000000000000000000000000000000000000000000000000002",
+    "bloomRoot": "0x0000000000000000000000000000000000000000000000000000000000000003"
+  }
+}`,
+	})
+	n, err := LoadNetwork(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Name != "testy" {
+		t.Errorf("name: want testy, got %s", n.Name)
+	}
+	if len(n.Bootnodes) != 1 || n.DNSDiscovery == "" {
+		t.Errorf("missing discovery settings: %v %s", n.Bootnodes, n.DNSDiscovery)
+	}
+	if n.Checkpoint == nil || n.Checkpoint.SectionIndex != 3 {
+		t.Errorf("checkpoint: got %v", n.Checkpoint)
+	}
+	g := n.Genesis()
+	if *g.Config.GetNetworkID() != 1234 || g.Config.GetChainID().Uint64() != 5678 {
+		t.Errorf("config: got network %d chain %v", *g.Config.GetNetworkID(), g.Config.GetChainID())
+	}
+	if tr := g.Config.GetEIP155Transition(); tr == nil || *tr != 10 {
+		t.Errorf("config: got EIP155 %v", tr)
+	}
+	if g.Nonce != 0x42 || g.GasLimit != 5000 || g.Difficulty.Uint64() != 0x20000 {
+		t.Errorf("genesis: got nonce %d gaslimit %d difficulty %v", g.Nonce, g.GasLimit, g.Difficulty)
+	}
+	if len(g.Alloc) != 1 || g.Alloc[common.BytesToAddress([]byte{1})].Balance.Uint64() != 1 {
+		t.Errorf("genesis: got alloc %v", g.Alloc)
+	}
+	// Every call returns a new copy.
+	g.GasLimit = 1
+	if n.Genesis().GasLimit != 5000 {
+		t.Error("genesis not copied")
+	}
+
+	// Chain specifications may include the genesis.
+	spec, err := ioutil.ReadFile(filepath.Join("confp", "testdata", "stureby_parity.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir = filepath.Join(tmp, "stureby")
+	writeNetworkFiles(t, dir, map[string]string{NetworkConfigFile: string(spec)})
+	n, err = LoadNetwork(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := n.Genesis(); *g.Config.GetNetworkID() != 314158 || len(g.Alloc) == 0 {
+		t.Errorf("stureby: got network %d, %d accounts", *g.Config.GetNetworkID(), len(g.Alloc))
+	}
+
+	// Plain chain configurations need a genesis file.
+	dir = filepath.Join(tmp, "nogenesis")
+	writeNetworkFiles(t, dir, map[string]string{NetworkConfigFile: `{"networkId": 1234, "chainId": 5678, "ethash": {}}`})
+	if _, err := LoadNetwork(dir); err == nil {
+		t.Error("want error for missing genesis")
+	}
+}
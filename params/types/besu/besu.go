@@ -0,0 +1,110 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package besu
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// BesuGenesis represents the genesis file format used by Hyperledger Besu.
+// It resembles go-ethereum's genesis format, but its chain configuration names
+// the Ethereum Classic hard forks, and uses its own consensus engine parameters.
+type BesuGenesis struct {
+	BesuConfig `json:"config"`
+
+	Nonce      math.HexOrDecimal64                              `json:"nonce"`
+	Timestamp  math.HexOrDecimal64                              `json:"timestamp"`
+	ExtraData  hexutil.Bytes                                    `json:"extraData"`
+	GasLimit   math.HexOrDecimal64                              `json:"gasLimit"`
+	Difficulty *math.HexOrDecimal256                            `json:"difficulty"`
+	MixHash    common.Hash                                      `json:"mixHash"`
+	Coinbase   common.Address                                   `json:"coinbase"`
+	ParentHash common.Hash                                      `json:"parentHash"`
+	Alloc      map[common.UnprefixedAddress]*BesuGenesisAccount `json:"alloc"`
+}
+
+// BesuGenesisAccount is an account in the genesis state.
+type BesuGenesisAccount struct {
+	Balance *math.HexOrDecimal256       `json:"balance"`
+	Nonce   math.HexOrDecimal64         `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// BesuConfig is the chain configuration of a Besu genesis.
+// Block numbers of the Ethereum Classic hard forks are kept separately from
+// those of the Ethereum Foundation hard forks they correspond to;
+// see the configurator implementation for how features are assigned to them.
+type BesuConfig struct {
+	// NetworkID is not part of the Besu genesis format; Besu defaults it to the chain ID.
+	NetworkID uint64   `json:"-"`
+	ChainID   *big.Int `json:"chainId"`
+
+	HomesteadBlock      *uint64     `json:"homesteadBlock,omitempty"`
+	DAOForkBlock        *uint64     `json:"daoForkBlock,omitempty"`
+	EIP150Block         *uint64     `json:"eip150Block,omitempty"`
+	EIP150Hash          common.Hash `json:"eip150Hash,omitempty"`
+	EIP155Block         *uint64     `json:"eip155Block,omitempty"`
+	EIP158Block         *uint64     `json:"eip158Block,omitempty"`
+	ByzantiumBlock      *uint64     `json:"byzantiumBlock,omitempty"`
+	ConstantinopleBlock *uint64     `json:"constantinopleBlock,omitempty"`
+	PetersburgBlock     *uint64     `json:"petersburgBlock,omitempty"`
+	IstanbulBlock       *uint64     `json:"istanbulBlock,omitempty"`
+	MuirGlacierBlock    *uint64     `json:"muirGlacierBlock,omitempty"`
+	BerlinBlock         *uint64     `json:"berlinBlock,omitempty"`
+
+	// Ethereum Classic hard forks.
+	ClassicForkBlock  *uint64 `json:"classicForkBlock,omitempty"` // The DAO fork block, not taking the fork
+	ECIP1015Block     *uint64 `json:"ecip1015Block,omitempty"`    // Tangerine Whistle equivalent
+	DieHardBlock      *uint64 `json:"diehardBlock,omitempty"`     // EIP155, EIP160, difficulty bomb pause
+	GothamBlock       *uint64 `json:"gothamBlock,omitempty"`      // ECIP1017 monetary policy, difficulty bomb continue
+	ECIP1041Block     *uint64 `json:"ecip1041Block,omitempty"`    // difficulty bomb removal
+	AtlantisBlock     *uint64 `json:"atlantisBlock,omitempty"`    // Spurious Dragon and Byzantium equivalent
+	AghartaBlock      *uint64 `json:"aghartaBlock,omitempty"`     // Petersburg equivalent
+	PhoenixBlock      *uint64 `json:"phoenixBlock,omitempty"`     // Istanbul equivalent
+	ThanosBlock       *uint64 `json:"thanosBlock,omitempty"`      // ECIP1099 DAG size limit
+	ECIP1049Block     *uint64 `json:"ecip1049Block,omitempty"`    // Keccak-256 proof-of-work
+	ECIP1017EraRounds *uint64 `json:"ecip1017EraRounds,omitempty"`
+
+	Ethash *BesuEthashConfig `json:"ethash,omitempty"`
+	Clique *BesuCliqueConfig `json:"clique,omitempty"`
+
+	// NOTE: These are not part of the Besu genesis format.
+	EIP1706Transition  *uint64 `json:"-"`
+	ECIP1080Transition *uint64 `json:"-"`
+	ECBP1100Transition *uint64 `json:"-"`
+	EIP2537Transition  *uint64 `json:"-"`
+
+	CustomPrecompiles ctypes.CustomPrecompiles `json:"-"`
+}
+
+// BesuEthashConfig holds the Ethash engine parameters.
+type BesuEthashConfig struct {
+	FixedDifficulty  *uint64                    `json:"fixeddifficulty,omitempty"`
+	BlockRewardSplit ctypes.RewardSplitSchedule `json:"blockrewardsplit,omitempty"`
+}
+
+// BesuCliqueConfig holds the Clique engine parameters.
+type BesuCliqueConfig struct {
+	BlockPeriodSeconds uint64 `json:"blockperiodseconds"`
+	EpochLength        uint64 `json:"epochlength"`
+}
@@ -0,0 +1,896 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package besu
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/internal"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// File contains the Besu implementation of the Configurator interface.
+// Like go-ethereum's, Besu's chain configuration is organized by hard forks,
+// so features are read from and written to the hard fork bundling them.
+// Features shared by an Ethereum Foundation hard fork and an Ethereum Classic one
+// are read from the earlier of the two, and written to the Ethereum Classic one
+// if the configuration uses any Ethereum Classic hard fork.
+// Since a hard fork may bundle several features, disabling a single feature
+// does not unset the hard fork.
+
+func newU64(u uint64) *uint64 {
+	return &u
+}
+
+// minBlock returns the lower of two block numbers, either of which may be nil.
+func minBlock(a, b *uint64) *uint64 {
+	if a == nil {
+		return b
+	}
+	if b == nil || *a <= *b {
+		return a
+	}
+	return b
+}
+
+// classic tells if the configuration uses any of the Ethereum Classic hard forks.
+func (c *BesuConfig) classic() bool {
+	for _, b := range []*uint64{
+		c.ClassicForkBlock, c.ECIP1015Block, c.DieHardBlock, c.GothamBlock, c.ECIP1041Block,
+		c.AtlantisBlock, c.AghartaBlock, c.PhoenixBlock, c.ThanosBlock, c.ECIP1049Block, c.ECIP1017EraRounds,
+	} {
+		if b != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// toClassic moves the Ethereum Foundation hard fork block numbers to their
+// corresponding Ethereum Classic hard forks.
+func (c *BesuConfig) toClassic() {
+	if c.classic() {
+		return
+	}
+	c.ECIP1015Block, c.EIP150Block = c.EIP150Block, nil
+	c.DieHardBlock, c.EIP155Block = c.EIP155Block, nil
+	c.AtlantisBlock = minBlock(c.EIP158Block, c.ByzantiumBlock)
+	c.EIP158Block, c.ByzantiumBlock = nil, nil
+	c.AghartaBlock = minBlock(c.ConstantinopleBlock, c.PetersburgBlock)
+	c.ConstantinopleBlock, c.PetersburgBlock = nil, nil
+	c.PhoenixBlock, c.IstanbulBlock = c.IstanbulBlock, nil
+}
+
+// setFork sets the block number of the hard fork bundling a feature.
+// The Ethereum Classic hard fork etc is used if it is not nil,
+// and the configuration uses the Ethereum Classic hard forks.
+func (c *BesuConfig) setFork(eth, etc **uint64, n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	if etc != nil && c.classic() {
+		*etc = newU64(*n)
+		return nil
+	}
+	*eth = newU64(*n)
+	return nil
+}
+
+// setEIP158Fork sets the block number of the hard fork bundling a Spurious Dragon
+// feature other than EIP160. Ethereum Classic activated EIP160 separately from these,
+// so a configuration activating them at a different block than EIP160 uses
+// the Ethereum Classic hard forks.
+func (c *BesuConfig) setEIP158Fork(n *uint64) error {
+	if n != nil && !c.classic() && c.EIP158Block != nil && *c.EIP158Block != *n {
+		eip160 := c.EIP158Block
+		c.EIP158Block = nil
+		c.toClassic()
+		if c.DieHardBlock == nil {
+			c.DieHardBlock = eip160
+		}
+	}
+	return c.setFork(&c.EIP158Block, &c.AtlantisBlock, n)
+}
+
+// setClassicFork sets the block number of an Ethereum Classic hard fork.
+func (c *BesuConfig) setClassicFork(etc **uint64, n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	c.toClassic()
+	*etc = newU64(*n)
+	return nil
+}
+
+func (c *BesuConfig) GetAccountStartNonce() *uint64 {
+	return internal.GlobalConfigurator().GetAccountStartNonce()
+}
+
+func (c *BesuConfig) SetAccountStartNonce(n *uint64) error {
+	return internal.GlobalConfigurator().SetAccountStartNonce(n)
+}
+
+func (c *BesuConfig) GetMaximumExtraDataSize() *uint64 {
+	return internal.GlobalConfigurator().GetMaximumExtraDataSize()
+}
+
+func (c *BesuConfig) SetMaximumExtraDataSize(n *uint64) error {
+	return internal.GlobalConfigurator().SetMaximumExtraDataSize(n)
+}
+
+func (c *BesuConfig) GetMinGasLimit() *uint64 {
+	return internal.GlobalConfigurator().GetMinGasLimit()
+}
+
+func (c *BesuConfig) SetMinGasLimit(n *uint64) error {
+	return internal.GlobalConfigurator().SetMinGasLimit(n)
+}
+
+func (c *BesuConfig) GetGasLimitBoundDivisor() *uint64 {
+	return internal.GlobalConfigurator().GetGasLimitBoundDivisor()
+}
+
+func (c *BesuConfig) SetGasLimitBoundDivisor(n *uint64) error {
+	return internal.GlobalConfigurator().SetGasLimitBoundDivisor(n)
+}
+
+func (c *BesuConfig) GetNetworkID() *uint64 {
+	if c.NetworkID != 0 {
+		return &c.NetworkID
+	}
+	if c.ChainID != nil {
+		return newU64(c.ChainID.Uint64())
+	}
+	return newU64(vars.DefaultNetworkID)
+}
+
+func (c *BesuConfig) SetNetworkID(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	if c.ChainID == nil {
+		c.ChainID = new(big.Int).SetUint64(*n)
+	}
+	c.NetworkID = *n
+	return nil
+}
+
+func (c *BesuConfig) GetChainID() *big.Int {
+	return c.ChainID
+}
+
+func (c *BesuConfig) SetChainID(n *big.Int) error {
+	c.ChainID = n
+	return nil
+}
+
+func (c *BesuConfig) GetMaxCodeSize() *uint64 {
+	return internal.GlobalConfigurator().GetMaxCodeSize()
+}
+
+func (c *BesuConfig) SetMaxCodeSize(n *uint64) error {
+	return internal.GlobalConfigurator().SetMaxCodeSize(n)
+}
+
+func (c *BesuConfig) GetEIP7Transition() *uint64 {
+	return c.HomesteadBlock
+}
+
+func (c *BesuConfig) SetEIP7Transition(n *uint64) error {
+	return c.setFork(&c.HomesteadBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEIP150Transition() *uint64 {
+	return minBlock(c.EIP150Block, c.ECIP1015Block)
+}
+
+func (c *BesuConfig) SetEIP150Transition(n *uint64) error {
+	return c.setFork(&c.EIP150Block, &c.ECIP1015Block, n)
+}
+
+func (c *BesuConfig) GetEIP152Transition() *uint64 {
+	return minBlock(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuConfig) SetEIP152Transition(n *uint64) error {
+	return c.setFork(&c.IstanbulBlock, &c.PhoenixBlock, n)
+}
+
+func (c *BesuConfig) GetEIP160Transition() *uint64 {
+	return minBlock(c.EIP158Block, c.DieHardBlock)
+}
+
+func (c *BesuConfig) SetEIP160Transition(n *uint64) error {
+	return c.setFork(&c.EIP158Block, &c.DieHardBlock, n)
+}
+
+func (c *BesuConfig) GetEIP161dTransition() *uint64 {
+	return minBlock(c.EIP158Block, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP161dTransition(n *uint64) error {
+	return c.setEIP158Fork(n)
+}
+
+func (c *BesuConfig) GetEIP161abcTransition() *uint64 {
+	return minBlock(c.EIP158Block, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP161abcTransition(n *uint64) error {
+	return c.setEIP158Fork(n)
+}
+
+func (c *BesuConfig) GetEIP170Transition() *uint64 {
+	return minBlock(c.EIP158Block, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP170Transition(n *uint64) error {
+	return c.setEIP158Fork(n)
+}
+
+func (c *BesuConfig) GetEIP155Transition() *uint64 {
+	return minBlock(c.EIP155Block, c.DieHardBlock)
+}
+
+func (c *BesuConfig) SetEIP155Transition(n *uint64) error {
+	return c.setFork(&c.EIP155Block, &c.DieHardBlock, n)
+}
+
+func (c *BesuConfig) GetEIP140Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP140Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP198Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP198Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP211Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP211Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP212Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP212Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP213Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP213Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP214Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP214Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP658Transition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEIP658Transition(n *uint64) error {
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEIP145Transition() *uint64 {
+	return minBlock(c.ConstantinopleBlock, c.AghartaBlock)
+}
+
+func (c *BesuConfig) SetEIP145Transition(n *uint64) error {
+	return c.setFork(&c.ConstantinopleBlock, &c.AghartaBlock, n)
+}
+
+func (c *BesuConfig) GetEIP1014Transition() *uint64 {
+	return minBlock(c.ConstantinopleBlock, c.AghartaBlock)
+}
+
+func (c *BesuConfig) SetEIP1014Transition(n *uint64) error {
+	return c.setFork(&c.ConstantinopleBlock, &c.AghartaBlock, n)
+}
+
+func (c *BesuConfig) GetEIP1052Transition() *uint64 {
+	return minBlock(c.ConstantinopleBlock, c.AghartaBlock)
+}
+
+func (c *BesuConfig) SetEIP1052Transition(n *uint64) error {
+	return c.setFork(&c.ConstantinopleBlock, &c.AghartaBlock, n)
+}
+
+// EIP1283 was never activated on Ethereum Classic.
+
+func (c *BesuConfig) GetEIP1283Transition() *uint64 {
+	return c.ConstantinopleBlock
+}
+
+func (c *BesuConfig) SetEIP1283Transition(n *uint64) error {
+	return c.setFork(&c.ConstantinopleBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEIP1283DisableTransition() *uint64 {
+	return c.PetersburgBlock
+}
+
+func (c *BesuConfig) SetEIP1283DisableTransition(n *uint64) error {
+	if c.classic() {
+		return nil
+	}
+	return c.setFork(&c.PetersburgBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEIP1108Transition() *uint64 {
+	return minBlock(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuConfig) SetEIP1108Transition(n *uint64) error {
+	return c.setFork(&c.IstanbulBlock, &c.PhoenixBlock, n)
+}
+
+func (c *BesuConfig) GetEIP2200Transition() *uint64 {
+	return minBlock(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuConfig) SetEIP2200Transition(n *uint64) error {
+	return c.setFork(&c.IstanbulBlock, &c.PhoenixBlock, n)
+}
+
+func (c *BesuConfig) GetEIP2200DisableTransition() *uint64 {
+	return nil
+}
+
+func (c *BesuConfig) SetEIP2200DisableTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuConfig) GetEIP1344Transition() *uint64 {
+	return minBlock(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuConfig) SetEIP1344Transition(n *uint64) error {
+	return c.setFork(&c.IstanbulBlock, &c.PhoenixBlock, n)
+}
+
+func (c *BesuConfig) GetEIP1884Transition() *uint64 {
+	return minBlock(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuConfig) SetEIP1884Transition(n *uint64) error {
+	return c.setFork(&c.IstanbulBlock, &c.PhoenixBlock, n)
+}
+
+func (c *BesuConfig) GetEIP2028Transition() *uint64 {
+	return minBlock(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuConfig) SetEIP2028Transition(n *uint64) error {
+	return c.setFork(&c.IstanbulBlock, &c.PhoenixBlock, n)
+}
+
+func (c *BesuConfig) GetECIP1080Transition() *uint64 {
+	return c.ECIP1080Transition
+}
+
+func (c *BesuConfig) SetECIP1080Transition(n *uint64) error {
+	c.ECIP1080Transition = n
+	return nil
+}
+
+func (c *BesuConfig) GetEIP1706Transition() *uint64 {
+	return c.EIP1706Transition
+}
+
+func (c *BesuConfig) SetEIP1706Transition(n *uint64) error {
+	c.EIP1706Transition = n
+	return nil
+}
+
+func (c *BesuConfig) GetECBP1100Transition() *uint64 {
+	return c.ECBP1100Transition
+}
+
+func (c *BesuConfig) SetECBP1100Transition(n *uint64) error {
+	c.ECBP1100Transition = n
+	return nil
+}
+
+func (c *BesuConfig) GetEIP2718Transition() *uint64 {
+	return c.BerlinBlock
+}
+
+func (c *BesuConfig) SetEIP2718Transition(n *uint64) error {
+	return c.setFork(&c.BerlinBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEIP2929Transition() *uint64 {
+	return c.BerlinBlock
+}
+
+func (c *BesuConfig) SetEIP2929Transition(n *uint64) error {
+	return c.setFork(&c.BerlinBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEIP2537Transition() *uint64 {
+	return c.EIP2537Transition
+}
+
+func (c *BesuConfig) SetEIP2537Transition(n *uint64) error {
+	c.EIP2537Transition = n
+	return nil
+}
+
+func (c *BesuConfig) GetCustomPrecompiles() ctypes.CustomPrecompiles {
+	return c.CustomPrecompiles
+}
+
+func (c *BesuConfig) SetCustomPrecompiles(m ctypes.CustomPrecompiles) error {
+	c.CustomPrecompiles = m
+	return nil
+}
+
+func (c *BesuConfig) IsEnabled(fn func() *uint64, n *big.Int) bool {
+	f := fn()
+	if f == nil || n == nil {
+		return false
+	}
+	return new(big.Int).SetUint64(*f).Cmp(n) <= 0
+}
+
+func (c *BesuConfig) GetForkCanonHash(n uint64) common.Hash {
+	if tr := c.GetEIP150Transition(); tr != nil && *tr == n {
+		return c.EIP150Hash
+	}
+	return common.Hash{}
+}
+
+func (c *BesuConfig) SetForkCanonHash(n uint64, h common.Hash) error {
+	if tr := c.GetEIP150Transition(); tr != nil && *tr == n {
+		c.EIP150Hash = h
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (c *BesuConfig) GetForkCanonHashes() map[uint64]common.Hash {
+	tr := c.GetEIP150Transition()
+	if tr == nil || c.EIP150Hash == (common.Hash{}) {
+		return nil
+	}
+	return map[uint64]common.Hash{
+		*tr: c.EIP150Hash,
+	}
+}
+
+func (c *BesuConfig) GetConsensusEngineType() ctypes.ConsensusEngineT {
+	if c.Clique != nil {
+		return ctypes.ConsensusEngineT_Clique
+	}
+	return ctypes.ConsensusEngineT_Ethash
+}
+
+func (c *BesuConfig) MustSetConsensusEngineType(t ctypes.ConsensusEngineT) error {
+	switch t {
+	case ctypes.ConsensusEngineT_Ethash:
+		c.Ethash = new(BesuEthashConfig)
+		c.Clique = nil
+		return nil
+	case ctypes.ConsensusEngineT_Clique:
+		c.Clique = new(BesuCliqueConfig)
+		c.Ethash = nil
+		return nil
+	default:
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+}
+
+func (c *BesuConfig) GetEthashMinimumDifficulty() *big.Int {
+	return internal.GlobalConfigurator().GetEthashMinimumDifficulty()
+}
+
+func (c *BesuConfig) SetEthashMinimumDifficulty(i *big.Int) error {
+	return internal.GlobalConfigurator().SetEthashMinimumDifficulty(i)
+}
+
+func (c *BesuConfig) GetEthashDifficultyBoundDivisor() *big.Int {
+	return internal.GlobalConfigurator().GetEthashDifficultyBoundDivisor()
+}
+
+func (c *BesuConfig) SetEthashDifficultyBoundDivisor(i *big.Int) error {
+	return internal.GlobalConfigurator().SetEthashDifficultyBoundDivisor(i)
+}
+
+func (c *BesuConfig) GetEthashDurationLimit() *big.Int {
+	return internal.GlobalConfigurator().GetEthashDurationLimit()
+}
+
+func (c *BesuConfig) SetEthashDurationLimit(i *big.Int) error {
+	return internal.GlobalConfigurator().SetEthashDurationLimit(i)
+}
+
+// As with go-ethereum's configurator, the Set_ methods for Ethash features
+// require the Ethash engine to be configured, while the Get_ methods do not.
+
+func (c *BesuConfig) GetEthashHomesteadTransition() *uint64 {
+	return c.HomesteadBlock
+}
+
+func (c *BesuConfig) SetEthashHomesteadTransition(n *uint64) error {
+	return c.setFork(&c.HomesteadBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEthashEIP2Transition() *uint64 {
+	return c.HomesteadBlock
+}
+
+func (c *BesuConfig) SetEthashEIP2Transition(n *uint64) error {
+	return c.setFork(&c.HomesteadBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEthashEIP779Transition() *uint64 {
+	return c.DAOForkBlock
+}
+
+func (c *BesuConfig) SetEthashEIP779Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.DAOForkBlock = n
+	return nil
+}
+
+func (c *BesuConfig) GetEthashEIP649Transition() *uint64 {
+	return c.ByzantiumBlock
+}
+
+func (c *BesuConfig) SetEthashEIP649Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setFork(&c.ByzantiumBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEthashEIP1234Transition() *uint64 {
+	return c.ConstantinopleBlock
+}
+
+func (c *BesuConfig) SetEthashEIP1234Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setFork(&c.ConstantinopleBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEthashEIP2384Transition() *uint64 {
+	return c.MuirGlacierBlock
+}
+
+func (c *BesuConfig) SetEthashEIP2384Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setFork(&c.MuirGlacierBlock, nil, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1010PauseTransition() *uint64 {
+	return c.DieHardBlock
+}
+
+func (c *BesuConfig) SetEthashECIP1010PauseTransition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setClassicFork(&c.DieHardBlock, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1010ContinueTransition() *uint64 {
+	return c.GothamBlock
+}
+
+func (c *BesuConfig) SetEthashECIP1010ContinueTransition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setClassicFork(&c.GothamBlock, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1017Transition() *uint64 {
+	return c.GothamBlock
+}
+
+func (c *BesuConfig) SetEthashECIP1017Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setClassicFork(&c.GothamBlock, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1017EraRounds() *uint64 {
+	return c.ECIP1017EraRounds
+}
+
+func (c *BesuConfig) SetEthashECIP1017EraRounds(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	if n == nil {
+		return nil
+	}
+	c.toClassic()
+	c.ECIP1017EraRounds = newU64(*n)
+	return nil
+}
+
+func (c *BesuConfig) GetEthashEIP100BTransition() *uint64 {
+	return minBlock(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuConfig) SetEthashEIP100BTransition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setFork(&c.ByzantiumBlock, &c.AtlantisBlock, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1041Transition() *uint64 {
+	return c.ECIP1041Block
+}
+
+func (c *BesuConfig) SetEthashECIP1041Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setClassicFork(&c.ECIP1041Block, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1099Transition() *uint64 {
+	return c.ThanosBlock
+}
+
+func (c *BesuConfig) SetEthashECIP1099Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setClassicFork(&c.ThanosBlock, n)
+}
+
+func (c *BesuConfig) GetEthashECIP1049Transition() *uint64 {
+	return c.ECIP1049Block
+}
+
+func (c *BesuConfig) SetEthashECIP1049Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return c.setClassicFork(&c.ECIP1049Block, n)
+}
+
+func (c *BesuConfig) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
+	return nil
+}
+
+func (c *BesuConfig) SetEthashDifficultyBombDelaySchedule(m ctypes.Uint64BigMapEncodesHex) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (c *BesuConfig) GetEthashBlockRewardSchedule() ctypes.Uint64BigMapEncodesHex {
+	return nil
+}
+
+func (c *BesuConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesHex) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (c *BesuConfig) GetEthashBlockRewardSplitSchedule() ctypes.RewardSplitSchedule {
+	if c.Ethash == nil {
+		return nil
+	}
+	return c.Ethash.BlockRewardSplit
+}
+
+func (c *BesuConfig) SetEthashBlockRewardSplitSchedule(m ctypes.RewardSplitSchedule) error {
+	if c.Ethash == nil {
+		if len(m) == 0 {
+			return nil
+		}
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Ethash.BlockRewardSplit = m
+	return nil
+}
+
+func (c *BesuConfig) GetCliquePeriod() uint64 {
+	if c.Clique == nil {
+		return 0
+	}
+	return c.Clique.BlockPeriodSeconds
+}
+
+func (c *BesuConfig) SetCliquePeriod(n uint64) error {
+	if c.Clique == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Clique.BlockPeriodSeconds = n
+	return nil
+}
+
+func (c *BesuConfig) GetCliqueEpoch() uint64 {
+	if c.Clique == nil {
+		return 0
+	}
+	return c.Clique.EpochLength
+}
+
+func (c *BesuConfig) SetCliqueEpoch(n uint64) error {
+	if c.Clique == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Clique.EpochLength = n
+	return nil
+}
+
+func (c *BesuConfig) GetCliqueOnDemandTransition() *uint64 {
+	return nil
+}
+
+func (c *BesuConfig) SetCliqueOnDemandTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuConfig) GetCliqueMaxIdle() uint64 {
+	return 0
+}
+
+func (c *BesuConfig) SetCliqueMaxIdle(n uint64) error {
+	if n == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+// Following methods implement the ctypes.GenesisBlocker interface.
+
+func (g *BesuGenesis) GetSealingType() ctypes.BlockSealingT {
+	return ctypes.BlockSealing_Ethereum
+}
+
+func (g *BesuGenesis) SetSealingType(t ctypes.BlockSealingT) error {
+	if t != ctypes.BlockSealing_Ethereum {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisSealerEthereumNonce() uint64 {
+	return uint64(g.Nonce)
+}
+
+func (g *BesuGenesis) SetGenesisSealerEthereumNonce(n uint64) error {
+	g.Nonce = math.HexOrDecimal64(n)
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisSealerEthereumMixHash() common.Hash {
+	return g.MixHash
+}
+
+func (g *BesuGenesis) SetGenesisSealerEthereumMixHash(h common.Hash) error {
+	g.MixHash = h
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisDifficulty() *big.Int {
+	return (*big.Int)(g.Difficulty)
+}
+
+func (g *BesuGenesis) SetGenesisDifficulty(i *big.Int) error {
+	g.Difficulty = (*math.HexOrDecimal256)(i)
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisAuthor() common.Address {
+	return g.Coinbase
+}
+
+func (g *BesuGenesis) SetGenesisAuthor(a common.Address) error {
+	g.Coinbase = a
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisTimestamp() uint64 {
+	return uint64(g.Timestamp)
+}
+
+func (g *BesuGenesis) SetGenesisTimestamp(u uint64) error {
+	g.Timestamp = math.HexOrDecimal64(u)
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisParentHash() common.Hash {
+	return g.ParentHash
+}
+
+func (g *BesuGenesis) SetGenesisParentHash(h common.Hash) error {
+	g.ParentHash = h
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisExtraData() []byte {
+	return g.ExtraData
+}
+
+func (g *BesuGenesis) SetGenesisExtraData(b []byte) error {
+	g.ExtraData = b
+	return nil
+}
+
+func (g *BesuGenesis) GetGenesisGasLimit() uint64 {
+	return uint64(g.GasLimit)
+}
+
+func (g *BesuGenesis) SetGenesisGasLimit(u uint64) error {
+	g.GasLimit = math.HexOrDecimal64(u)
+	return nil
+}
+
+func (g *BesuGenesis) ForEachAccount(fn func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error) error {
+	for k, v := range g.Alloc {
+		if err := fn(common.Address(k), (*big.Int)(v.Balance), uint64(v.Nonce), v.Code, v.Storage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *BesuGenesis) UpdateAccount(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+	if g.Alloc == nil {
+		g.Alloc = make(map[common.UnprefixedAddress]*BesuGenesisAccount)
+	}
+	g.Alloc[common.UnprefixedAddress(address)] = &BesuGenesisAccount{
+		Balance: (*math.HexOrDecimal256)(bal),
+		Nonce:   math.HexOrDecimal64(nonce),
+		Code:    code,
+		Storage: storage,
+	}
+	return nil
+}
@@ -162,6 +162,18 @@ type CoreGethChainConfig struct {
 	// https://eips.ethereum.org/EIPS/eip-1706
 	EIP1706FBlock *big.Int `json:"eip1706FBlock,omitempty"`
 
+	// EIP-2718: Typed Transaction Envelope
+	// https://eips.ethereum.org/EIPS/eip-2718
+	EIP2718FBlock *big.Int `json:"eip2718FBlock,omitempty"`
+
+	// EIP-2929: Gas cost increases for state access opcodes
+	// https://eips.ethereum.org/EIPS/eip-2929
+	EIP2929FBlock *big.Int `json:"eip2929FBlock,omitempty"`
+
+	// EIP-2537: Precompile for BLS12-381 curve operations
+	// https://eips.ethereum.org/EIPS/eip-2537
+	EIP2537FBlock *big.Int `json:"eip2537FBlock,omitempty"`
+
 	//EWASMBlock *big.Int `json:"ewasmBlock,omitempty"` // EWASM switch block (nil = no fork, 0 = already activated)
 
 	ECIP1010PauseBlock *big.Int `json:"ecip1010PauseBlock,omitempty"` // ECIP1010 pause HF block
@@ -170,6 +182,21 @@ type CoreGethChainConfig struct {
 	ECIP1017EraRounds  *big.Int `json:"ecip1017EraRounds,omitempty"` // ECIP1017 era rounds
 	ECIP1080FBlock     *big.Int `json:"ecip1080FBlock,omitempty"`
 
+	// ECIP-1099: Calibrate Epoch Duration
+	// Doubles the Ethash epoch length from 30000 to 60000 blocks (aka. Etchash).
+	// https://ecips.ethereumclassic.org/ECIPs/ecip-1099
+	ECIP1099FBlock *big.Int `json:"ecip1099FBlock,omitempty"`
+
+	// ECIP-1049: Change the ETC Proof of Work Algorithm to Keccak-256
+	// Replaces Ethash with plain Keccak-256 proof-of-work.
+	// https://ecips.ethereumclassic.org/ECIPs/ecip-1049
+	ECIP1049FBlock *big.Int `json:"ecip1049FBlock,omitempty"`
+
+	// ECBP1100 (MESS) is not a consensus rule change; it is a subjective
+	// chain reorganization rule which modifies the chain selection algorithm.
+	// https://github.com/ethereumclassic/ECIPs/blob/master/_specs/ecip-1100.md
+	ECBP1100FBlock *big.Int `json:"ecbp1100FBlock,omitempty"` // ECBP1100:MESS artificial finality
+
 	DisposalBlock    *big.Int `json:"disposalBlock,omitempty"`    // Bomb disposal HF block
 	SocialBlock      *big.Int `json:"socialBlock,omitempty"`      // Ethereum Social Reward block
 	EthersocialBlock *big.Int `json:"ethersocialBlock,omitempty"` // Ethersocial Reward block
@@ -184,7 +211,15 @@ type CoreGethChainConfig struct {
 	DifficultyBombDelaySchedule ctypes.Uint64BigMapEncodesHex `json:"difficultyBombDelays,omitempty"` // JSON tag matches Parity's
 	BlockRewardSchedule         ctypes.Uint64BigMapEncodesHex `json:"blockReward,omitempty"`          // JSON tag matches Parity's
 
+	// BlockRewardSplitSchedule pays a percentage of the block winner's reward
+	// to a treasury address, from the given blocks on.
+	BlockRewardSplitSchedule ctypes.RewardSplitSchedule `json:"blockRewardSplit,omitempty"`
+
 	RequireBlockHashes map[uint64]common.Hash `json:"requireBlockHashes"`
+
+	// CustomPrecompiles are precompiled contracts installed at arbitrary addresses,
+	// implemented by builtins registered with the EVM.
+	CustomPrecompiles ctypes.CustomPrecompiles `json:"customPrecompiles,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -372,6 +372,51 @@ func (c *CoreGethChainConfig) SetEIP1706Transition(n *uint64) error {
 	return nil
 }
 
+func (c *CoreGethChainConfig) GetECBP1100Transition() *uint64 {
+	return bigNewU64(c.ECBP1100FBlock)
+}
+
+func (c *CoreGethChainConfig) SetECBP1100Transition(n *uint64) error {
+	c.ECBP1100FBlock = setBig(c.ECBP1100FBlock, n)
+	return nil
+}
+
+func (c *CoreGethChainConfig) GetEIP2718Transition() *uint64 {
+	return bigNewU64(c.EIP2718FBlock)
+}
+
+func (c *CoreGethChainConfig) SetEIP2718Transition(n *uint64) error {
+	c.EIP2718FBlock = setBig(c.EIP2718FBlock, n)
+	return nil
+}
+
+func (c *CoreGethChainConfig) GetEIP2929Transition() *uint64 {
+	return bigNewU64(c.EIP2929FBlock)
+}
+
+func (c *CoreGethChainConfig) SetEIP2929Transition(n *uint64) error {
+	c.EIP2929FBlock = setBig(c.EIP2929FBlock, n)
+	return nil
+}
+
+func (c *CoreGethChainConfig) GetEIP2537Transition() *uint64 {
+	return bigNewU64(c.EIP2537FBlock)
+}
+
+func (c *CoreGethChainConfig) SetEIP2537Transition(n *uint64) error {
+	c.EIP2537FBlock = setBig(c.EIP2537FBlock, n)
+	return nil
+}
+
+func (c *CoreGethChainConfig) GetCustomPrecompiles() ctypes.CustomPrecompiles {
+	return c.CustomPrecompiles
+}
+
+func (c *CoreGethChainConfig) SetCustomPrecompiles(m ctypes.CustomPrecompiles) error {
+	c.CustomPrecompiles = m
+	return nil
+}
+
 func (c *CoreGethChainConfig) IsEnabled(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -695,6 +740,30 @@ func (c *CoreGethChainConfig) SetEthashECIP1041Transition(n *uint64) error {
 	return nil
 }
 
+func (c *CoreGethChainConfig) GetEthashECIP1099Transition() *uint64 {
+	return bigNewU64(c.ECIP1099FBlock)
+}
+
+func (c *CoreGethChainConfig) SetEthashECIP1099Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ECIP1099FBlock = setBig(c.ECIP1099FBlock, n)
+	return nil
+}
+
+func (c *CoreGethChainConfig) GetEthashECIP1049Transition() *uint64 {
+	return bigNewU64(c.ECIP1049FBlock)
+}
+
+func (c *CoreGethChainConfig) SetEthashECIP1049Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ECIP1049FBlock = setBig(c.ECIP1049FBlock, n)
+	return nil
+}
+
 func (c *CoreGethChainConfig) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
 	return c.DifficultyBombDelaySchedule
 }
@@ -719,6 +788,18 @@ func (c *CoreGethChainConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMap
 	return nil
 }
 
+func (c *CoreGethChainConfig) GetEthashBlockRewardSplitSchedule() ctypes.RewardSplitSchedule {
+	return c.BlockRewardSplitSchedule
+}
+
+func (c *CoreGethChainConfig) SetEthashBlockRewardSplitSchedule(m ctypes.RewardSplitSchedule) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.BlockRewardSplitSchedule = m
+	return nil
+}
+
 func (c *CoreGethChainConfig) GetCliquePeriod() uint64 {
 	if c.Clique == nil {
 		return 0
@@ -748,3 +829,39 @@ func (c *CoreGethChainConfig) SetCliqueEpoch(n uint64) error {
 	c.Clique.Epoch = n
 	return nil
 }
+
+func (c *CoreGethChainConfig) GetCliqueOnDemandTransition() *uint64 {
+	if c.Clique == nil {
+		return nil
+	}
+	return c.Clique.OnDemandBlock
+}
+
+func (c *CoreGethChainConfig) SetCliqueOnDemandTransition(n *uint64) error {
+	if c.Clique == nil {
+		if n == nil {
+			return nil
+		}
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Clique.OnDemandBlock = n
+	return nil
+}
+
+func (c *CoreGethChainConfig) GetCliqueMaxIdle() uint64 {
+	if c.Clique == nil {
+		return 0
+	}
+	return c.Clique.MaxIdle
+}
+
+func (c *CoreGethChainConfig) SetCliqueMaxIdle(n uint64) error {
+	if c.Clique == nil {
+		if n == 0 {
+			return nil
+		}
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Clique.MaxIdle = n
+	return nil
+}
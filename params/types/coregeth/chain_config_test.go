@@ -2,10 +2,14 @@ package coregeth
 
 import (
 	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
 )
 
 var testConfig = &CoreGethChainConfig{
@@ -48,3 +52,75 @@ func TestCoreGethChainConfig_String(t *testing.T) {
 	t.Skip("(noop) development use only")
 	t.Log(testConfig.String())
 }
+
+func TestCustomPrecompilesForksCompatible(t *testing.T) {
+	keccak := func(activation, word uint64) *CoreGethChainConfig {
+		return &CoreGethChainConfig{
+			Ethash: new(ctypes.EthashConfig),
+			CustomPrecompiles: ctypes.CustomPrecompiles{
+				common.HexToAddress("0x0100"): {
+					Builtin:         "keccak256",
+					Pricing:         ctypes.CustomPrecompilePricing{Linear: &ctypes.LinearPricing{Base: 30, Word: word}},
+					ActivationBlock: &activation,
+				},
+			},
+		}
+	}
+	stored := keccak(100, 6)
+	if err := confp.Convert(stored, new(goethereum.ChainConfig)); err == nil {
+		t.Error("converted custom precompiles to go-ethereum config")
+	}
+	if forks := confp.Forks(stored); !reflect.DeepEqual(forks, []uint64{100}) {
+		t.Errorf("forks mismatch: have %v, want [100]", forks)
+	}
+	tests := []struct {
+		conf   *CoreGethChainConfig
+		head   uint64
+		rewind uint64 // 0 if compatible
+	}{
+		// Pricing changes are compatible only before the precompile activates
+		{conf: keccak(100, 7), head: 99},
+		{conf: keccak(100, 7), head: 100, rewind: 99},
+		// So are activation changes
+		{conf: keccak(200, 6), head: 50},
+		{conf: keccak(200, 6), head: 150, rewind: 99},
+	}
+	for i, tt := range tests {
+		err := confp.Compatible(&tt.head, stored, tt.conf)
+		switch {
+		case tt.rewind == 0 && err != nil:
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		case tt.rewind != 0 && (err == nil || err.RewindTo != tt.rewind):
+			t.Errorf("test %d: error mismatch: have %v, want rewind to %d", i, err, tt.rewind)
+		}
+	}
+}
+
+func TestSetTransition(t *testing.T) {
+	names := confp.TransitionNames()
+	if len(names) == 0 {
+		t.Fatal("no transition names")
+	}
+	for _, name := range names {
+		// Engine specific transitions are only settable with that engine configured
+		conf := &CoreGethChainConfig{Ethash: new(ctypes.EthashConfig)}
+		if strings.HasPrefix(name, "Clique") {
+			conf = &CoreGethChainConfig{Clique: new(ctypes.CliqueConfig)}
+		}
+		n := uint64(42)
+		if err := confp.SetTransition(conf, name, &n); err != nil {
+			t.Errorf("%s: failed to set transition: %v", name, err)
+			continue
+		}
+		if name == "EthashECIP1010Continue" {
+			continue // derived from the unset pause transition
+		}
+		have := reflect.ValueOf(conf).MethodByName("Get" + name + "Transition").Call(nil)[0].Interface().(*uint64)
+		if have == nil || *have != n {
+			t.Errorf("%s: transition mismatch: have %v, want %d", name, have, n)
+		}
+	}
+	if err := confp.SetTransition(new(CoreGethChainConfig), "Foo", nil); err == nil {
+		t.Error("set unknown transition")
+	}
+}
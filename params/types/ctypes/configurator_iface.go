@@ -120,6 +120,16 @@ type ProtocolSpecifier interface {
 	SetECIP1080Transition(n *uint64) error
 	GetEIP1706Transition() *uint64
 	SetEIP1706Transition(n *uint64) error
+	GetECBP1100Transition() *uint64
+	SetECBP1100Transition(n *uint64) error
+	GetEIP2718Transition() *uint64
+	SetEIP2718Transition(n *uint64) error
+	GetEIP2929Transition() *uint64
+	SetEIP2929Transition(n *uint64) error
+	GetEIP2537Transition() *uint64
+	SetEIP2537Transition(n *uint64) error
+	GetCustomPrecompiles() CustomPrecompiles
+	SetCustomPrecompiles(m CustomPrecompiles) error
 }
 
 type Forker interface {
@@ -177,11 +187,17 @@ type EthashConfigurator interface {
 	SetEthashEIP100BTransition(n *uint64) error
 	GetEthashECIP1041Transition() *uint64
 	SetEthashECIP1041Transition(n *uint64) error
+	GetEthashECIP1099Transition() *uint64
+	SetEthashECIP1099Transition(n *uint64) error
+	GetEthashECIP1049Transition() *uint64
+	SetEthashECIP1049Transition(n *uint64) error
 
 	GetEthashDifficultyBombDelaySchedule() Uint64BigMapEncodesHex
 	SetEthashDifficultyBombDelaySchedule(m Uint64BigMapEncodesHex) error
 	GetEthashBlockRewardSchedule() Uint64BigMapEncodesHex
 	SetEthashBlockRewardSchedule(m Uint64BigMapEncodesHex) error
+	GetEthashBlockRewardSplitSchedule() RewardSplitSchedule
+	SetEthashBlockRewardSplitSchedule(m RewardSplitSchedule) error
 }
 
 type CliqueConfigurator interface {
@@ -189,6 +205,10 @@ type CliqueConfigurator interface {
 	SetCliquePeriod(n uint64) error
 	GetCliqueEpoch() uint64
 	SetCliqueEpoch(n uint64) error
+	GetCliqueOnDemandTransition() *uint64
+	SetCliqueOnDemandTransition(n *uint64) error
+	GetCliqueMaxIdle() uint64
+	SetCliqueMaxIdle(n uint64) error
 }
 
 type BlockSealer interface {
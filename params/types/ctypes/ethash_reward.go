@@ -49,3 +49,12 @@ func EthashBlockReward(c ChainConfigurator, n *big.Int) *big.Int {
 
 	return blockReward
 }
+
+// EthashBlockRewardSplit returns the split of the block winner's reward in
+// effect at block n, if any.
+func EthashBlockRewardSplit(c ChainConfigurator, n *big.Int) (RewardSplit, bool) {
+	if c == nil || n == nil {
+		return RewardSplit{}, false
+	}
+	return c.GetEthashBlockRewardSplitSchedule().SplitAt(n.Uint64())
+}
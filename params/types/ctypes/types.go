@@ -164,6 +164,65 @@ func (b Uint64BigMapEncodesHex) MarshalJSON() ([]byte, error) {
 	return json.Marshal(mm)
 }
 
+// RewardSplit is a share of the block winner's reward paid to a treasury address.
+type RewardSplit struct {
+	Address    common.Address `json:"address"`
+	Percentage uint64         `json:"percentage"` // Percentage of the block reward, 0 to 100
+}
+
+// Share returns the part of a block reward paid to the treasury.
+func (s RewardSplit) Share(reward *big.Int) *big.Int {
+	pct := s.Percentage
+	if pct > 100 {
+		pct = 100
+	}
+	share := new(big.Int).Mul(reward, new(big.Int).SetUint64(pct))
+	return share.Div(share, big.NewInt(100))
+}
+
+// RewardSplitSchedule maps activation block numbers to the block reward split
+// in effect from that block on. A zero percentage ends any earlier split.
+// It encodes and decodes w/ JSON hex format keys.
+type RewardSplitSchedule map[uint64]RewardSplit
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (s *RewardSplitSchedule) UnmarshalJSON(input []byte) error {
+	m := make(map[math.HexOrDecimal64]RewardSplit)
+	if err := json.Unmarshal(input, &m); err != nil {
+		return err
+	}
+	ss := make(RewardSplitSchedule, len(m))
+	for k, v := range m {
+		ss[uint64(k)] = v
+	}
+	*s = ss
+	return nil
+}
+
+// MarshalJSON implements the json Marshaler interface.
+func (s RewardSplitSchedule) MarshalJSON() ([]byte, error) {
+	m := make(map[math.HexOrDecimal64]RewardSplit, len(s))
+	for k, v := range s {
+		m[math.HexOrDecimal64(k)] = v
+	}
+	return json.Marshal(m)
+}
+
+// SplitAt returns the block reward split in effect at block n, if any.
+func (s RewardSplitSchedule) SplitAt(n uint64) (RewardSplit, bool) {
+	var (
+		split      RewardSplit
+		activation uint64
+		found      bool
+	)
+	for k, v := range s {
+		if k <= n && (!found || k >= activation) {
+			split, activation, found = v, k, true
+		}
+	}
+	return split, found && split.Percentage > 0
+}
+
 func (b Uint64BigMapEncodesHex) SetValueTotalForHeight(n *uint64, val *big.Int) {
 	if n == nil || val == nil {
 		return
@@ -367,9 +426,53 @@ func (c *EthashConfig) String() string {
 type CliqueConfig struct {
 	Period uint64 `json:"period"` // Number of seconds between blocks to enforce
 	Epoch  uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
+
+	OnDemandBlock *uint64 `json:"onDemandBlock,omitempty"` // Block from which empty blocks are only sealed once idle (nil = never)
+	MaxIdle       uint64  `json:"maxIdle,omitempty"`       // Number of seconds without transactions before an empty block (0 = never)
 }
 
 // String implements the stringer interface, returning the consensus engine details.
 func (c *CliqueConfig) String() string {
 	return "clique"
 }
+
+// CustomPrecompiles maps addresses to precompiled contracts declared by the chain configuration.
+type CustomPrecompiles map[common.Address]CustomPrecompile
+
+// CustomPrecompile is a precompiled contract declared by the chain configuration,
+// modelled on the 'builtin' definitions carried by Parity chain spec accounts.
+// Its implementation is looked up by name in the EVM's builtin registry.
+type CustomPrecompile struct {
+	Builtin         string                  `json:"builtin"`         // Name of the registered builtin implementation
+	Pricing         CustomPrecompilePricing `json:"pricing"`         // Gas pricing formula
+	ActivationBlock *uint64                 `json:"activationBlock"` // Block number from which the precompile is available (nil = never)
+}
+
+// CustomPrecompilePricing is the gas pricing formula of a custom precompile.
+// Exactly one pricing model must be set.
+type CustomPrecompilePricing struct {
+	Linear *LinearPricing `json:"linear,omitempty"`
+}
+
+// LinearPricing charges a base fee plus a fee per 32-byte word of input, rounded up.
+type LinearPricing struct {
+	Base uint64 `json:"base"`
+	Word uint64 `json:"word"`
+}
+
+// RequiredGas returns the gas charged for the given input length.
+// A nil or empty pricing returns the maximum uint64 value; the precompile is then unusable.
+func (p CustomPrecompilePricing) RequiredGas(inputLen int) uint64 {
+	if p.Linear == nil {
+		return math.MaxUint64
+	}
+	words := (uint64(inputLen) + 31) / 32
+	gas, overflow := math.SafeMul(words, p.Linear.Word)
+	if overflow {
+		return math.MaxUint64
+	}
+	if gas, overflow = math.SafeAdd(gas, p.Linear.Base); overflow {
+		return math.MaxUint64
+	}
+	return gas
+}
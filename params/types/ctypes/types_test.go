@@ -247,3 +247,47 @@ func TestUint64BigMapEncodesHex_SetValueTotalForHeight(t *testing.T) {
 	mgTestlike.SetValueTotalForHeight(&five, vars.EIP1234DifficultyBombDelay)
 	check(mgTestlike, mgTestlike.SumValues(&zero), vars.EIP649DifficultyBombDelay.Uint64())
 }
+
+func TestRewardSplitSchedule(t *testing.T) {
+	var s RewardSplitSchedule
+	input := `{"0x0": {"address": "0x0000000000000000000000000000000000000001", "percentage": 10}, "100": {"address": "0x0000000000000000000000000000000000000002", "percentage": 20}, "0xc8": {"address": "0x0000000000000000000000000000000000000002", "percentage": 0}}`
+	if err := json.Unmarshal([]byte(input), &s); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		n     uint64
+		split RewardSplit
+		ok    bool
+	}{
+		{0, RewardSplit{common.HexToAddress("0x1"), 10}, true},
+		{99, RewardSplit{common.HexToAddress("0x1"), 10}, true},
+		{100, RewardSplit{common.HexToAddress("0x2"), 20}, true},
+		{200, RewardSplit{}, false},
+		{1000, RewardSplit{}, false},
+	}
+	for _, c := range cases {
+		split, ok := s.SplitAt(c.n)
+		if ok != c.ok || (ok && split != c.split) {
+			t.Errorf("block %d: got %v %v, want %v %v", c.n, split, ok, c.split, c.ok)
+		}
+	}
+	// Round trip.
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s2 RewardSplitSchedule
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, s2) {
+		t.Errorf("round trip mismatch: got %v, want %v", s2, s)
+	}
+	// Shares round down, and never exceed the reward.
+	if share := (RewardSplit{Percentage: 10}).Share(big.NewInt(99)); share.Cmp(big.NewInt(9)) != 0 {
+		t.Errorf("got share %v, want 9", share)
+	}
+	if share := (RewardSplit{Percentage: 150}).Share(big.NewInt(99)); share.Cmp(big.NewInt(99)) != 0 {
+		t.Errorf("got share %v, want 99", share)
+	}
+}
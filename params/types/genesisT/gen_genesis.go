@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/params/confp/generic"
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	common0 "github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
 	"github.com/ethereum/go-ethereum/params/types/coregeth"
@@ -96,6 +97,9 @@ func (g *Genesis) UnmarshalJSON(input []byte) error {
 	if err != nil {
 		return err
 	}
+	if spec, ok := conf.(*besu.BesuGenesis); ok {
+		return g.setBesuGenesis(spec)
+	}
 
 	switch conf.(type) {
 	case *coregeth.CoreGethChainConfig:
@@ -28,6 +28,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -82,6 +85,14 @@ func (g *Genesis) UpdateAccount(address common.Address, bal *big.Int, nonce uint
 	return nil
 }
 
+// setBesuGenesis sets the genesis from a Besu genesis.
+// Besu genesis files only differ in their chain configuration, which is converted
+// to core-geth's so that the genesis can be stored and used as is.
+func (g *Genesis) setBesuGenesis(spec *besu.BesuGenesis) error {
+	g.Config = &coregeth.CoreGethChainConfig{}
+	return confp.Convert(spec, g)
+}
+
 // GenesisAlloc specifies the initial state that is part of the genesis block.
 type GenesisAlloc map[common.Address]GenesisAccount
 
@@ -536,6 +547,46 @@ func (g Genesis) SetEIP1706Transition(n *uint64) error {
 	return g.Config.SetEIP1706Transition(n)
 }
 
+func (g *Genesis) GetECBP1100Transition() *uint64 {
+	return g.Config.GetECBP1100Transition()
+}
+
+func (g *Genesis) SetECBP1100Transition(n *uint64) error {
+	return g.Config.SetECBP1100Transition(n)
+}
+
+func (g *Genesis) GetEIP2718Transition() *uint64 {
+	return g.Config.GetEIP2718Transition()
+}
+
+func (g *Genesis) SetEIP2718Transition(n *uint64) error {
+	return g.Config.SetEIP2718Transition(n)
+}
+
+func (g *Genesis) GetEIP2929Transition() *uint64 {
+	return g.Config.GetEIP2929Transition()
+}
+
+func (g *Genesis) SetEIP2929Transition(n *uint64) error {
+	return g.Config.SetEIP2929Transition(n)
+}
+
+func (g *Genesis) GetEIP2537Transition() *uint64 {
+	return g.Config.GetEIP2537Transition()
+}
+
+func (g *Genesis) SetEIP2537Transition(n *uint64) error {
+	return g.Config.SetEIP2537Transition(n)
+}
+
+func (g *Genesis) GetCustomPrecompiles() ctypes.CustomPrecompiles {
+	return g.Config.GetCustomPrecompiles()
+}
+
+func (g *Genesis) SetCustomPrecompiles(m ctypes.CustomPrecompiles) error {
+	return g.Config.SetCustomPrecompiles(m)
+}
+
 func (g *Genesis) IsEnabled(fn func() *uint64, n *big.Int) bool {
 	return g.Config.IsEnabled(fn, n)
 }
@@ -680,6 +731,22 @@ func (g *Genesis) SetEthashECIP1041Transition(n *uint64) error {
 	return g.Config.SetEthashECIP1041Transition(n)
 }
 
+func (g *Genesis) GetEthashECIP1099Transition() *uint64 {
+	return g.Config.GetEthashECIP1099Transition()
+}
+
+func (g *Genesis) SetEthashECIP1099Transition(n *uint64) error {
+	return g.Config.SetEthashECIP1099Transition(n)
+}
+
+func (g *Genesis) GetEthashECIP1049Transition() *uint64 {
+	return g.Config.GetEthashECIP1049Transition()
+}
+
+func (g *Genesis) SetEthashECIP1049Transition(n *uint64) error {
+	return g.Config.SetEthashECIP1049Transition(n)
+}
+
 func (g *Genesis) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
 	return g.Config.GetEthashDifficultyBombDelaySchedule()
 }
@@ -696,6 +763,14 @@ func (g *Genesis) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesHex)
 	return g.Config.SetEthashBlockRewardSchedule(m)
 }
 
+func (g *Genesis) GetEthashBlockRewardSplitSchedule() ctypes.RewardSplitSchedule {
+	return g.Config.GetEthashBlockRewardSplitSchedule()
+}
+
+func (g *Genesis) SetEthashBlockRewardSplitSchedule(m ctypes.RewardSplitSchedule) error {
+	return g.Config.SetEthashBlockRewardSplitSchedule(m)
+}
+
 func (g *Genesis) GetCliquePeriod() uint64 {
 	return g.Config.GetCliquePeriod()
 }
@@ -711,3 +786,19 @@ func (g *Genesis) GetCliqueEpoch() uint64 {
 func (g *Genesis) SetCliqueEpoch(n uint64) error {
 	return g.Config.SetCliqueEpoch(n)
 }
+
+func (g *Genesis) GetCliqueOnDemandTransition() *uint64 {
+	return g.Config.GetCliqueOnDemandTransition()
+}
+
+func (g *Genesis) SetCliqueOnDemandTransition(n *uint64) error {
+	return g.Config.SetCliqueOnDemandTransition(n)
+}
+
+func (g *Genesis) GetCliqueMaxIdle() uint64 {
+	return g.Config.GetCliqueMaxIdle()
+}
+
+func (g *Genesis) SetCliqueMaxIdle(n uint64) error {
+	return g.Config.SetCliqueMaxIdle(n)
+}
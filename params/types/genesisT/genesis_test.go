@@ -0,0 +1,80 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package genesisT
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+)
+
+// TestGenesisUnmarshalBesu tests that a Besu genesis is decoded,
+// and its chain configuration converted to core-geth's.
+func TestGenesisUnmarshalBesu(t *testing.T) {
+	raw := `{
+  "config": {
+    "chainId": 63,
+    "classicForkBlock": 0,
+    "ecip1015Block": 0,
+    "diehardBlock": 0,
+    "gothamBlock": 0,
+    "ecip1041Block": 0,
+    "atlantisBlock": 0,
+    "aghartaBlock": 301243,
+    "phoenixBlock": 999983,
+    "ecip1017EraRounds": 2000000,
+    "ethash": {}
+  },
+  "nonce": "0x42",
+  "timestamp": "0x5d9676db",
+  "extraData": "0x70686f656e697820636869636b656e206162737572642062616e616e61",
+  "gasLimit": "0x2fefd8",
+  "difficulty": "0x20000",
+  "alloc": {
+    "0000000000000000000000000000000000000001": {"balance": "0x1"}
+  }
+}`
+	genesis := &Genesis{}
+	if err := json.Unmarshal([]byte(raw), genesis); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := genesis.Config.(*coregeth.CoreGethChainConfig); !ok {
+		t.Fatalf("wrong config type: %T", genesis.Config)
+	}
+	if n := genesis.GetEIP1052Transition(); n == nil || *n != 301243 {
+		t.Errorf("EIP1052 transition: got %v, want 301243", n)
+	}
+	if n := genesis.GetEIP1283Transition(); n != nil {
+		t.Errorf("EIP1283 transition: got %v, want nil", *n)
+	}
+	if n := genesis.GetEthashECIP1017EraRounds(); n == nil || *n != 2000000 {
+		t.Errorf("ECIP1017 era rounds: got %v, want 2000000", n)
+	}
+	if genesis.Nonce != 0x42 || genesis.GasLimit != 0x2fefd8 || genesis.Difficulty.Cmp(big.NewInt(0x20000)) != 0 {
+		t.Errorf("wrong header fields: nonce %#x, gas limit %#x, difficulty %v", genesis.Nonce, genesis.GasLimit, genesis.Difficulty)
+	}
+	if string(genesis.ExtraData) != "phoenix chicken absurd banana" {
+		t.Errorf("wrong extra data: %q", genesis.ExtraData)
+	}
+	acc, ok := genesis.Alloc[common.HexToAddress("0x1")]
+	if !ok || acc.Balance.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("wrong genesis alloc: %v", genesis.Alloc)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 )
 
@@ -16,3 +17,17 @@ func TestChainConfig_converting(t *testing.T) {
 	}
 
 }
+
+func TestChainConfig_SetCustomPrecompiles(t *testing.T) {
+	c := &ChainConfig{}
+	if err := c.SetCustomPrecompiles(nil); err != nil {
+		t.Errorf("failed to set no custom precompiles: %v", err)
+	}
+	activation := uint64(1)
+	precompiles := ctypes.CustomPrecompiles{
+		common.HexToAddress("0x0100"): {Builtin: "keccak256", ActivationBlock: &activation},
+	}
+	if err := c.SetCustomPrecompiles(precompiles); err != ctypes.ErrUnsupportedConfigFatal {
+		t.Errorf("error mismatch: have %v, want %v", err, ctypes.ErrUnsupportedConfigFatal)
+	}
+}
@@ -68,6 +68,10 @@ type ChainConfig struct {
 
 	EIP1706Transition  *big.Int `json:"-"`
 	ECIP1080Transition *big.Int `json:"-"`
+	ECBP1100Transition *big.Int `json:"-"`
+	EIP2718Transition  *big.Int `json:"-"`
+	EIP2929Transition  *big.Int `json:"-"`
+	EIP2537Transition  *big.Int `json:"-"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -382,6 +382,53 @@ func (c *ChainConfig) SetEIP1706Transition(n *uint64) error {
 	return nil
 }
 
+func (c *ChainConfig) GetECBP1100Transition() *uint64 {
+	return bigNewU64(c.ECBP1100Transition)
+}
+
+func (c *ChainConfig) SetECBP1100Transition(n *uint64) error {
+	c.ECBP1100Transition = setBig(c.ECBP1100Transition, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP2718Transition() *uint64 {
+	return bigNewU64(c.EIP2718Transition)
+}
+
+func (c *ChainConfig) SetEIP2718Transition(n *uint64) error {
+	c.EIP2718Transition = setBig(c.EIP2718Transition, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP2929Transition() *uint64 {
+	return bigNewU64(c.EIP2929Transition)
+}
+
+func (c *ChainConfig) SetEIP2929Transition(n *uint64) error {
+	c.EIP2929Transition = setBig(c.EIP2929Transition, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP2537Transition() *uint64 {
+	return bigNewU64(c.EIP2537Transition)
+}
+
+func (c *ChainConfig) SetEIP2537Transition(n *uint64) error {
+	c.EIP2537Transition = setBig(c.EIP2537Transition, n)
+	return nil
+}
+
+func (c *ChainConfig) GetCustomPrecompiles() ctypes.CustomPrecompiles {
+	return nil
+}
+
+func (c *ChainConfig) SetCustomPrecompiles(m ctypes.CustomPrecompiles) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) IsEnabled(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -609,6 +656,28 @@ func (c *ChainConfig) SetEthashECIP1041Transition(i *uint64) error {
 	return ctypes.ErrUnsupportedConfigFatal
 }
 
+func (c *ChainConfig) GetEthashECIP1099Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEthashECIP1099Transition(i *uint64) error {
+	if i == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetEthashECIP1049Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEthashECIP1049Transition(i *uint64) error {
+	if i == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
 	return nil
 }
@@ -625,6 +694,17 @@ func (c *ChainConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesH
 	return ctypes.ErrUnsupportedConfigNoop
 }
 
+func (c *ChainConfig) GetEthashBlockRewardSplitSchedule() ctypes.RewardSplitSchedule {
+	return nil
+}
+
+func (c *ChainConfig) SetEthashBlockRewardSplitSchedule(m ctypes.RewardSplitSchedule) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) GetCliquePeriod() uint64 {
 	if c.Clique == nil {
 		return 0
@@ -654,3 +734,25 @@ func (c *ChainConfig) SetCliqueEpoch(n uint64) error {
 	c.Clique.Epoch = n
 	return nil
 }
+
+func (c *ChainConfig) GetCliqueOnDemandTransition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetCliqueOnDemandTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetCliqueMaxIdle() uint64 {
+	return 0
+}
+
+func (c *ChainConfig) SetCliqueMaxIdle(n uint64) error {
+	if n == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
@@ -385,6 +385,61 @@ func (c *ChainConfig) SetEIP1706Transition(n *uint64) error {
 	return ctypes.ErrUnsupportedConfigFatal
 }
 
+func (c *ChainConfig) GetECBP1100Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetECBP1100Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetEIP2718Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEIP2718Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetEIP2929Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEIP2929Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetEIP2537Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEIP2537Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetCustomPrecompiles() ctypes.CustomPrecompiles {
+	return nil
+}
+
+func (c *ChainConfig) SetCustomPrecompiles(m ctypes.CustomPrecompiles) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) IsEnabled(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -639,6 +694,28 @@ func (c *ChainConfig) SetEthashECIP1041Transition(n *uint64) error {
 	return nil
 }
 
+func (c *ChainConfig) GetEthashECIP1099Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEthashECIP1099Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetEthashECIP1049Transition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetEthashECIP1049Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
 	return nil
 }
@@ -655,6 +732,17 @@ func (c *ChainConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesH
 	return ctypes.ErrUnsupportedConfigNoop
 }
 
+func (c *ChainConfig) GetEthashBlockRewardSplitSchedule() ctypes.RewardSplitSchedule {
+	return nil
+}
+
+func (c *ChainConfig) SetEthashBlockRewardSplitSchedule(m ctypes.RewardSplitSchedule) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) GetCliquePeriod() uint64 {
 	if c.Clique == nil {
 		return 0
@@ -684,3 +772,25 @@ func (c *ChainConfig) SetCliqueEpoch(n uint64) error {
 	c.Clique.Epoch = n
 	return nil
 }
+
+func (c *ChainConfig) GetCliqueOnDemandTransition() *uint64 {
+	return nil
+}
+
+func (c *ChainConfig) SetCliqueOnDemandTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ChainConfig) GetCliqueMaxIdle() uint64 {
+	return 0
+}
+
+func (c *ChainConfig) SetCliqueMaxIdle(n uint64) error {
+	if n == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package nethermind
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// NethermindChainSpec represents the chain specification format used by Nethermind.
+// It extends Parity's chain specification with transitions for features which
+// Parity derives from other fields, eg. from the activation of builtin contracts.
+type NethermindChainSpec struct {
+	parity.ParityChainSpec
+
+	// NethermindParams are decoded from, and encoded to, the "params" field
+	// along with the Parity parameters.
+	NethermindParams NethermindParams `json:"-"`
+}
+
+// NethermindParams are the Nethermind-specific chain specification parameters.
+type NethermindParams struct {
+	EIP152Transition  *parity.ParityU64 `json:"eip152Transition,omitempty"`
+	EIP1108Transition *parity.ParityU64 `json:"eip1108Transition,omitempty"`
+	EIP1706Transition *parity.ParityU64 `json:"eip1706Transition,omitempty"`
+	EIP2200Transition *parity.ParityU64 `json:"eip2200Transition,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (spec *NethermindChainSpec) UnmarshalJSON(input []byte) error {
+	if err := json.Unmarshal(input, &spec.ParityChainSpec); err != nil {
+		return err
+	}
+	var dec struct {
+		Params NethermindParams `json:"params"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	spec.NethermindParams = dec.Params
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (spec NethermindChainSpec) MarshalJSON() ([]byte, error) {
+	params := make(map[string]json.RawMessage)
+	for _, v := range []interface{}{spec.Params, spec.NethermindParams} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &params); err != nil {
+			return nil, err
+		}
+	}
+	// The outer params field takes precedence over the embedded one.
+	enc := struct {
+		*parity.ParityChainSpec
+		Params map[string]json.RawMessage `json:"params"`
+	}{&spec.ParityChainSpec, params}
+	return json.Marshal(enc)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package nethermind
+
+import (
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// File contains the Nethermind overrides of the Parity implementation of the Configurator interface.
+// Getters prefer the Nethermind-specific transition, if any, and setters set both
+// the Nethermind and Parity representations of a feature, so that the
+// specification is understood by either client.
+
+func (spec *NethermindChainSpec) GetEIP152Transition() *uint64 {
+	if spec.NethermindParams.EIP152Transition != nil {
+		return spec.NethermindParams.EIP152Transition.Uint64P()
+	}
+	return spec.ParityChainSpec.GetEIP152Transition()
+}
+
+func (spec *NethermindChainSpec) SetEIP152Transition(n *uint64) error {
+	spec.NethermindParams.EIP152Transition = new(parity.ParityU64).SetUint64(n)
+	return spec.ParityChainSpec.SetEIP152Transition(n)
+}
+
+func (spec *NethermindChainSpec) GetEIP1108Transition() *uint64 {
+	if spec.NethermindParams.EIP1108Transition != nil {
+		return spec.NethermindParams.EIP1108Transition.Uint64P()
+	}
+	return spec.ParityChainSpec.GetEIP1108Transition()
+}
+
+func (spec *NethermindChainSpec) SetEIP1108Transition(n *uint64) error {
+	spec.NethermindParams.EIP1108Transition = new(parity.ParityU64).SetUint64(n)
+	return spec.ParityChainSpec.SetEIP1108Transition(n)
+}
+
+func (spec *NethermindChainSpec) GetEIP1706Transition() *uint64 {
+	if spec.NethermindParams.EIP1706Transition != nil {
+		return spec.NethermindParams.EIP1706Transition.Uint64P()
+	}
+	return spec.ParityChainSpec.GetEIP1706Transition()
+}
+
+func (spec *NethermindChainSpec) SetEIP1706Transition(n *uint64) error {
+	spec.NethermindParams.EIP1706Transition = new(parity.ParityU64).SetUint64(n)
+	return spec.ParityChainSpec.SetEIP1706Transition(n)
+}
+
+func (spec *NethermindChainSpec) GetEIP2200Transition() *uint64 {
+	if spec.NethermindParams.EIP2200Transition != nil {
+		return spec.NethermindParams.EIP2200Transition.Uint64P()
+	}
+	return spec.ParityChainSpec.GetEIP2200Transition()
+}
+
+func (spec *NethermindChainSpec) SetEIP2200Transition(n *uint64) error {
+	spec.NethermindParams.EIP2200Transition = new(parity.ParityU64).SetUint64(n)
+	return spec.ParityChainSpec.SetEIP2200Transition(n)
+}
@@ -42,6 +42,7 @@ type ParityChainSpec struct {
 				DurationLimit          *math.HexOrDecimal256         `json:"durationLimit"`
 				BlockReward            ctypes.Uint64BigValOrMapHex   `json:"blockReward"`
 				DifficultyBombDelays   ctypes.Uint64BigMapEncodesHex `json:"difficultyBombDelays,omitempty"`
+				BlockRewardSplit       ctypes.RewardSplitSchedule    `json:"blockRewardSplit,omitempty"`
 
 				// Caches.
 				// These inferences require computation.
@@ -68,12 +69,17 @@ type ParityChainSpec struct {
 				ECIP1010PauseTransition    *ParityU64 `json:"ecip1010PauseTransition,omitempty"`
 				ECIP1010ContinueTransition *ParityU64 `json:"ecip1010ContinueTransition,omitempty"`
 				ECIP1017EraRounds          *ParityU64 `json:"ecip1017EraRounds,omitempty"`
+				ECIP1099Transition         *ParityU64 `json:"ecip1099Transition,omitempty"`
+				ECIP1049Transition         *ParityU64 `json:"ecip1049Transition,omitempty"`
 			} `json:"params"`
 		} `json:"Ethash,omitempty"`
 		Clique struct {
 			Params struct {
 				Period *ParityU64 `json:"period,omitempty"`
 				Epoch  *ParityU64 `json:"epoch,omitempty"`
+
+				OnDemandTransition *ParityU64 `json:"onDemandTransition,omitempty"`
+				MaxIdle            *ParityU64 `json:"maxIdle,omitempty"`
 			} `json:"params,omitempty"`
 		} `json:"Clique,omitempty"`
 	} `json:"engine"`
@@ -108,6 +114,10 @@ type ParityChainSpec struct {
 		EIP2028Transition         *ParityU64 `json:"eip2028Transition,omitempty"`
 		EIP1706Transition         *ParityU64 `json:"-"` // FIXME, when and if i'm implemented in Parity
 		ECIP1080Transition        *ParityU64 `json:"-"` // FIXME, when and if i'm implemented in Parity
+		ECBP1100Transition        *ParityU64 `json:"-"` // core-geth only; not a consensus rule
+		EIP2718Transition         *ParityU64 `json:"eip2718Transition,omitempty"`
+		EIP2929Transition         *ParityU64 `json:"eip2929Transition,omitempty"`
+		EIP2537Transition         *ParityU64 `json:"eip2537Transition,omitempty"`
 
 		ForkBlock     *ParityU64   `json:"forkBlock,omitempty"`
 		ForkCanonHash *common.Hash `json:"forkCanonHash,omitempty"`
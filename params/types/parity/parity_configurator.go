@@ -477,6 +477,116 @@ func (c *ParityChainSpec) SetEIP1706Transition(n *uint64) error {
 	return nil
 }
 
+func (c *ParityChainSpec) GetECBP1100Transition() *uint64 {
+	return c.Params.ECBP1100Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetECBP1100Transition(n *uint64) error {
+	c.Params.ECBP1100Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP2718Transition() *uint64 {
+	return c.Params.EIP2718Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP2718Transition(n *uint64) error {
+	c.Params.EIP2718Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP2929Transition() *uint64 {
+	return c.Params.EIP2929Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP2929Transition(n *uint64) error {
+	c.Params.EIP2929Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP2537Transition() *uint64 {
+	return c.Params.EIP2537Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP2537Transition(n *uint64) error {
+	c.Params.EIP2537Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+// parityStandardBuiltins are the names and addresses of the builtins implementing
+// the protocol's precompiled contracts, which are configured by the EIP transition
+// methods and are not considered custom precompiles.
+var parityStandardBuiltins = map[string]common.Address{
+	"ecrecover":         common.BytesToAddress([]byte{1}),
+	"sha256":            common.BytesToAddress([]byte{2}),
+	"ripemd160":         common.BytesToAddress([]byte{3}),
+	"identity":          common.BytesToAddress([]byte{4}),
+	"modexp":            common.BytesToAddress([]byte{5}),
+	"alt_bn128_add":     common.BytesToAddress([]byte{6}),
+	"alt_bn128_mul":     common.BytesToAddress([]byte{7}),
+	"alt_bn128_pairing": common.BytesToAddress([]byte{8}),
+	"blake2_f":          common.BytesToAddress([]byte{9}),
+}
+
+// GetCustomPrecompiles returns the linearly priced builtins which are not one of the
+// standard builtins installed at its standard address.
+func (spec *ParityChainSpec) GetCustomPrecompiles() ctypes.CustomPrecompiles {
+	var m ctypes.CustomPrecompiles
+	for addr, acc := range spec.Accounts {
+		if acc == nil || acc.Builtin == nil || acc.Builtin.Pricing == nil {
+			continue
+		}
+		a := common.Address(addr)
+		if std, ok := parityStandardBuiltins[acc.Builtin.Name]; ok && std == a {
+			continue
+		}
+		var (
+			pricing    *ParityChainSpecPricing
+			activation *uint64
+		)
+		if acc.Builtin.Pricing.Map != nil {
+			// Custom precompiles have a single pricing; use the earliest.
+			for k, v := range acc.Builtin.Pricing.Map {
+				if n := k.ToInt().Uint64(); activation == nil || n < *activation {
+					v := v
+					activation, pricing = &n, &v.ParityChainSpecPricing
+				}
+			}
+		} else {
+			pricing, activation = acc.Builtin.Pricing.Pricing, acc.Builtin.ActivateAt.Uint64P()
+		}
+		if pricing == nil || pricing.Linear == nil {
+			continue
+		}
+		if m == nil {
+			m = make(ctypes.CustomPrecompiles)
+		}
+		m[a] = ctypes.CustomPrecompile{
+			Builtin: acc.Builtin.Name,
+			Pricing: ctypes.CustomPrecompilePricing{
+				Linear: &ctypes.LinearPricing{Base: pricing.Linear.Base, Word: pricing.Linear.Word},
+			},
+			ActivationBlock: activation,
+		}
+	}
+	return m
+}
+
+func (spec *ParityChainSpec) SetCustomPrecompiles(m ctypes.CustomPrecompiles) error {
+	for addr, p := range m {
+		if p.Pricing.Linear == nil {
+			return ctypes.ErrUnsupportedConfigFatal
+		}
+		spec.SetPrecompile2(addr, p.Builtin, p.ActivationBlock, ParityChainSpecPricing{
+			Linear: &ParityChainSpecLinearPricing{
+				Base: p.Pricing.Linear.Base,
+				Word: p.Pricing.Linear.Word,
+			},
+		})
+	}
+	return nil
+}
+
 func (spec *ParityChainSpec) IsEnabled(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -761,6 +871,24 @@ func (spec *ParityChainSpec) SetEthashECIP1041Transition(n *uint64) error {
 	return nil
 }
 
+func (spec *ParityChainSpec) GetEthashECIP1099Transition() *uint64 {
+	return spec.Engine.Ethash.Params.ECIP1099Transition.Uint64P()
+}
+
+func (spec *ParityChainSpec) SetEthashECIP1099Transition(n *uint64) error {
+	spec.Engine.Ethash.Params.ECIP1099Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *ParityChainSpec) GetEthashECIP1049Transition() *uint64 {
+	return spec.Engine.Ethash.Params.ECIP1049Transition.Uint64P()
+}
+
+func (spec *ParityChainSpec) SetEthashECIP1049Transition(n *uint64) error {
+	spec.Engine.Ethash.Params.ECIP1049Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
 func (spec *ParityChainSpec) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
 	if reflect.DeepEqual(spec.Engine.Ethash, reflect.Zero(reflect.TypeOf(spec.Engine.Ethash)).Interface()) {
 		return nil
@@ -785,6 +913,15 @@ func (spec *ParityChainSpec) SetEthashBlockRewardSchedule(input ctypes.Uint64Big
 	return nil
 }
 
+func (spec *ParityChainSpec) GetEthashBlockRewardSplitSchedule() ctypes.RewardSplitSchedule {
+	return spec.Engine.Ethash.Params.BlockRewardSplit
+}
+
+func (spec *ParityChainSpec) SetEthashBlockRewardSplitSchedule(input ctypes.RewardSplitSchedule) error {
+	spec.Engine.Ethash.Params.BlockRewardSplit = input
+	return nil
+}
+
 func (spec *ParityChainSpec) GetCliquePeriod() uint64 {
 	p := spec.Engine.Clique.Params.Period.Uint64P()
 	if p == nil {
@@ -811,6 +948,32 @@ func (spec *ParityChainSpec) SetCliqueEpoch(i uint64) error {
 	return nil
 }
 
+func (spec *ParityChainSpec) GetCliqueOnDemandTransition() *uint64 {
+	return spec.Engine.Clique.Params.OnDemandTransition.Uint64P()
+}
+
+func (spec *ParityChainSpec) SetCliqueOnDemandTransition(n *uint64) error {
+	spec.Engine.Clique.Params.OnDemandTransition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *ParityChainSpec) GetCliqueMaxIdle() uint64 {
+	p := spec.Engine.Clique.Params.MaxIdle.Uint64P()
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func (spec *ParityChainSpec) SetCliqueMaxIdle(i uint64) error {
+	if i == 0 {
+		spec.Engine.Clique.Params.MaxIdle = nil
+		return nil
+	}
+	spec.Engine.Clique.Params.MaxIdle = new(ParityU64).SetUint64(&i)
+	return nil
+}
+
 func (spec *ParityChainSpec) GetSealingType() ctypes.BlockSealingT {
 	if !reflect.DeepEqual(spec.Genesis.Seal.Ethereum, reflect.Zero(reflect.TypeOf(spec.Genesis.Seal.Ethereum)).Interface()) {
 		return ctypes.BlockSealing_Ethereum
@@ -17,6 +17,7 @@
 package parity
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/math"
@@ -56,3 +57,22 @@ func TestParityChainSpec_GetSetUint64(t *testing.T) {
 		t.Error("not right answer")
 	}
 }
+
+func TestParityChainSpec_ECIP1099Transition(t *testing.T) {
+	spec := &ParityChainSpec{}
+	n := uint64(11_700_000)
+	if err := spec.SetEthashECIP1099Transition(&n); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &ParityChainSpec{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if v := got.GetEthashECIP1099Transition(); v == nil || *v != n {
+		t.Errorf("mismatch ecip1099Transition: got %v, want %d", v, n)
+	}
+}
@@ -153,6 +153,56 @@ var Forks = map[string]ctypes.ChainConfigurator{
 		PetersburgBlock:     big.NewInt(0),
 		IstanbulBlock:       big.NewInt(0),
 	},
+	"Berlin": &goethereum.ChainConfig{
+		Ethash:              new(ctypes.EthashConfig),
+		ChainID:             big.NewInt(1),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		EIP2718Transition:   big.NewInt(0),
+		EIP2929Transition:   big.NewInt(0),
+	},
+	"ETC_Magneto": &coregeth.CoreGethChainConfig{
+		NetworkID:          1,
+		Ethash:             new(ctypes.EthashConfig),
+		ChainID:            big.NewInt(61),
+		EIP2FBlock:         big.NewInt(0),
+		EIP7FBlock:         big.NewInt(0),
+		EIP150Block:        big.NewInt(0),
+		EIP155Block:        big.NewInt(0),
+		EIP160FBlock:       big.NewInt(0),
+		EIP161FBlock:       big.NewInt(0),
+		EIP170FBlock:       big.NewInt(0),
+		EIP100FBlock:       big.NewInt(0),
+		EIP140FBlock:       big.NewInt(0),
+		EIP198FBlock:       big.NewInt(0),
+		EIP211FBlock:       big.NewInt(0),
+		EIP212FBlock:       big.NewInt(0),
+		EIP213FBlock:       big.NewInt(0),
+		EIP214FBlock:       big.NewInt(0),
+		EIP658FBlock:       big.NewInt(0),
+		EIP145FBlock:       big.NewInt(0),
+		EIP1014FBlock:      big.NewInt(0),
+		EIP1052FBlock:      big.NewInt(0),
+		EIP152FBlock:       big.NewInt(0),
+		EIP1108FBlock:      big.NewInt(0),
+		EIP1344FBlock:      big.NewInt(0),
+		EIP1884FBlock:      big.NewInt(0),
+		EIP2028FBlock:      big.NewInt(0),
+		EIP2200FBlock:      big.NewInt(0), // RePetersburg
+		EIP2718FBlock:      big.NewInt(0),
+		EIP2929FBlock:      big.NewInt(0),
+		DisposalBlock:      big.NewInt(0),
+		ECIP1017FBlock:     big.NewInt(5000000),
+		ECIP1017EraRounds:  big.NewInt(5000000),
+		ECIP1010PauseBlock: nil,
+		ECIP1010Length:     nil,
+	},
 	"FrontierToHomesteadAt5": &goethereum.ChainConfig{
 		Ethash:         new(ctypes.EthashConfig),
 		ChainID:        big.NewInt(1),
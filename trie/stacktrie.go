@@ -0,0 +1,255 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// errStackTrieOrder is returned if the keys are not inserted into a stack trie
+	// in strictly increasing order.
+	errStackTrieOrder = errors.New("stack trie keys not in increasing order")
+
+	// errStackTrieDelete is returned if an empty value is inserted into a stack
+	// trie, deletions being unsupported.
+	errStackTrieDelete = errors.New("stack trie deletion not supported")
+)
+
+const (
+	stEmptyNode = iota
+	stBranchNode
+	stExtNode
+	stLeafNode
+	stHashedNode
+)
+
+// StackTrie is a trie builder for keys of equal length inserted in increasing
+// order. As no key can be inserted on the left of the last one, every subtrie
+// left of its path is complete, so it is hashed right away, flushed into the
+// database and released. This allows regenerating tries of any size, and all
+// their nodes, with a memory usage bounded by the depth of the trie.
+type StackTrie struct {
+	nodeType  uint8                // Node type (empty, branch, extension, leaf or hashed)
+	val       []byte               // Value of a leaf, or the reference to a hashed node
+	key       []byte               // Key nibbles covered by an extension or a leaf
+	keyOffset int                  // Offset of the node key inside the full key
+	children  [16]*StackTrie       // Children of a branch, or the child of an extension
+	db        ethdb.KeyValueWriter // Database to flush the hashed nodes into, if any
+	last      []byte               // Last key inserted, tracked by the root only
+}
+
+// NewStackTrie creates an empty stack trie, writing the nodes it hashes into the
+// given database, if any.
+func NewStackTrie(db ethdb.KeyValueWriter) *StackTrie {
+	return &StackTrie{db: db}
+}
+
+// newStackLeaf creates a leaf covering the nibbles of the given key past the
+// offset.
+func newStackLeaf(offset int, key, value []byte, db ethdb.KeyValueWriter) *StackTrie {
+	return &StackTrie{
+		nodeType:  stLeafNode,
+		val:       value,
+		key:       key[offset:],
+		keyOffset: offset,
+		db:        db,
+	}
+}
+
+// TryUpdate inserts the given value at the given key, which must be greater than
+// all the keys inserted before.
+func (st *StackTrie) TryUpdate(key, value []byte) error {
+	if len(value) == 0 {
+		return errStackTrieDelete
+	}
+	if st.last != nil && bytes.Compare(key, st.last) <= 0 {
+		return errStackTrieOrder
+	}
+	st.last = common.CopyBytes(key)
+
+	hex := keybytesToHex(key)
+	st.insert(hex[:len(hex)-1], common.CopyBytes(value))
+	return nil
+}
+
+// prefixLen returns the number of leading nibbles of the node key shared by the
+// given full key.
+func (st *StackTrie) prefixLen(key []byte) int {
+	return prefixLen(st.key, key[st.keyOffset:])
+}
+
+// insert adds the value at the given key nibbles into the subtrie.
+func (st *StackTrie) insert(key, value []byte) {
+	switch st.nodeType {
+	case stEmptyNode:
+		st.nodeType, st.key, st.val = stLeafNode, key[st.keyOffset:], value
+
+	case stBranchNode:
+		idx := int(key[st.keyOffset])
+
+		// The nearest sibling on the left can't be extended anymore, hash it
+		for i := idx - 1; i >= 0; i-- {
+			if st.children[i] != nil {
+				st.children[i].hash()
+				break
+			}
+		}
+		if st.children[idx] == nil {
+			st.children[idx] = newStackLeaf(st.keyOffset+1, key, value, st.db)
+		} else {
+			st.children[idx].insert(key, value)
+		}
+
+	case stExtNode:
+		diff := st.prefixLen(key)
+		if diff == len(st.key) {
+			st.children[0].insert(key, value)
+			return
+		}
+		// The key diverges inside the extension, the subtrie below it is complete
+		child := st.children[0]
+		if diff < len(st.key)-1 {
+			child = &StackTrie{
+				nodeType:  stExtNode,
+				key:       st.key[diff+1:],
+				keyOffset: st.keyOffset + diff + 1,
+				children:  [16]*StackTrie{st.children[0]},
+				db:        st.db,
+			}
+		}
+		child.hash()
+		st.split(diff, child, key, value)
+
+	case stLeafNode:
+		diff := st.prefixLen(key)
+		if diff == len(st.key) {
+			panic("stack trie key inserted twice")
+		}
+		child := newStackLeaf(diff+1, st.key, st.val, st.db)
+		child.keyOffset += st.keyOffset
+		child.hash()
+		st.split(diff, child, key, value)
+
+	case stHashedNode:
+		panic("stack trie insertion into hashed node")
+	}
+}
+
+// split turns the extension or leaf into a branch at the given number of nibbles
+// of its key, preceded by a shorter extension if the key isn't exhausted, with
+// the node previously covering the key and a new leaf as children.
+func (st *StackTrie) split(diff int, child *StackTrie, key, value []byte) {
+	origIdx, newIdx := st.key[diff], key[st.keyOffset+diff]
+
+	branch := st
+	if diff == 0 {
+		st.nodeType = stBranchNode
+	} else {
+		st.nodeType = stExtNode
+		branch = &StackTrie{
+			nodeType:  stBranchNode,
+			keyOffset: st.keyOffset + diff,
+			db:        st.db,
+		}
+	}
+	st.key, st.val, st.children = st.key[:diff], nil, [16]*StackTrie{}
+	if branch != st {
+		st.children[0] = branch
+	}
+	branch.children[origIdx] = child
+	branch.children[newIdx] = newStackLeaf(branch.keyOffset+1, key, value, st.db)
+}
+
+// ref returns the reference to the hashed node in its parent: the node itself if
+// its encoding is shorter than a hash, or its hash otherwise.
+func (st *StackTrie) ref() node {
+	if len(st.val) < 32 {
+		return rawNode(st.val)
+	}
+	return hashNode(st.val)
+}
+
+// hash collapses the subtrie into its reference, writing the nodes larger than a
+// hash into the database.
+func (st *StackTrie) hash() {
+	var enc []byte
+	switch st.nodeType {
+	case stHashedNode:
+		return
+
+	case stEmptyNode:
+		st.nodeType, st.val = stHashedNode, emptyRoot.Bytes()
+		return
+
+	case stBranchNode:
+		var nodes [17]node
+		for i, child := range st.children {
+			if child == nil {
+				nodes[i] = nilValueNode
+				continue
+			}
+			child.hash()
+			nodes[i] = child.ref()
+		}
+		nodes[16] = nilValueNode
+		enc, _ = rlp.EncodeToBytes(nodes)
+
+	case stExtNode:
+		st.children[0].hash()
+		enc, _ = rlp.EncodeToBytes([]node{valueNode(hexToCompact(st.key)), st.children[0].ref()})
+
+	case stLeafNode:
+		key := append(append([]byte{}, st.key...), 16)
+		enc, _ = rlp.EncodeToBytes([]node{valueNode(hexToCompact(key)), valueNode(st.val)})
+	}
+	st.nodeType, st.key, st.children = stHashedNode, nil, [16]*StackTrie{}
+	if len(enc) < 32 {
+		st.val = enc
+		return
+	}
+	h := newHasher(false)
+	defer returnHasherToPool(h)
+
+	st.val = h.hashData(enc)
+	if st.db != nil {
+		st.db.Put(st.val, enc)
+	}
+}
+
+// Hash returns the root hash of the trie, hashing and flushing all the nodes not
+// yet hashed. No key can be inserted afterwards.
+func (st *StackTrie) Hash() common.Hash {
+	st.hash()
+	if len(st.val) < 32 {
+		// The root is always hashed, even if its encoding is short
+		h := newHasher(false)
+		defer returnHasherToPool(h)
+
+		enc := st.val
+		st.val = h.hashData(enc)
+		if st.db != nil {
+			st.db.Put(st.val, enc)
+		}
+	}
+	return common.BytesToHash(st.val)
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 The core-geth Authors
+// This file is part of the core-geth library.
+//
+// The core-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The core-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the core-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// Tests that a stack trie generates the same root and nodes as a regular trie,
+// for tries of all shapes.
+func TestStackTrieNodes(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 16, 17, 100, 1000} {
+		for _, short := range []bool{false, true} {
+			keys := make([][]byte, size)
+			for i := range keys {
+				keys[i] = crypto.Keccak256(indexBytes(i))
+				if short {
+					// Share long prefixes to create extensions and embedded nodes
+					keys[i] = append(bytes.Repeat([]byte{0xaa}, 30), keys[i][:2]...)
+				}
+			}
+			sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+			var (
+				diskdb = memorydb.New()
+				triedb = NewDatabase(diskdb)
+				tr, _  = New(common.Hash{}, triedb)
+				stdb   = memorydb.New()
+				st     = NewStackTrie(stdb)
+			)
+			for i, key := range keys {
+				if i > 0 && bytes.Equal(key, keys[i-1]) {
+					continue
+				}
+				value := []byte{byte(rand.Intn(256))}
+				if i%3 == 0 {
+					value = bytes.Repeat(value, 40)
+				}
+				tr.Update(key, value)
+				if err := st.TryUpdate(key, value); err != nil {
+					t.Fatalf("size %d: failed to insert key %x: %v", size, key, err)
+				}
+			}
+			root, _ := tr.Commit(nil)
+			triedb.Commit(root, false)
+
+			if have := st.Hash(); have != root {
+				t.Fatalf("size %d, short %v: root mismatch: have %x, want %x", size, short, have, root)
+			}
+			if have, want := stdb.Len(), diskdb.Len(); have != want {
+				t.Errorf("size %d, short %v: node count mismatch: have %d, want %d", size, short, have, want)
+			}
+			it := diskdb.NewIterator(nil, nil)
+			for it.Next() {
+				if blob, _ := stdb.Get(it.Key()); !bytes.Equal(blob, it.Value()) {
+					t.Errorf("size %d, short %v: node %x mismatch: have %x, want %x", size, short, it.Key(), blob, it.Value())
+				}
+			}
+			it.Release()
+		}
+	}
+}
+
+// Tests that keys can only be inserted in increasing order.
+func TestStackTrieOrder(t *testing.T) {
+	st := NewStackTrie(nil)
+	if err := st.TryUpdate([]byte{0x02}, []byte{0x01}); err != nil {
+		t.Fatalf("failed to insert key: %v", err)
+	}
+	for _, key := range [][]byte{{0x01}, {0x02}} {
+		if err := st.TryUpdate(key, []byte{0x01}); err != errStackTrieOrder {
+			t.Errorf("key %x: error mismatch: have %v, want %v", key, err, errStackTrieOrder)
+		}
+	}
+	if err := st.TryUpdate([]byte{0x03}, nil); err != errStackTrieDelete {
+		t.Errorf("deletion error mismatch: have %v, want %v", err, errStackTrieDelete)
+	}
+}
+
+func indexBytes(i int) []byte {
+	return []byte{byte(i >> 24), byte(i >> 16), byte(i >> 8), byte(i)}
+}